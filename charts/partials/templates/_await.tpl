@@ -0,0 +1,16 @@
+{{- define "partials.await" -}}
+env:
+- name: LINKERD2_PROXY_AWAIT_COPY_PATH
+  value: /var/run/linkerd/await/linkerd-await
+image: {{.Values.proxy.image.name}}:{{.Values.proxy.image.version | default .Values.linkerdVersion}}
+imagePullPolicy: {{.Values.proxy.image.pullPolicy | default .Values.imagePullPolicy}}
+name: linkerd-await
+securityContext:
+  allowPrivilegeEscalation: false
+  readOnlyRootFilesystem: true
+  runAsUser: {{.Values.proxy.uid}}
+terminationMessagePolicy: FallbackToLogsOnError
+volumeMounts:
+- mountPath: /var/run/linkerd/await
+  name: linkerd-await
+{{- end -}}
@@ -1,5 +1,10 @@
 {{ define "partials.linkerd.trace" -}}
 {{ if .Values.controlPlaneTracing -}}
-- -trace-collector=collector.{{.Values.controlPlaneTracingNamespace}}.svc.{{.Values.clusterDomain}}:55678
+- -trace-collector=collector.{{.Values.controlPlaneTracingNamespace}}.svc.{{.Values.clusterDomain}}:{{ ternary 4318 4317 (eq .Values.controlPlaneTracingProtocol "http") }}
+- -trace-protocol={{.Values.controlPlaneTracingProtocol}}
+- -trace-sample-ratio={{.Values.controlPlaneTracingSampleRatio}}
+{{ if .Values.controlPlaneTracingResourceAttributes -}}
+- {{ printf "-trace-resource-attributes=%s" .Values.controlPlaneTracingResourceAttributes | quote }}
+{{ end -}}
 {{ end -}}
 {{- end }}
@@ -0,0 +1,488 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	charts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/issuercerts"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/scrypt"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// backupFormatVersion is the version of the layout of the backup
+	// archives, to be bumped when it changes in an incompatible way
+	backupFormatVersion = "v1"
+	backupEncryption    = "scrypt-aes256gcm"
+
+	backupMetadataFile        = "backup.yaml"
+	backupConfigFile          = "config.yaml"
+	backupSecretsFile         = "secrets.yaml"
+	backupServiceProfilesFile = "serviceprofiles.yaml"
+	backupLinksFile           = "links.yaml"
+	encryptedFileSuffix       = ".enc"
+
+	configOverridesSecretName = "linkerd-config-overrides"
+)
+
+// backupFiles are the files of a backup archive holding objects, in the order
+// they are restored
+var backupFiles = []string{
+	backupConfigFile,
+	backupSecretsFile,
+	backupServiceProfilesFile,
+	backupLinksFile,
+}
+
+// backupMetadata is the content of the backup.yaml file of a backup archive
+type backupMetadata struct {
+	FormatVersion  string    `json:"formatVersion"`
+	LinkerdVersion string    `json:"linkerdVersion"`
+	Namespace      string    `json:"namespace"`
+	CreatedAt      time.Time `json:"createdAt"`
+	// Encryption is the scheme the secrets file is encrypted with, if any
+	Encryption string   `json:"encryption,omitempty"`
+	Files      []string `json:"files"`
+}
+
+// controlPlaneBackup holds the objects of a backup archive by file
+type controlPlaneBackup struct {
+	metadata backupMetadata
+	objects  map[string][]*unstructured.Unstructured
+}
+
+func newCmdBackup() *cobra.Command {
+	var passphraseFile string
+
+	cmd := &cobra.Command{
+		Use:   "backup [flags] FILE",
+		Args:  cobra.ExactArgs(1),
+		Short: "Back up the control plane configuration to an archive",
+		Long: `Back up the control plane configuration to an archive.
+
+The archive holds the linkerd-config ConfigMap, the linkerd-config-overrides and
+linkerd-identity-issuer Secrets, and the ServiceProfiles and multicluster Links
+of the cluster along with the Secrets holding the credentials of the Links. It
+can be restored with "linkerd restore".
+
+The Secrets hold the issuer key and the credentials of the target clusters:
+unless they are encrypted with the --passphrase-file flag, the archive must be
+stored as safely as the key itself.`,
+		Example: `  # Back up the control plane, encrypting the issuer key with a passphrase.
+  linkerd backup linkerd-backup.tar.gz --passphrase-file passphrase.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passphrase, err := readPassphrase(passphraseFile)
+			if err != nil {
+				return err
+			}
+
+			k, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			backup, err := collectBackup(cmd.Context(), k, k.DynamicClient)
+			if err != nil {
+				return err
+			}
+
+			file, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			if err := writeBackup(file, backup, passphrase); err != nil {
+				return fmt.Errorf("failed to write the backup archive: %s", err)
+			}
+
+			count := 0
+			for _, objects := range backup.objects {
+				count += len(objects)
+			}
+			fmt.Fprintf(stdout, "Backed up %d objects of Linkerd %s to %s\n", count, backup.metadata.LinkerdVersion, args[0])
+			if passphrase == "" {
+				fmt.Fprintf(stderr, "%s The issuer key isn't encrypted, store the archive safely or use --passphrase-file\n", warnStatus)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&passphraseFile, "passphrase-file", "",
+		"Encrypt the Secrets of the archive with the passphrase read from this file")
+
+	return cmd
+}
+
+// collectBackup fetches the objects to back up, after verifying that the
+// stored configuration can be loaded and that the issuer certificate is
+// valid for the trust anchors
+func collectBackup(ctx context.Context, k *k8s.KubernetesAPI, client dynamic.Interface) (*controlPlaneBackup, error) {
+	values, err := loadStoredValues(ctx, k)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the stored configuration: %s", err)
+	}
+	if values == nil {
+		return nil, errors.New(`Could not find the Linkerd config. If Linkerd was installed with Helm, please
+back up its values with Helm. If Linkerd was not installed with Helm, please use
+the 'linkerd repair' command to repair the Linkerd config`)
+	}
+
+	linkerdVersion, err := healthcheck.GetServerVersion(ctx, controlPlaneNamespace, k)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := k.CoreV1().ConfigMaps(controlPlaneNamespace).Get(ctx, k8s.ConfigConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := k.CoreV1().Secrets(controlPlaneNamespace).Get(ctx, configOverridesSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := k.CoreV1().Secrets(controlPlaneNamespace).Get(ctx, k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyIssuer(values, issuer); err != nil {
+		return nil, err
+	}
+
+	backup := &controlPlaneBackup{
+		metadata: backupMetadata{
+			FormatVersion:  backupFormatVersion,
+			LinkerdVersion: linkerdVersion,
+			Namespace:      controlPlaneNamespace,
+			CreatedAt:      time.Now().UTC().Truncate(time.Second),
+		},
+		objects: map[string][]*unstructured.Unstructured{},
+	}
+
+	for file, objects := range map[string][]runtime.Object{
+		backupConfigFile:  {config},
+		backupSecretsFile: {overrides, issuer},
+	} {
+		for _, obj := range objects {
+			u, err := toUnstructured(obj)
+			if err != nil {
+				return nil, err
+			}
+			backup.objects[file] = append(backup.objects[file], u)
+		}
+	}
+
+	for file, gvr := range map[string]schema.GroupVersionResource{
+		backupServiceProfilesFile: k8s.ServiceProfileGVR,
+		backupLinksFile:           multicluster.LinkGVR,
+	} {
+		list, err := client.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if kerrors.IsNotFound(err) {
+			// the CRD isn't installed
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the %s: %s", gvr.Resource, err)
+		}
+		for i := range list.Items {
+			backup.objects[file] = append(backup.objects[file], &list.Items[i])
+		}
+	}
+
+	for _, link := range backup.objects[backupLinksFile] {
+		credentials, err := linkCredentials(ctx, k, link)
+		if err != nil {
+			return nil, err
+		}
+		backup.objects[backupSecretsFile] = append(backup.objects[backupSecretsFile], credentials)
+	}
+
+	for _, objects := range backup.objects {
+		for _, obj := range objects {
+			removeServerFields(obj.Object)
+		}
+	}
+	return backup, nil
+}
+
+// linkCredentials returns the Secret holding the credentials of the target
+// cluster of the Link, which lives in the namespace of the Link
+func linkCredentials(ctx context.Context, k *k8s.KubernetesAPI, link *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	name, found, err := unstructured.NestedString(link.Object, "spec", "clusterCredentialsSecret")
+	if err != nil || !found || name == "" {
+		return nil, fmt.Errorf("the Link %s/%s has no cluster credentials Secret", link.GetNamespace(), link.GetName())
+	}
+	secret, err := k.CoreV1().Secrets(link.GetNamespace()).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the credentials of the Link %s/%s: %s", link.GetNamespace(), link.GetName(), err)
+	}
+	return toUnstructured(secret)
+}
+
+// verifyIssuer verifies that the issuer certificate of the secret is valid
+// for the trust anchors of the configuration
+func verifyIssuer(values *charts.Values, secret *corev1.Secret) error {
+	var data *issuercerts.IssuerCertData
+	var err error
+	if values.Identity.Issuer.Scheme == string(corev1.SecretTypeTLS) {
+		data, err = issuercerts.ExternalIssuerDataFromSecret(secret)
+	} else {
+		data, err = issuercerts.IssuerDataFromSecret(secret, values.IdentityTrustAnchorsPEM)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := data.VerifyAndBuildCreds(); err != nil {
+		return fmt.Errorf("invalid issuer certificate: %s", err)
+	}
+	return nil
+}
+
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		u.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	case *corev1.Secret:
+		u.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	}
+	return u, nil
+}
+
+// writeBackup writes the backup as a gzipped tarball, encrypting the secrets
+// file if passphrase isn't empty
+func writeBackup(w io.Writer, backup *controlPlaneBackup, passphrase string) error {
+	files := map[string][]byte{}
+	metadata := backup.metadata
+	metadata.Files = []string{}
+	if passphrase != "" {
+		metadata.Encryption = backupEncryption
+	}
+
+	for _, file := range backupFiles {
+		objects, ok := backup.objects[file]
+		if !ok {
+			continue
+		}
+		var buf bytes.Buffer
+		for _, obj := range objects {
+			data, err := yaml.Marshal(obj.Object)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(yamlSep)
+			buf.Write(data)
+		}
+
+		name, data := file, buf.Bytes()
+		if file == backupSecretsFile && passphrase != "" {
+			var err error
+			if data, err = encrypt(data, passphrase); err != nil {
+				return err
+			}
+			name += encryptedFileSuffix
+		}
+		files[name] = data
+		metadata.Files = append(metadata.Files, name)
+	}
+
+	metadataBytes, err := yaml.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	files[backupMetadataFile] = metadataBytes
+	return writeTarGz(w, append([]string{backupMetadataFile}, metadata.Files...), files, metadata.CreatedAt)
+}
+
+// writeTarGz writes the files as a gzipped tarball, in the order of names
+func writeTarGz(w io.Writer, names []string, files map[string][]byte, modTime time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		data := files[name]
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// readBackup reads a backup archive written by writeBackup. The passphrase
+// is required if the secrets file is encrypted.
+func readBackup(r io.Reader, passphrase string) (*controlPlaneBackup, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %s", err)
+	}
+	tr := tar.NewReader(gz)
+	files := map[string][]byte{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[header.Name] = data
+	}
+
+	metadataBytes, ok := files[backupMetadataFile]
+	if !ok {
+		return nil, fmt.Errorf("not a backup archive: %s is missing", backupMetadataFile)
+	}
+	backup := &controlPlaneBackup{objects: map[string][]*unstructured.Unstructured{}}
+	if err := yaml.Unmarshal(metadataBytes, &backup.metadata); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", backupMetadataFile, err)
+	}
+	if backup.metadata.FormatVersion != backupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %q, this CLI supports version %q", backup.metadata.FormatVersion, backupFormatVersion)
+	}
+
+	for _, name := range backup.metadata.Files {
+		data, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid backup archive: %s is missing", name)
+		}
+		file := name
+		if strings.HasSuffix(name, encryptedFileSuffix) {
+			if backup.metadata.Encryption != backupEncryption {
+				return nil, fmt.Errorf("unsupported encryption %q", backup.metadata.Encryption)
+			}
+			if passphrase == "" {
+				return nil, errors.New("the archive is encrypted, please provide its passphrase with --passphrase-file")
+			}
+			if data, err = decrypt(data, passphrase); err != nil {
+				return nil, err
+			}
+			file = strings.TrimSuffix(name, encryptedFileSuffix)
+		}
+		objects, err := parseManifest(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", name, err)
+		}
+		backup.objects[file] = objects
+	}
+	return backup, nil
+}
+
+const (
+	scryptSaltSize = 16
+	// the scrypt parameters recommended for interactive logins in 2017
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// encrypt encrypts data with AES-256-GCM, using a key derived from the
+// passphrase with scrypt. The salt and nonce are prepended to the result.
+func encrypt(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, scryptSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newBackupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	result := append(salt, nonce...)
+	return gcm.Seal(result, nonce, data, nil), nil
+}
+
+func decrypt(data []byte, passphrase string) ([]byte, error) {
+	if len(data) < scryptSaltSize {
+		return nil, errors.New("invalid encrypted data")
+	}
+	salt, data := data[:scryptSaltSize], data[scryptSaltSize:]
+	gcm, err := newBackupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("invalid encrypted data")
+	}
+	nonce, data := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt the archive, the passphrase may be wrong")
+	}
+	return plaintext, nil
+}
+
+func newBackupCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// readPassphrase returns the first line of the file, or an empty passphrase
+// if path is empty
+func readPassphrase(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	passphrase := strings.TrimRight(strings.SplitN(string(data), "\n", 2)[0], "\r")
+	if passphrase == "" {
+		return "", fmt.Errorf("the passphrase file %s is empty", path)
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBackupArchive(t *testing.T) {
+	backup := &controlPlaneBackup{
+		metadata: backupMetadata{
+			FormatVersion:  backupFormatVersion,
+			LinkerdVersion: "stable-2.10.2",
+			Namespace:      "linkerd",
+		},
+		objects: map[string][]*unstructured.Unstructured{
+			backupConfigFile: {
+				newUnstructured(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, "linkerd", "linkerd-config", nil),
+			},
+			backupSecretsFile: {
+				newUnstructured(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, "linkerd", "linkerd-identity-issuer", nil),
+			},
+		},
+	}
+
+	for _, passphrase := range []string{"", "correct horse battery staple"} {
+		var buf bytes.Buffer
+		if err := writeBackup(&buf, backup, passphrase); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if passphrase != "" && bytes.Contains(buf.Bytes(), []byte("linkerd-identity-issuer")) {
+			t.Error("Expected the secrets to be encrypted")
+		}
+		archive := buf.Bytes()
+
+		actual, err := readBackup(bytes.NewReader(archive), passphrase)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(actual.objects, backup.objects) {
+			t.Errorf("Expected objects\n%+v\nbut got\n%+v", backup.objects, actual.objects)
+		}
+
+		if passphrase != "" {
+			if _, err := readBackup(bytes.NewReader(archive), ""); err == nil {
+				t.Error("Expected an error without passphrase")
+			}
+			if _, err := readBackup(bytes.NewReader(archive), "wrong"); err == nil {
+				t.Error("Expected an error with a wrong passphrase")
+			}
+		}
+	}
+
+	backup.metadata.FormatVersion = "v0"
+	var buf bytes.Buffer
+	if err := writeBackup(&buf, backup, ""); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := readBackup(&buf, ""); err == nil || !strings.Contains(err.Error(), "unsupported backup format version") {
+		t.Errorf("Expected an unsupported version error, got %v", err)
+	}
+}
+
+func TestCollectBackup(t *testing.T) {
+	values, err := testInstallValues()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	valuesBytes, err := yaml.Marshal(values)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	overridesBytes, err := yaml.Marshal(map[string]interface{}{
+		"identityTrustAnchorsPEM": values.IdentityTrustAnchorsPEM,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	k, err := k8s.NewFakeAPI(fmt.Sprintf(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  values: %q
+`, valuesBytes), fmt.Sprintf(`
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-config-overrides
+  namespace: linkerd
+  resourceVersion: "42"
+data:
+  linkerd-config-overrides: %s
+`, base64.StdEncoding.EncodeToString(overridesBytes)), fmt.Sprintf(`
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-identity-issuer
+  namespace: linkerd
+data:
+  crt.pem: %s
+  key.pem: %s
+`, base64.StdEncoding.EncodeToString([]byte(values.Identity.Issuer.TLS.CrtPEM)),
+		base64.StdEncoding.EncodeToString([]byte(values.Identity.Issuer.TLS.KeyPEM))), `
+kind: Secret
+apiVersion: v1
+metadata:
+  name: cluster-credentials-east
+  namespace: linkerd-multicluster
+data:
+  kubeconfig: a3ViZWNvbmZpZw==
+`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	profile := newUnstructured(schema.GroupVersionKind{Group: "linkerd.io", Version: "v1alpha2", Kind: "ServiceProfile"},
+		"emojivoto", "web-svc.emojivoto.svc.cluster.local", nil)
+	link := newUnstructured(schema.GroupVersionKind{Group: "multicluster.linkerd.io", Version: "v1alpha1", Kind: "Link"},
+		"linkerd-multicluster", "east", nil)
+	link.Object["spec"] = map[string]interface{}{"clusterCredentialsSecret": "cluster-credentials-east"}
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		k8s.ServiceProfileGVR: "ServiceProfileList",
+		multicluster.LinkGVR:  "LinkList",
+	}, profile, link)
+
+	backup, err := collectBackup(context.Background(), k, client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if backup.metadata.LinkerdVersion != installControlPlaneVersion {
+		t.Errorf("Expected version %s, got %s", installControlPlaneVersion, backup.metadata.LinkerdVersion)
+	}
+
+	counts := map[string]int{}
+	for file, objects := range backup.objects {
+		counts[file] = len(objects)
+		for _, obj := range objects {
+			if obj.GetResourceVersion() != "" {
+				t.Errorf("Expected the resourceVersion of %s to be removed", obj.GetName())
+			}
+		}
+	}
+	expected := map[string]int{
+		backupConfigFile:          1,
+		backupSecretsFile:         3,
+		backupServiceProfilesFile: 1,
+		backupLinksFile:           1,
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected objects %v, got %v", expected, counts)
+	}
+
+	if err := verifyBackup(backup); err != nil {
+		t.Errorf("Unexpected error verifying the backup: %s", err)
+	}
+}
@@ -0,0 +1,471 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	pkgcmd "github.com/linkerd/linkerd2/pkg/cmd"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	bundleMetadataFile = "bundle.yaml"
+	bundleCheckFile    = "check.json"
+	bundleConfigFile   = "config/linkerd-config.yaml"
+
+	redacted = "REDACTED"
+)
+
+// redactions replace the secrets and tokens found in the files of a bundle.
+// The matches for which keep returns true are left as is.
+var redactions = []struct {
+	re   *regexp.Regexp
+	repl string
+	keep func(submatches [][]byte) bool
+}{
+	{
+		re:   regexp.MustCompile(`(?s)-----BEGIN ([A-Z ]*)PRIVATE KEY-----.*?-----END ([A-Z ]*)PRIVATE KEY-----`),
+		repl: "-----BEGIN ${1}PRIVATE KEY-----\n" + redacted + "\n-----END ${2}PRIVATE KEY-----",
+	},
+	{
+		re:   regexp.MustCompile(`(?i)(bearer[ \t]+)[A-Za-z0-9._~+/-]+=*`),
+		repl: "${1}" + redacted,
+	},
+	{
+		re:   regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+		repl: redacted,
+	},
+	{
+		re:   regexp.MustCompile(`(?i)(([A-Za-z0-9_.-]*(?:password|passwd|secret|token|api[_-]?key))["']?[ \t]*[:=][ \t]*["']?)([^\s"',}&]+)`),
+		repl: "${1}" + redacted,
+		keep: func(submatches [][]byte) bool {
+			key, value := strings.ToLower(string(submatches[2])), strings.ToLower(string(submatches[3]))
+			return nonSecretFields[key] || value == "true" || value == "false"
+		},
+	},
+}
+
+// nonSecretFields are the lowercased fields whose name ends like the ones
+// holding secrets or tokens, but which only refer to them or toggle them
+var nonSecretFields = map[string]bool{
+	"automountserviceaccounttoken": true,
+	"clustercredentialssecret":     true,
+	"externalsecret":               true,
+}
+
+// sensitiveEnvName matches the names of the environment variables whose value
+// is redacted from the pod manifests
+var sensitiveEnvName = regexp.MustCompile(`(?i)password|passwd|secret|token|key|credential`)
+
+type bundleOptions struct {
+	namespace string
+	dataPlane []string
+	logLines  int64
+	wait      time.Duration
+}
+
+// bundleMetadata is the content of the bundle.yaml file of a bundle
+type bundleMetadata struct {
+	CreatedAt           time.Time `json:"createdAt"`
+	CLIVersion          string    `json:"cliVersion"`
+	ControlPlaneVersion string    `json:"controlPlaneVersion,omitempty"`
+	KubernetesVersion   string    `json:"kubernetesVersion,omitempty"`
+	Namespaces          []string  `json:"namespaces"`
+	Files               []string  `json:"files"`
+	// Errors are the failures to collect some of the diagnostics, which
+	// don't prevent the bundle from being written
+	Errors []string `json:"errors,omitempty"`
+}
+
+// bundle holds the redacted files of a diagnostics bundle
+type bundle struct {
+	metadata bundleMetadata
+	files    map[string][]byte
+}
+
+func newBundleOptions() *bundleOptions {
+	return &bundleOptions{
+		dataPlane: []string{},
+		logLines:  1000,
+		wait:      30 * time.Second,
+	}
+}
+
+func newBundle(createdAt time.Time) *bundle {
+	return &bundle{
+		metadata: bundleMetadata{
+			CreatedAt:  createdAt,
+			CLIVersion: version.Version,
+			Namespaces: []string{},
+			Files:      []string{},
+		},
+		files: map[string][]byte{},
+	}
+}
+
+func newCmdBundle() *cobra.Command {
+	options := newBundleOptions()
+
+	cmd := &cobra.Command{
+		Use:   "bundle [flags] [FILE]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Collect the diagnostics of the Linkerd installation into an archive",
+		Long: `Collect the diagnostics of the Linkerd installation into an archive.
+
+  This command gathers what is needed to report an issue into a gzipped
+  tarball:
+  * the versions of the CLI, the control plane and Kubernetes
+  * the JSON output of the core and extension checks
+  * the linkerd-config ConfigMap
+  * the events of the control plane and extension namespaces
+  * the manifests, logs and metrics of the control plane and extension pods
+
+  The data-plane pods of the resources given with --data-plane are collected
+  as well. Secrets aren't collected, and the tokens, passwords and private keys
+  found in the collected files are redacted. The archive should still be
+  reviewed before being shared.
+
+  FILE defaults to linkerd-bundle-<timestamp>.tar.gz.`,
+		Example: `  # Collect the diagnostics of the control plane and the extensions.
+  linkerd diagnostics bundle
+
+  # Also collect the diagnostics of the web deployment in the emojivoto namespace.
+  linkerd diagnostics bundle -n emojivoto --data-plane deploy/web bundle.tar.gz`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.namespace == "" {
+				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			}
+			createdAt := time.Now().UTC()
+			file := fmt.Sprintf("linkerd-bundle-%s.tar.gz", createdAt.Format("20060102-150405"))
+			if len(args) == 1 {
+				file = args[0]
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+			extensionNamespaces, err := k8sAPI.GetAllNamespacesWithExtensionLabel(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			b := newBundle(createdAt)
+			extensions := make([]string, len(extensionNamespaces))
+			for i, ns := range extensionNamespaces {
+				extensions[i] = ns.Labels[k8s.LinkerdExtensionLabel]
+			}
+			collectChecks(cmd.Context(), b, extensions, bundleCheckFlags())
+			collectBundle(cmd.Context(), b, k8sAPI, extensionNamespaces, options)
+
+			out, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+			if err := writeBundle(out, b); err != nil {
+				return fmt.Errorf("failed to write the bundle: %s", err)
+			}
+
+			for _, e := range b.metadata.Errors {
+				fmt.Fprintf(stderr, "%s %s\n", warnStatus, e)
+			}
+			fmt.Fprintf(stdout, "Collected %d files from %d namespaces to %s\n", len(b.metadata.Files), len(b.metadata.Namespaces), file)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the --data-plane resources")
+	cmd.Flags().StringArrayVar(&options.dataPlane, "data-plane", options.dataPlane,
+		"Also collect the pods of this resource (TYPE/NAME), can be repeated")
+	cmd.Flags().Int64Var(&options.logLines, "log-lines", options.logLines, "Number of lines collected from the end of each container log")
+	cmd.Flags().DurationVarP(&options.wait, "wait", "w", options.wait, "Time allowed to fetch the metrics of the pods")
+
+	pkgcmd.ConfigureNamespaceFlagCompletion(cmd, []string{"namespace"},
+		kubeconfigPath, impersonate, impersonateGroup, kubeContext)
+
+	return cmd
+}
+
+// bundleCheckFlags returns the flags of the check commands of the extensions.
+// They are built from the global flags only, as the --namespace and --wait
+// flags of the bundle have another meaning for the checks. The checks aren't
+// retried, so that the bundle holds the current state of the installation.
+func bundleCheckFlags() []string {
+	flags := []string{}
+	for _, flag := range []struct{ name, value string }{
+		{"api-addr", apiAddr},
+		{"context", kubeContext},
+		{"as", impersonate},
+		{"kubeconfig", kubeconfigPath},
+		{"linkerd-namespace", controlPlaneNamespace},
+	} {
+		if flag.value != "" {
+			flags = append(flags, fmt.Sprintf("--%s=%s", flag.name, flag.value))
+		}
+	}
+	for _, group := range impersonateGroup {
+		flags = append(flags, fmt.Sprintf("--as-group=%s", group))
+	}
+	if verbose {
+		flags = append(flags, "--verbose")
+	}
+	return append(flags, "--wait=0s", "--output=json")
+}
+
+// collectChecks adds the JSON output of the core checks, and of the checks of
+// the extensions, which are run by their check command
+func collectChecks(ctx context.Context, b *bundle, extensions []string, flags []string) {
+	checks := append([]healthcheck.CategoryID{
+		healthcheck.KubernetesAPIChecks,
+		healthcheck.KubernetesVersionChecks,
+		healthcheck.LinkerdVersionChecks,
+	}, controlPlaneChecks("", false)...)
+	hc := healthcheck.NewHealthChecker(checks, &healthcheck.Options{
+		ControlPlaneNamespace: controlPlaneNamespace,
+		CNINamespace:          cniNamespace,
+		KubeConfig:            kubeconfigPath,
+		KubeContext:           kubeContext,
+		Impersonate:           impersonate,
+		ImpersonateGroup:      impersonateGroup,
+		APIAddr:               apiAddr,
+		RetryDeadline:         time.Now(),
+	})
+	var buf bytes.Buffer
+	healthcheck.RunChecks(&buf, &buf, hc, jsonOutput)
+	b.add(bundleCheckFile, buf.Bytes())
+
+	for _, extension := range extensions {
+		command, args, err := healthcheck.ExtensionCheckCommand(extension, flags)
+		if err != nil {
+			b.addError("failed to run the checks of the %s extension: %s", extension, err)
+			continue
+		}
+		// the command fails when a check fails, its output is still valid
+		output, err := exec.CommandContext(ctx, command, args...).Output()
+		if len(output) == 0 && err != nil {
+			b.addError("failed to run the checks of the %s extension: %s", extension, err)
+			continue
+		}
+		b.add(fmt.Sprintf("checks/%s.json", extension), output)
+	}
+}
+
+// collectBundle adds the versions, the configuration, and the events and pods
+// of the control plane, extension and data-plane namespaces. The failures are
+// recorded in the bundle, which holds whatever could be collected.
+func collectBundle(ctx context.Context, b *bundle, k *k8s.KubernetesAPI, extensionNamespaces []corev1.Namespace, options *bundleOptions) {
+	if versionInfo, err := k.GetVersionInfo(); err != nil {
+		b.addError("failed to get the Kubernetes version: %s", err)
+	} else {
+		b.metadata.KubernetesVersion = versionInfo.GitVersion
+	}
+	if serverVersion, err := healthcheck.GetServerVersion(ctx, controlPlaneNamespace, k); err != nil {
+		b.addError("failed to get the control plane version: %s", err)
+	} else {
+		b.metadata.ControlPlaneVersion = serverVersion
+	}
+
+	if cm, err := k.CoreV1().ConfigMaps(controlPlaneNamespace).Get(ctx, k8s.ConfigConfigMapName, metav1.GetOptions{}); err != nil {
+		b.addError("failed to get %s: %s", k8s.ConfigConfigMapName, err)
+	} else {
+		cm.ManagedFields = nil
+		b.addObjects(bundleConfigFile, cm)
+	}
+
+	// the control plane and extension pods serve their metrics on the
+	// admin-http port, in addition to the metrics of their proxy
+	portNames := []string{adminHTTPPortName, k8s.ProxyAdminPortName}
+	namespaces := []string{controlPlaneNamespace}
+	for _, ns := range extensionNamespaces {
+		namespaces = append(namespaces, ns.GetName())
+	}
+	for _, ns := range namespaces {
+		pods, err := k.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			b.addError("failed to list the pods of namespace %s: %s", ns, err)
+			continue
+		}
+		b.addNamespace(ctx, k, ns, pods.Items, portNames, options)
+	}
+
+	if len(options.dataPlane) == 0 {
+		return
+	}
+	for _, ns := range namespaces {
+		if ns == options.namespace {
+			// the pods of the namespace have already been collected
+			return
+		}
+	}
+	pods := []corev1.Pod{}
+	seen := map[string]struct{}{}
+	for _, resource := range options.dataPlane {
+		resourcePods, err := getPodsFor(ctx, k, options.namespace, resource)
+		if err != nil {
+			b.addError("failed to get the pods of %s: %s", resource, err)
+			continue
+		}
+		for _, pod := range resourcePods {
+			if _, ok := seen[pod.GetName()]; !ok {
+				seen[pod.GetName()] = struct{}{}
+				pods = append(pods, pod)
+			}
+		}
+	}
+	b.addNamespace(ctx, k, options.namespace, pods, []string{k8s.ProxyAdminPortName}, options)
+}
+
+// addNamespace adds the events of the namespace ns, and the manifests, logs
+// and metrics of its pods. The metrics are fetched from the ports named
+// portNames of the running pods.
+func (b *bundle) addNamespace(ctx context.Context, k *k8s.KubernetesAPI, ns string, pods []corev1.Pod, portNames []string, options *bundleOptions) {
+	b.metadata.Namespaces = append(b.metadata.Namespaces, ns)
+	dir := path.Join("namespaces", ns)
+
+	if events, err := k.CoreV1().Events(ns).List(ctx, metav1.ListOptions{}); err != nil {
+		b.addError("failed to list the events of namespace %s: %s", ns, err)
+	} else {
+		objects := make([]interface{}, len(events.Items))
+		for i := range events.Items {
+			events.Items[i].ManagedFields = nil
+			objects[i] = &events.Items[i]
+		}
+		b.addObjects(path.Join(dir, "events.yaml"), objects...)
+	}
+
+	running := []corev1.Pod{}
+	for _, pod := range pods {
+		b.addPod(ctx, k, pod, options.logLines)
+		if pod.Status.Phase == corev1.PodRunning {
+			running = append(running, pod)
+		}
+	}
+
+	if len(running) == 0 {
+		return
+	}
+	for _, portName := range portNames {
+		for _, result := range getMetrics(k, running, portName, options.wait, verbose) {
+			if result.err != nil {
+				b.addError("failed to fetch the metrics of pod %s/%s: %s", ns, result.pod, result.err)
+				continue
+			}
+			b.add(path.Join(dir, "pods", result.pod, result.container+".metrics"), result.metrics)
+		}
+	}
+}
+
+// addPod adds the manifest of the pod, without the values of its sensitive
+// environment variables, and the logs of its containers, including the logs
+// of their previous instance if they restarted
+func (b *bundle) addPod(ctx context.Context, k *k8s.KubernetesAPI, pod corev1.Pod, logLines int64) {
+	dir := path.Join("namespaces", pod.GetNamespace(), "pods", pod.GetName())
+
+	manifest := pod.DeepCopy()
+	manifest.ManagedFields = nil
+	for _, containers := range [][]corev1.Container{manifest.Spec.InitContainers, manifest.Spec.Containers} {
+		for i := range containers {
+			for j, env := range containers[i].Env {
+				if env.Value != "" && sensitiveEnvName.MatchString(env.Name) {
+					containers[i].Env[j].Value = redacted
+				}
+			}
+		}
+	}
+	b.addObjects(path.Join(dir, "pod.yaml"), manifest)
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		b.addLogs(ctx, k, pod, status.Name, false, logLines, path.Join(dir, status.Name+".log"))
+		if status.RestartCount > 0 {
+			b.addLogs(ctx, k, pod, status.Name, true, logLines, path.Join(dir, status.Name+".previous.log"))
+		}
+	}
+}
+
+func (b *bundle) addLogs(ctx context.Context, k *k8s.KubernetesAPI, pod corev1.Pod, container string, previous bool, logLines int64, name string) {
+	logs, err := k.CoreV1().Pods(pod.GetNamespace()).GetLogs(pod.GetName(), &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &logLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		b.addError("failed to get the logs of container %s of pod %s/%s: %s", container, pod.GetNamespace(), pod.GetName(), err)
+		return
+	}
+	b.add(name, logs)
+}
+
+// addObjects adds the objects as a YAML stream
+func (b *bundle) addObjects(name string, objects ...interface{}) {
+	var buf bytes.Buffer
+	for _, obj := range objects {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			b.addError("failed to serialize %s: %s", name, err)
+			return
+		}
+		buf.WriteString(yamlSep)
+		buf.Write(data)
+	}
+	b.add(name, buf.Bytes())
+}
+
+// add adds a file to the bundle, after redacting it
+func (b *bundle) add(name string, data []byte) {
+	if _, ok := b.files[name]; !ok {
+		b.metadata.Files = append(b.metadata.Files, name)
+	}
+	b.files[name] = redact(data)
+}
+
+func (b *bundle) addError(format string, args ...interface{}) {
+	b.metadata.Errors = append(b.metadata.Errors, string(redact([]byte(fmt.Sprintf(format, args...)))))
+}
+
+// redact replaces the private keys, bearer tokens, JWTs and the values of the
+// password, secret, token and API key fields found in data
+func redact(data []byte) []byte {
+	for _, r := range redactions {
+		if r.keep == nil {
+			data = r.re.ReplaceAll(data, []byte(r.repl))
+			continue
+		}
+		r := r
+		data = r.re.ReplaceAllFunc(data, func(match []byte) []byte {
+			if r.keep(r.re.FindSubmatch(match)) {
+				return match
+			}
+			return r.re.Expand(nil, []byte(r.repl), match, r.re.FindSubmatchIndex(match))
+		})
+	}
+	return data
+}
+
+// writeBundle writes the bundle as a gzipped tarball
+func writeBundle(w io.Writer, b *bundle) error {
+	metadataBytes, err := yaml.Marshal(b.metadata)
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{bundleMetadataFile: metadataBytes}
+	for name, data := range b.files {
+		files[name] = data
+	}
+	return writeTarGz(w, append([]string{bundleMetadataFile}, b.metadata.Files...), files, b.metadata.CreatedAt)
+}
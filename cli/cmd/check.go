@@ -62,7 +62,7 @@ func (options *checkOptions) checkFlagSet() *pflag.FlagSet {
 
 	flags.StringVar(&options.versionOverride, "expected-version", options.versionOverride, "Overrides the version used when checking if Linkerd is running the latest version (mostly for testing)")
 	flags.StringVar(&options.cliVersionOverride, "cli-version-override", "", "Used to override the version of the cli (mostly for testing)")
-	flags.StringVarP(&options.output, "output", "o", options.output, "Output format. One of: basic, json, short")
+	flags.StringVarP(&options.output, "output", "o", options.output, "Output format. One of: basic, json, short, junit, sarif")
 	flags.DurationVar(&options.wait, "wait", options.wait, "Maximum allowed time for all tests to pass")
 
 	return flags
@@ -75,8 +75,10 @@ func (options *checkOptions) validate() error {
 	if !options.preInstallOnly && options.cniEnabled {
 		return errors.New("--linkerd-cni-enabled can only be used with --pre")
 	}
-	if options.output != tableOutput && options.output != jsonOutput && options.output != shortOutput {
-		return fmt.Errorf("Invalid output type '%s'. Supported output types are: %s, %s, %s", options.output, jsonOutput, tableOutput, shortOutput)
+	switch options.output {
+	case tableOutput, jsonOutput, shortOutput, junitOutput, sarifOutput:
+	default:
+		return fmt.Errorf("Invalid output type '%s'. Supported output types are: %s, %s, %s, %s, %s", options.output, jsonOutput, tableOutput, shortOutput, junitOutput, sarifOutput)
 	}
 	return nil
 }
@@ -175,24 +177,7 @@ func configureAndRunChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, s
 			return fmt.Errorf("Error rendering install manifest: %v", err)
 		}
 	} else {
-		checks = append(checks, healthcheck.LinkerdConfigChecks)
-
-		if stage != configStage {
-			checks = append(checks, healthcheck.LinkerdControlPlaneExistenceChecks)
-			checks = append(checks, healthcheck.LinkerdIdentity)
-			checks = append(checks, healthcheck.LinkerdWebhooksAndAPISvcTLS)
-			checks = append(checks, healthcheck.LinkerdControlPlaneProxyChecks)
-
-			if options.dataPlaneOnly {
-				checks = append(checks, healthcheck.LinkerdDataPlaneChecks)
-				checks = append(checks, healthcheck.LinkerdIdentityDataPlane)
-				checks = append(checks, healthcheck.LinkerdOpaquePortsDefinitionChecks)
-			} else {
-				checks = append(checks, healthcheck.LinkerdControlPlaneVersionChecks)
-			}
-			checks = append(checks, healthcheck.LinkerdCNIPluginChecks)
-			checks = append(checks, healthcheck.LinkerdHAChecks)
-		}
+		checks = append(checks, controlPlaneChecks(stage, options.dataPlaneOnly)...)
 	}
 
 	hc := healthcheck.NewHealthChecker(checks, &healthcheck.Options{
@@ -210,6 +195,10 @@ func configureAndRunChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, s
 		InstallManifest:       installManifest,
 	})
 
+	if options.output == junitOutput || options.output == sarifOutput {
+		return runReportChecks(cmd, wout, werr, hc, options)
+	}
+
 	if options.output != jsonOutput {
 		healthcheck.PrintCoreChecksHeader(wout)
 	}
@@ -230,30 +219,86 @@ func configureAndRunChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, s
 	return nil
 }
 
-func runExtensionChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, opts *checkOptions) (bool, error) {
-	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+// controlPlaneChecks returns the checks of an installed control plane for
+// stage, and of its data plane if dataPlaneOnly is set
+func controlPlaneChecks(stage string, dataPlaneOnly bool) []healthcheck.CategoryID {
+	checks := []healthcheck.CategoryID{healthcheck.LinkerdConfigChecks}
+
+	if stage != configStage {
+		checks = append(checks, healthcheck.LinkerdControlPlaneExistenceChecks)
+		checks = append(checks, healthcheck.LinkerdIdentity)
+		checks = append(checks, healthcheck.LinkerdWebhooksAndAPISvcTLS)
+		checks = append(checks, healthcheck.LinkerdControlPlaneProxyChecks)
+
+		if dataPlaneOnly {
+			checks = append(checks, healthcheck.LinkerdDataPlaneChecks)
+			checks = append(checks, healthcheck.LinkerdIdentityDataPlane)
+			checks = append(checks, healthcheck.LinkerdOpaquePortsDefinitionChecks)
+		} else {
+			checks = append(checks, healthcheck.LinkerdControlPlaneVersionChecks)
+		}
+		checks = append(checks, healthcheck.LinkerdCNIPluginChecks)
+		checks = append(checks, healthcheck.LinkerdHAChecks)
+	}
+	return checks
+}
+
+// runReportChecks runs the core and extension checks, and renders all their
+// results as a single report
+func runReportChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, hc *healthcheck.HealthChecker, options *checkOptions) error {
+	results := healthcheck.CollectCheckResults(hc)
+
+	extensions, err := getExtensions(cmd.Context())
 	if err != nil {
-		return false, err
+		err = fmt.Errorf("failed to run extensions checks: %s", err)
+		fmt.Fprintln(werr, err)
+		os.Exit(1)
+	}
+	if len(extensions) > 0 {
+		extensionResults := healthcheck.ExtensionsCheckResults(extensions, getExtensionCheckFlags(cmd.Flags()))
+		results.Results = append(results.Results, extensionResults.Results...)
 	}
 
-	namespaces, err := kubeAPI.GetAllNamespacesWithExtensionLabel(cmd.Context())
+	if !healthcheck.RunChecks(wout, werr, results, options.output) {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func runExtensionChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, opts *checkOptions) (bool, error) {
+	extensions, err := getExtensions(cmd.Context())
 	if err != nil {
 		return false, err
 	}
 
 	success := true
 	// no extensions to check
-	if len(namespaces) == 0 {
+	if len(extensions) == 0 {
 		return success, nil
 	}
 
-	nsLabels := make([]string, len(namespaces))
-	for i, ns := range namespaces {
-		nsLabels[i] = ns.Labels[k8s.LinkerdExtensionLabel]
+	extensionSuccess := healthcheck.RunExtensionsChecks(wout, werr, extensions, getExtensionCheckFlags(cmd.Flags()), opts.output)
+	return extensionSuccess, nil
+}
+
+// getExtensions returns the names of the extensions installed in the cluster
+func getExtensions(ctx context.Context) ([]string, error) {
+	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+	if err != nil {
+		return nil, err
 	}
 
-	extensionSuccess := healthcheck.RunExtensionsChecks(wout, werr, nsLabels, getExtensionCheckFlags(cmd.Flags()), opts.output)
-	return extensionSuccess, nil
+	namespaces, err := kubeAPI.GetAllNamespacesWithExtensionLabel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	extensions := make([]string, len(namespaces))
+	for i, ns := range namespaces {
+		extensions[i] = ns.Labels[k8s.LinkerdExtensionLabel]
+	}
+	return extensions, nil
 }
 
 func getExtensionCheckFlags(lf *pflag.FlagSet) []string {
@@ -74,6 +74,85 @@ func TestCheckStatus(t *testing.T) {
 
 		expectedContent := string(goldenFileBytes)
 
+		if expectedContent != output.String() {
+			t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, output)
+		}
+	})
+	t.Run("Prints expected output in junit", func(t *testing.T) {
+		hc := healthcheck.NewHealthChecker(
+			[]healthcheck.CategoryID{},
+			&healthcheck.Options{},
+		)
+		hc.AppendCategories(healthcheck.NewCategory("category", []healthcheck.Checker{
+			*healthcheck.NewChecker("check1").
+				WithCheck(func(context.Context) error {
+					return nil
+				}),
+			*healthcheck.NewChecker("check2").
+				WithHintAnchor("hint-anchor").
+				Warning().
+				WithCheck(func(context.Context) error {
+					return fmt.Errorf("This should contain instructions for warning")
+				}),
+			*healthcheck.NewChecker("check3").
+				WithHintAnchor("hint-anchor").
+				WithCheck(func(context.Context) error {
+					return fmt.Errorf("This should contain instructions for fail")
+				}),
+		},
+			true,
+		))
+
+		output := bytes.NewBufferString("")
+		healthcheck.RunChecks(output, stderr, hc, junitOutput)
+
+		goldenFileBytes, err := ioutil.ReadFile("testdata/check_output_junit.golden")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedContent := string(goldenFileBytes)
+
+		if expectedContent != output.String() {
+			t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, output)
+		}
+	})
+
+	t.Run("Prints expected output in sarif", func(t *testing.T) {
+		hc := healthcheck.NewHealthChecker(
+			[]healthcheck.CategoryID{},
+			&healthcheck.Options{},
+		)
+		hc.AppendCategories(healthcheck.NewCategory("category", []healthcheck.Checker{
+			*healthcheck.NewChecker("check1").
+				WithCheck(func(context.Context) error {
+					return nil
+				}),
+			*healthcheck.NewChecker("check2").
+				WithHintAnchor("hint-anchor").
+				Warning().
+				WithCheck(func(context.Context) error {
+					return fmt.Errorf("This should contain instructions for warning")
+				}),
+			*healthcheck.NewChecker("check3").
+				WithHintAnchor("hint-anchor").
+				WithCheck(func(context.Context) error {
+					return fmt.Errorf("This should contain instructions for fail")
+				}),
+		},
+			true,
+		))
+
+		output := bytes.NewBufferString("")
+		healthcheck.RunChecks(output, stderr, hc, sarifOutput)
+
+		goldenFileBytes, err := ioutil.ReadFile("testdata/check_output_sarif.golden")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedContent := string(goldenFileBytes)
+
 		if expectedContent != output.String() {
 			t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, output)
 		}
@@ -24,15 +24,27 @@ This command provides subcommands to diagnose the functionality of Linkerd.`,
 
   # Get metrics from the web deployment in the emojivoto namespace.
   linkerd diagnostics proxy-metrics -n emojivoto deploy/web
+
+  # Collect CPU profiles from each replica of the destination component.
+  linkerd diagnostics pprof --component destination --type cpu --duration 30s
+
+  # Enable debug logs in the proxies of the web deployment for 5 minutes.
+  linkerd diagnostics proxy-log-level -n emojivoto deploy/web warn,linkerd=debug --duration 5m
  
   # Get the endpoints for authorities in Linkerd's control-plane itself
   linkerd diagnostics endpoints web.linkerd-viz.svc.cluster.local:8084
+
+  # Collect the diagnostics of the control plane and the extensions into an archive
+  linkerd diagnostics bundle
   `,
 	}
 
+	diagnosticsCmd.AddCommand(newCmdBundle())
 	diagnosticsCmd.AddCommand(newCmdControllerMetrics())
 	diagnosticsCmd.AddCommand(newCmdEndpoints())
 	diagnosticsCmd.AddCommand(newCmdMetrics())
+	diagnosticsCmd.AddCommand(newCmdPprof())
+	diagnosticsCmd.AddCommand(newCmdProxyLogLevel())
 
 	return diagnosticsCmd
 }
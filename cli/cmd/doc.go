@@ -164,6 +164,10 @@ func generateAnnotationsDocs() []annotationDoc {
 			Name:        k8s.ProxyIgnoreOutboundPortsAnnotation,
 			Description: "Outbound ports that should skip the proxy. Comma-separated list of values, where each value can be a port number or a range `a-b`.",
 		},
+		{
+			Name:        k8s.ProxyEnableIPv6Annotation,
+			Description: "Whether the CNI plugin redirects IPv6 traffic to the proxy as well",
+		},
 		{
 			Name:        k8s.ProxyInboundPortAnnotation,
 			Description: "Proxy port to use for inbound traffic",
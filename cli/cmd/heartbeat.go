@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/linkerd/linkerd2/controller/heartbeat"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+	promApi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	vizExtensionName     = "viz"
+	prometheusDeployment = "prometheus"
+	prometheusPort       = 9090
+)
+
+type heartbeatOptions struct {
+	heartbeatURL  string
+	prometheusURL string
+	dryRun        bool
+}
+
+func newCmdHeartbeat() *cobra.Command {
+	options := &heartbeatOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "heartbeat [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Send the heartbeat of the control plane, or preview its payload",
+		Long: `Send the heartbeat of the control plane, or preview its payload.
+
+The heartbeat cronjob of the control plane periodically sends anonymous usage
+statistics gathered from Kubernetes and Prometheus to the URL set with the
+heartbeatURL value, which defaults to versioncheck.linkerd.io. This command
+gathers the same statistics from the CLI and sends them to the same URL, or
+only prints them with --dry-run. If the heartbeat is disabled with the
+disableHeartBeat value, it's only sent to a URL set with --heartbeat-url.
+
+Prometheus is reached with a port-forward to the Prometheus instance of the
+viz extension, unless --prometheus-url is set. The statistics coming from
+Prometheus are left out if it can't be reached.`,
+		Example: `  # Print the heartbeat payload without sending it.
+  linkerd heartbeat --dry-run
+
+  # Send the heartbeat to a self-hosted collector.
+  linkerd heartbeat --heartbeat-url http://heartbeat-collector.example.com/`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+			return runHeartbeat(cmd.Context(), k8sAPI, options, stdout, stderr)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.heartbeatURL, "heartbeat-url", "",
+		"URL to send the heartbeat to (default: the heartbeatURL value of the control plane)")
+	cmd.Flags().StringVar(&options.prometheusURL, "prometheus-url", "",
+		"URL of the Prometheus instance to gather statistics from (default: port-forward to the viz extension's Prometheus)")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", false,
+		"Print the heartbeat payload instead of sending it")
+
+	return cmd
+}
+
+func runHeartbeat(ctx context.Context, k8sAPI *k8s.KubernetesAPI, options *heartbeatOptions, wout, werr io.Writer) error {
+	_, values, err := healthcheck.FetchCurrentConfiguration(ctx, k8sAPI, controlPlaneNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the control plane configuration: %s", err)
+	}
+
+	// the heartbeat opt-out of the control plane is respected unless the URL
+	// to send the heartbeat to is set explicitly
+	if values.DisableHeartBeat && options.heartbeatURL == "" && !options.dryRun {
+		return errors.New("the heartbeat is disabled by the disableHeartBeat value of the control plane, set --heartbeat-url to send it anyway")
+	}
+
+	heartbeatURL := options.heartbeatURL
+	if heartbeatURL == "" {
+		heartbeatURL = values.HeartbeatURL
+	}
+	if heartbeatURL == "" {
+		heartbeatURL = version.CheckURL
+	}
+
+	promAPI, stop, err := heartbeatPromAPI(ctx, k8sAPI, options.prometheusURL)
+	if err != nil {
+		fmt.Fprintf(werr, "%s Leaving out the Prometheus statistics: %s\n", warnStatus, err)
+	} else {
+		defer stop()
+	}
+
+	v := heartbeat.Values(ctx, k8sAPI, promAPI, controlPlaneNamespace)
+	// the heartbeat is sent on behalf of the control plane, whose version can
+	// differ from the CLI's
+	if values.LinkerdVersion != "" {
+		v.Set("version", values.LinkerdVersion)
+	}
+
+	if options.dryRun {
+		return printHeartbeat(wout, heartbeatURL, v)
+	}
+
+	if err := heartbeat.SendTo(heartbeatURL, v); err != nil {
+		return err
+	}
+	fmt.Fprintf(wout, "Heartbeat sent to %s\n", heartbeatURL)
+	return nil
+}
+
+// heartbeatPromAPI returns a client for the Prometheus instance at
+// prometheusURL, or for the viz extension's Prometheus through a port-forward
+// if prometheusURL is empty, along with a function stopping the port-forward
+func heartbeatPromAPI(ctx context.Context, k8sAPI *k8s.KubernetesAPI, prometheusURL string) (promv1.API, func(), error) {
+	stop := func() {}
+	if prometheusURL == "" {
+		vizNs, err := k8sAPI.GetNamespaceWithExtensionLabel(ctx, vizExtensionName)
+		if err != nil {
+			return nil, nil, err
+		}
+		portforward, err := k8s.NewPortForward(ctx, k8sAPI, vizNs.Name, prometheusDeployment, "localhost", 0, prometheusPort, verbose)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := portforward.Init(); err != nil {
+			return nil, nil, err
+		}
+		prometheusURL = portforward.URLFor("")
+		stop = portforward.Stop
+	}
+
+	client, err := promApi.NewClient(promApi.Config{Address: prometheusURL})
+	if err != nil {
+		stop()
+		return nil, nil, err
+	}
+	return promv1.NewAPI(client), stop, nil
+}
+
+// printHeartbeat prints the request the heartbeat would be sent with,
+// followed by its parameters
+func printHeartbeat(w io.Writer, heartbeatURL string, v url.Values) error {
+	reqURL, err := heartbeat.RequestURL(heartbeatURL, v)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "GET %s\n\n", reqURL)
+
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PARAMETER\tVALUE")
+	for _, key := range keys {
+		fmt.Fprintf(tw, "%s\t%s\n", key, v.Get(key))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestPrintHeartbeat(t *testing.T) {
+	v := url.Values{
+		"version":     []string{"stable-2.10.2"},
+		"source":      []string{"heartbeat"},
+		"uuid":        []string{"fake-uuid"},
+		"meshed-pods": []string{"12"},
+	}
+
+	var buf bytes.Buffer
+	if err := printHeartbeat(&buf, "http://collector.example.com/heartbeat", v); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := `GET http://collector.example.com/heartbeat?meshed-pods=12&source=heartbeat&uuid=fake-uuid&version=stable-2.10.2
+
+PARAMETER    VALUE
+meshed-pods  12
+source       heartbeat
+uuid         fake-uuid
+version      stable-2.10.2
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	if err := printHeartbeat(&buf, "http://[::1", v); err == nil {
+		t.Error("Expected an error for an invalid URL")
+	}
+}
+
+func TestRunHeartbeatDisabled(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  values: |
+    disableHeartBeat: true
+`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = runHeartbeat(context.Background(), k8sAPI, &heartbeatOptions{}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "the heartbeat is disabled") {
+		t.Errorf("Expected the disabled heartbeat to be refused, got %v", err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -21,6 +22,8 @@ import (
 	api "github.com/linkerd/linkerd2/pkg/public"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 )
 
@@ -42,6 +45,9 @@ type resourceTransformerInject struct {
 	overrideAnnotations map[string]string
 	enableDebugSidecar  bool
 	closeWaitTimeout    time.Duration
+	proxyConfigs        []inject.ProxyConfig
+	explain             bool
+	reportFormat        string
 }
 
 func runInjectCmd(inputs []io.Reader, errWriter, outWriter io.Writer, transformer *resourceTransformerInject) int {
@@ -56,8 +62,9 @@ func newCmdInject() *cobra.Command {
 	}
 	flags, proxyFlagSet := makeProxyFlags(defaults)
 	injectFlags, injectFlagSet := makeInjectFlags(defaults)
-	var manualOption, enableDebugSidecar bool
+	var manualOption, enableDebugSidecar, explain, kustomizeFunction, helmPostRenderer bool
 	var closeWaitTimeout time.Duration
+	var reportFormat string
 
 	cmd := &cobra.Command{
 		Use:   "inject [flags] CONFIG-FILE",
@@ -73,18 +80,44 @@ sub-folders, or coming from stdin.`,
   linkerd inject http://url.to/yml | kubectl apply -f -
 
   # Inject all the resources inside a folder and its sub-folders.
-  linkerd inject <folder> | kubectl apply -f -`,
+  linkerd inject <folder> | kubectl apply -f -
+
+  # Inject the resources of a Kustomize build, as a KRM function.
+  kpt fn eval --exec "linkerd inject --kustomize-function" .
+
+  # Inject the resources of a Helm release, as a post-renderer.
+  helm install emojivoto ./emojivoto --post-renderer linkerd \
+    --post-renderer-args inject --post-renderer-args --helm-post-renderer`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if kustomizeFunction && helmPostRenderer {
+				return errors.New("--kustomize-function and --helm-post-renderer are mutually exclusive")
+			}
+			// in both the Kustomize and Helm modes the resources are read from stdin
+			if kustomizeFunction || helmPostRenderer {
+				if len(args) > 0 {
+					return errors.New("the resources are read from stdin with --kustomize-function or --helm-post-renderer")
+				}
+				args = []string{"-"}
+			}
 			if len(args) < 1 {
 				return fmt.Errorf("please specify a kubernetes resource file")
 			}
 
+			if err := validateReportFormat(reportFormat); err != nil {
+				return err
+			}
+
 			values := defaults
+			var proxyConfigs []inject.ProxyConfig
 			if !ignoreCluster {
 				values, err = fetchConfigs(cmd.Context())
 				if err != nil {
 					return err
 				}
+				proxyConfigs, err = fetchProxyConfigs(cmd.Context())
+				if err != nil {
+					return err
+				}
 			}
 
 			baseValues, err := values.DeepCopy()
@@ -110,6 +143,12 @@ sub-folders, or coming from stdin.`,
 				overrideAnnotations: overrideAnnotations,
 				enableDebugSidecar:  enableDebugSidecar,
 				closeWaitTimeout:    closeWaitTimeout,
+				proxyConfigs:        proxyConfigs,
+				explain:             explain,
+				reportFormat:        reportFormat,
+			}
+			if kustomizeFunction {
+				os.Exit(runKRMFunction(os.Stdin, stdout, transformer))
 			}
 			exitCode := uninjectAndInject(in, stderr, stdout, transformer)
 			os.Exit(exitCode)
@@ -134,6 +173,18 @@ sub-folders, or coming from stdin.`,
 		&closeWaitTimeout, "close-wait-timeout", closeWaitTimeout,
 		"Sets nf_conntrack_tcp_timeout_close_wait")
 
+	cmd.Flags().BoolVar(&explain, "explain", explain,
+		"Show which layer set each proxy configuration value: a ProxyConfig resource, an annotation or a flag")
+
+	cmd.Flags().StringVar(&reportFormat, "report-format", "",
+		"Print the report to stderr as one record per resource in the given format: json or yaml (default human readable)")
+
+	cmd.Flags().BoolVar(&kustomizeFunction, "kustomize-function", kustomizeFunction,
+		"Run as a Kustomize KRM function: read a ResourceList from stdin and write it back injected, with the report as its results")
+
+	cmd.Flags().BoolVar(&helmPostRenderer, "helm-post-renderer", helmPostRenderer,
+		"Run as a Helm post-renderer: read the rendered manifests from stdin and write them back injected")
+
 	cmd.Flags().AddFlagSet(proxyFlagSet)
 	cmd.Flags().AddFlagSet(injectFlagSet)
 
@@ -149,7 +200,8 @@ func uninjectAndInject(inputs []io.Reader, errWriter, outWriter io.Writer, trans
 }
 
 func (rt resourceTransformerInject) transform(bytes []byte) ([]byte, []inject.Report, error) {
-	conf := inject.NewResourceConfig(rt.values, inject.OriginCLI)
+	conf := inject.NewResourceConfig(rt.values, inject.OriginCLI).
+		WithProxyConfigs("", rt.proxyConfigs)
 
 	if rt.enableDebugSidecar {
 		conf.AppendPodAnnotation(k8s.ProxyEnableDebugAnnotation, "true")
@@ -206,10 +258,18 @@ func (rt resourceTransformerInject) transform(bytes []byte) ([]byte, []inject.Re
 		conf.AppendPodAnnotation(k8s.ProxyInjectAnnotation, k8s.ProxyInjectEnabled)
 	}
 
+	if rt.explain {
+		reports[0].ConfigOrigins = conf.ExplainConfig()
+	}
+
 	patchJSON, err := conf.GetPodPatch(rt.injectProxy)
 	if err != nil {
 		return nil, nil, err
 	}
+	if rt.injectProxy {
+		reports[0].InjectedContainers = conf.InjectedContainers()
+	}
+	reports[0].OverriddenConfig = rt.overriddenConfig(conf)
 
 	if len(patchJSON) == 0 {
 		return bytes, reports, nil
@@ -235,7 +295,35 @@ func (rt resourceTransformerInject) transform(bytes []byte) ([]byte, []inject.Re
 	return injectedYAML, reports, nil
 }
 
-func (resourceTransformerInject) generateReport(reports []inject.Report, output io.Writer) {
+// overriddenConfig returns the proxy configuration annotations the workload
+// sets, overridden by the ones set through flags
+func (rt resourceTransformerInject) overriddenConfig(conf *inject.ResourceConfig) map[string]string {
+	config := map[string]string{}
+	for annotation, value := range conf.GetOverriddenConfiguration() {
+		if value != "" {
+			config[annotation] = value
+		}
+	}
+	for _, annotation := range append(inject.ProxyAnnotations, inject.ProxyAlphaConfigAnnotations...) {
+		if value, ok := rt.overrideAnnotations[annotation]; ok {
+			config[annotation] = value
+		}
+	}
+	return config
+}
+
+func (rt resourceTransformerInject) generateReport(reports []inject.Report, output io.Writer) {
+	if rt.reportFormat != "" {
+		records := []reportRecord{}
+		for _, r := range reports {
+			records = append(records, newInjectReportRecord(r))
+		}
+		if err := writeReportRecords(records, rt.reportFormat, output); err != nil {
+			fmt.Fprintf(output, "Error printing the report: %s\n", err)
+		}
+		return
+	}
+
 	injected := []inject.Report{}
 	annotatable := false
 	hostNetwork := []string{}
@@ -351,10 +439,35 @@ func (resourceTransformerInject) generateReport(reports []inject.Report, output
 		}
 	}
 
+	if rt.explain {
+		writeConfigOrigins(reports, output)
+	}
+
 	// Trailing newline to separate from kubectl output if piping
 	output.Write([]byte("\n"))
 }
 
+// writeConfigOrigins prints, for each injected resource, the proxy
+// configuration values it got injected with and the layer that set them
+func writeConfigOrigins(reports []inject.Report, output io.Writer) {
+	for _, r := range reports {
+		if ok, _ := r.Injectable(); !ok {
+			continue
+		}
+		output.Write([]byte(fmt.Sprintf("\n%s \"%s\" proxy configuration:\n", r.Kind, r.Name)))
+		if len(r.ConfigOrigins) == 0 {
+			output.Write([]byte("  all values are the control plane defaults\n"))
+			continue
+		}
+		w := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "  ANNOTATION\tVALUE\tLAYER\tSOURCE")
+		for _, origin := range r.ConfigOrigins {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", origin.Annotation, origin.Value, origin.Layer, origin.Source)
+		}
+		w.Flush()
+	}
+}
+
 func fetchConfigs(ctx context.Context) (*linkerd2.Values, error) {
 
 	api.CheckPublicAPIClientOrRetryOrExit(healthcheck.Options{
@@ -377,6 +490,39 @@ func fetchConfigs(ctx context.Context) (*linkerd2.Values, error) {
 	return values, err
 }
 
+// fetchProxyConfigs returns the ProxyConfigs of all namespaces, which is empty
+// if the control plane doesn't define the ProxyConfig CRD yet, or if the user
+// isn't allowed to list them
+func fetchProxyConfigs(ctx context.Context) ([]inject.ProxyConfig, error) {
+	api, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := api.DynamicClient.Resource(k8s.ProxyConfigGVR).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if kerrors.IsForbidden(err) {
+			log.Warnf("Not allowed to list the ProxyConfigs, injecting without them: %s", err)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	configs := []inject.ProxyConfig{}
+	for _, item := range list.Items {
+		config, err := inject.NewProxyConfig(item)
+		if err != nil {
+			log.Warnf("Ignoring invalid ProxyConfig %s/%s: %s", item.GetNamespace(), item.GetName(), err)
+			continue
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
 // overrideConfigs uses command-line overrides to update the provided configs.
 // the overrideAnnotations map keeps track of which configs are overridden, by
 // storing the corresponding annotations and values.
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/inject"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	resourceListKind       = "ResourceList"
+	resourceListAPIVersion = "config.kubernetes.io/v1"
+
+	// functionConfigManual is the functionConfig data key that includes the
+	// proxy container spec in the output, like the --manual flag
+	functionConfigManual = "manual"
+
+	krmSeverityError   = "error"
+	krmSeverityWarning = "warning"
+	krmSeverityInfo    = "info"
+)
+
+// resourceList is the input and output of a KRM function, as specified in
+// https://github.com/kubernetes-sigs/kustomize/blob/master/cmd/config/docs/api-conventions/functions-spec.md
+type resourceList struct {
+	APIVersion     string            `json:"apiVersion"`
+	Kind           string            `json:"kind"`
+	Items          []json.RawMessage `json:"items"`
+	FunctionConfig json.RawMessage   `json:"functionConfig,omitempty"`
+	Results        []krmResult       `json:"results,omitempty"`
+}
+
+// functionConfig holds the options of the function. Its data are either the
+// "manual" key or proxy configuration annotations set on all the workloads.
+type functionConfig struct {
+	Data map[string]string `json:"data,omitempty"`
+}
+
+type krmResult struct {
+	Message     string          `json:"message"`
+	Severity    string          `json:"severity"`
+	ResourceRef *krmResourceRef `json:"resourceRef,omitempty"`
+}
+
+type krmResourceRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+}
+
+// runKRMFunction reads a ResourceList from in and writes it back to out with
+// its items injected and one result per injectable or annotated resource. It
+// returns 1 if any item couldn't be transformed.
+func runKRMFunction(in io.Reader, out io.Writer, transformer *resourceTransformerInject) int {
+	list, err := readResourceList(in)
+	if err != nil {
+		return writeResourceList(out, &resourceList{
+			APIVersion: resourceListAPIVersion,
+			Kind:       resourceListKind,
+			Results:    []krmResult{{Message: err.Error(), Severity: krmSeverityError}},
+		})
+	}
+
+	if err := transformer.applyFunctionConfig(list.FunctionConfig); err != nil {
+		list.Results = append(list.Results, krmResult{Message: err.Error(), Severity: krmSeverityError})
+		return writeResourceList(out, list)
+	}
+
+	for i, item := range list.Items {
+		injected, results, err := transformer.transformItem(item)
+		if err != nil {
+			list.Results = append(list.Results, krmResult{
+				Message:     err.Error(),
+				Severity:    krmSeverityError,
+				ResourceRef: resourceRef(item),
+			})
+			continue
+		}
+		list.Items[i] = injected
+		list.Results = append(list.Results, results...)
+	}
+
+	return writeResourceList(out, list)
+}
+
+func readResourceList(in io.Reader) (*resourceList, error) {
+	bytes, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+	var list resourceList
+	if err := yaml.Unmarshal(bytes, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse the ResourceList: %s", err)
+	}
+	if list.Kind != resourceListKind {
+		return nil, fmt.Errorf("expected a %s as input, got kind %q", resourceListKind, list.Kind)
+	}
+	if list.APIVersion == "" {
+		list.APIVersion = resourceListAPIVersion
+	}
+	return &list, nil
+}
+
+// writeResourceList writes the list as YAML, and returns the exit code of the
+// function: 1 if the list holds an error result
+func writeResourceList(out io.Writer, list *resourceList) int {
+	bytes, err := yaml.Marshal(list)
+	if err != nil {
+		fmt.Fprintf(out, "Error printing the ResourceList: %s\n", err)
+		return 1
+	}
+	out.Write(bytes)
+
+	for _, result := range list.Results {
+		if result.Severity == krmSeverityError {
+			return 1
+		}
+	}
+	return 0
+}
+
+// applyFunctionConfig sets the options of the transformer from the
+// functionConfig of the ResourceList, if any
+func (rt *resourceTransformerInject) applyFunctionConfig(raw json.RawMessage) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	var config functionConfig
+	if err := yaml.Unmarshal(raw, &config); err != nil {
+		return fmt.Errorf("failed to parse the functionConfig: %s", err)
+	}
+
+	overrides := map[string]string{}
+	for key, value := range config.Data {
+		if key == functionConfigManual {
+			manual, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for the functionConfig key %q: %s", value, key, err)
+			}
+			rt.injectProxy = manual
+			continue
+		}
+		if !inject.IsProxyConfigAnnotation(key) {
+			return fmt.Errorf("unsupported functionConfig key %q, supported keys are %q and the proxy configuration annotations", key, functionConfigManual)
+		}
+		overrides[key] = value
+	}
+
+	// like the flags, the annotations are applied to the values used for the
+	// manual injection, and added to the workloads
+	values, err := inject.GetValuesWithAnnotations(rt.values, overrides)
+	if err != nil {
+		return err
+	}
+	rt.values = values
+	for key, value := range overrides {
+		rt.overrideAnnotations[key] = value
+	}
+	return nil
+}
+
+// transformItem uninjects then injects a single item of the ResourceList,
+// and returns its results
+func (rt *resourceTransformerInject) transformItem(item json.RawMessage) (json.RawMessage, []krmResult, error) {
+	uninjected, _, err := resourceTransformerUninjectSilent{values: rt.values}.transform(item)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, reports, err := rt.transform(uninjected)
+	if err != nil {
+		return nil, nil, err
+	}
+	injected, err := yaml.YAMLToJSON(result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ref := resourceRef(item)
+	results := []krmResult{}
+	for _, report := range reports {
+		if report.UnsupportedResource && !report.Annotated {
+			continue
+		}
+		record := newInjectReportRecord(report)
+		message := fmt.Sprintf("%s %s", report.ResName(), record.Status)
+		severity := krmSeverityInfo
+		if len(record.SkipReasons) > 0 {
+			reasons := []string{}
+			for _, reason := range record.SkipReasons {
+				reasons = append(reasons, reason.Message)
+			}
+			message = fmt.Sprintf("%s: %s", message, strings.Join(reasons, ", "))
+			severity = krmSeverityWarning
+		}
+		results = append(results, krmResult{Message: message, Severity: severity, ResourceRef: ref})
+		for _, warning := range record.Warnings {
+			results = append(results, krmResult{
+				Message:     fmt.Sprintf("%s: %s", report.ResName(), warning),
+				Severity:    krmSeverityWarning,
+				ResourceRef: ref,
+			})
+		}
+	}
+	return injected, results, nil
+}
+
+func resourceRef(item json.RawMessage) *krmResourceRef {
+	var meta struct {
+		metav1.TypeMeta   `json:",inline"`
+		metav1.ObjectMeta `json:"metadata,omitempty"`
+	}
+	if err := yaml.Unmarshal(item, &meta); err != nil {
+		return nil
+	}
+	return &krmResourceRef{
+		APIVersion: meta.APIVersion,
+		Kind:       meta.Kind,
+		Name:       meta.Name,
+		Namespace:  meta.Namespace,
+	}
+}
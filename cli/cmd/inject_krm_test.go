@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestRunKRMFunction(t *testing.T) {
+	file, err := os.Open("testdata/inject_krm.input.yml")
+	if err != nil {
+		t.Fatalf("error opening test input file: %v", err)
+	}
+	defer file.Close()
+
+	values := defaultConfig()
+	transformer := &resourceTransformerInject{
+		values:              values,
+		overrideAnnotations: getOverrideAnnotations(values, defaultConfig()),
+		allowNsInject:       true,
+	}
+
+	output := new(bytes.Buffer)
+	if exitCode := runKRMFunction(file, output, transformer); exitCode != 0 {
+		t.Errorf("Unexpected error running the function: %v", output)
+	}
+	testDataDiffer.DiffTestdata(t, "inject_krm.golden.yml", output.String())
+}
+
+func TestRunKRMFunctionErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "not a ResourceList",
+			input: "apiVersion: v1\nkind: List\nitems: []\n",
+		},
+		{
+			name:  "unsupported functionConfig key",
+			input: "kind: ResourceList\nfunctionConfig:\n  data:\n    foo: bar\nitems: []\n",
+		},
+		{
+			name:  "invalid manual value",
+			input: "kind: ResourceList\nfunctionConfig:\n  data:\n    manual: maybe\nitems: []\n",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			values := defaultConfig()
+			transformer := &resourceTransformerInject{
+				values:              values,
+				overrideAnnotations: map[string]string{},
+			}
+			output := new(bytes.Buffer)
+			if exitCode := runKRMFunction(bytes.NewBufferString(tc.input), output, transformer); exitCode != 1 {
+				t.Errorf("Expected the function to fail, got exit code %d", exitCode)
+			}
+			if !bytes.Contains(output.Bytes(), []byte("severity: error")) {
+				t.Errorf("Expected an error result, got %s", output)
+			}
+			if !bytes.Contains(output.Bytes(), []byte("apiVersion: "+resourceListAPIVersion)) {
+				t.Errorf("Expected a %s ResourceList, got %s", resourceListAPIVersion, output)
+			}
+		})
+	}
+}
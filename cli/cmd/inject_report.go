@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/linkerd/linkerd2/pkg/inject"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	reportStatusInjected   = "injected"
+	reportStatusAnnotated  = "annotated"
+	reportStatusUninjected = "uninjected"
+	reportStatusSkipped    = "skipped"
+)
+
+// reportRecord is the machine-readable report of the injection or uninjection
+// of a resource, printed when --report-format is set
+type reportRecord struct {
+	Kind               string            `json:"kind"`
+	Name               string            `json:"name"`
+	Status             string            `json:"status"`
+	SkipReasons        []skipReason      `json:"skipReasons,omitempty"`
+	InjectedContainers []string          `json:"injectedContainers,omitempty"`
+	OverriddenConfig   map[string]string `json:"overriddenConfig,omitempty"`
+	Warnings           []string          `json:"warnings,omitempty"`
+}
+
+type skipReason struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+func validateReportFormat(format string) error {
+	if format != "" && format != jsonOutput && format != yamlOutput {
+		return fmt.Errorf("invalid report format '%s', supported formats are: %s, %s", format, jsonOutput, yamlOutput)
+	}
+	return nil
+}
+
+func newInjectReportRecord(r inject.Report) reportRecord {
+	record := reportRecord{
+		Kind:               r.Kind,
+		Name:               r.Name,
+		InjectedContainers: r.InjectedContainers,
+		OverriddenConfig:   r.OverriddenConfig,
+	}
+
+	injectable, reasons := r.Injectable()
+	switch {
+	case injectable:
+		record.Status = reportStatusInjected
+	case r.Annotated:
+		record.Status = reportStatusAnnotated
+	default:
+		record.Status = reportStatusSkipped
+		for _, reason := range reasons {
+			record.SkipReasons = append(record.SkipReasons, skipReason{reason, inject.Reasons[reason]})
+		}
+	}
+
+	if r.HostNetwork {
+		record.Warnings = append(record.Warnings, "\"hostNetwork: true\" detected")
+	}
+	if r.Sidecar {
+		record.Warnings = append(record.Warnings, "known 3rd party sidecar detected")
+	}
+	if r.InjectDisabled {
+		record.Warnings = append(record.Warnings, fmt.Sprintf("\"%s: %s\" annotation set", k8s.ProxyInjectAnnotation, k8s.ProxyInjectDisabled))
+	}
+	if r.UDP {
+		record.Warnings = append(record.Warnings, "uses \"protocol: UDP\"")
+	}
+	if !r.AutomountServiceAccountToken {
+		record.Warnings = append(record.Warnings, "automountServiceAccountToken set to \"false\"")
+	}
+	return record
+}
+
+func newUninjectReportRecord(r inject.Report) reportRecord {
+	record := reportRecord{
+		Kind:   r.Kind,
+		Name:   r.Name,
+		Status: reportStatusSkipped,
+	}
+	if r.Uninjected.Proxy || r.Uninjected.ProxyInit {
+		record.Status = reportStatusUninjected
+	}
+	return record
+}
+
+// writeReportRecords prints one record per line in the json format, and one
+// document per record in the yaml format, so that the records of several
+// inputs can be streamed
+func writeReportRecords(records []reportRecord, format string, output io.Writer) error {
+	for _, record := range records {
+		switch format {
+		case jsonOutput:
+			bytes, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(output, "%s\n", bytes)
+		case yamlOutput:
+			bytes, err := yaml.Marshal(record)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(output, "---\n%s", bytes)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"sigs.k8s.io/yaml"
+)
+
+func TestInjectReportFormat(t *testing.T) {
+	for _, tc := range []struct {
+		inputFileName string
+		format        string
+		expected      reportRecord
+	}{
+		{
+			inputFileName: "inject_emojivoto_deployment.input.yml",
+			format:        jsonOutput,
+			expected: reportRecord{
+				Kind:               "deployment",
+				Name:               "web",
+				Status:             reportStatusInjected,
+				InjectedContainers: []string{k8s.InitContainerName, k8s.ProxyContainerName},
+				OverriddenConfig:   map[string]string{k8s.ProxyLogLevelAnnotation: "debug"},
+			},
+		},
+		{
+			inputFileName: "inject_emojivoto_deployment_injectDisabled.input.yml",
+			format:        yamlOutput,
+			expected: reportRecord{
+				Kind:   "deployment",
+				Name:   "web",
+				Status: reportStatusSkipped,
+				SkipReasons: []skipReason{{
+					"injection_disable_annotation_present",
+					"pod has the annotation \"linkerd.io/inject:disabled\"",
+				}},
+				Warnings: []string{"\"linkerd.io/inject: disabled\" annotation set"},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.inputFileName, func(t *testing.T) {
+			file, err := os.Open("testdata/" + tc.inputFileName)
+			if err != nil {
+				t.Fatalf("error opening test input file: %v", err)
+			}
+			defer file.Close()
+
+			values := defaultConfig()
+			values.Proxy.LogLevel = "debug"
+			transformer := &resourceTransformerInject{
+				injectProxy:         true,
+				values:              values,
+				overrideAnnotations: getOverrideAnnotations(values, defaultConfig()),
+				allowNsInject:       true,
+				reportFormat:        tc.format,
+			}
+
+			output := new(bytes.Buffer)
+			report := new(bytes.Buffer)
+			transformInput([]io.Reader{bufio.NewReader(file)}, report, output, transformer)
+
+			var record reportRecord
+			if tc.format == jsonOutput {
+				err = json.Unmarshal(report.Bytes(), &record)
+			} else {
+				err = yaml.Unmarshal(bytes.TrimPrefix(report.Bytes(), []byte("---\n")), &record)
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error parsing the report %q: %s", report, err)
+			}
+			if !reflect.DeepEqual(record, tc.expected) {
+				t.Errorf("Expected record\n%+v\nbut got\n%+v", tc.expected, record)
+			}
+		})
+	}
+}
+
+func TestValidateReportFormat(t *testing.T) {
+	for _, format := range []string{"", jsonOutput, yamlOutput} {
+		if err := validateReportFormat(format); err != nil {
+			t.Errorf("Unexpected error for format %q: %s", format, err)
+		}
+	}
+	if err := validateReportFormat("table"); err == nil {
+		t.Error("Expected an error for format \"table\"")
+	}
+}
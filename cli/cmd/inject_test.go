@@ -188,6 +188,13 @@ func TestUninjectAndInject(t *testing.T) {
 			injectProxy:      false,
 			testInjectConfig: defaultValues,
 		},
+		{
+			inputFileName:    "inject_emojivoto_job.input.yml",
+			goldenFileName:   "inject_emojivoto_job.golden.yml",
+			reportFileName:   "inject_emojivoto_job.report",
+			injectProxy:      true,
+			testInjectConfig: defaultValues,
+		},
 		{
 			inputFileName:    "inject_emojivoto_pod.input.yml",
 			goldenFileName:   "inject_emojivoto_pod.golden.yml",
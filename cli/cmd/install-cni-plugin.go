@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/linkerd/linkerd2/pkg/charts"
 	cnicharts "github.com/linkerd/linkerd2/pkg/charts/cni"
@@ -38,8 +39,17 @@ type cniPluginOptions struct {
 	destCNINetDir       string
 	destCNIBinDir       string
 	useWaitFlag         bool
+	enableIPv6          bool
 	priorityClassName   string
 	installNamespace    bool
+	apiTimeout          string
+	failurePolicy       string
+	enablePodCache      bool
+	podCacheDir         string
+	podCacheTimeout     string
+
+	enableRepairController    bool
+	repairControllerEvictPods bool
 }
 
 func (options *cniPluginOptions) validate() error {
@@ -62,6 +72,16 @@ func (options *cniPluginOptions) validate() error {
 	if err := validateRangeSlice(options.ignoreOutboundPorts); err != nil {
 		return err
 	}
+
+	if options.failurePolicy != "fail-open" && options.failurePolicy != "fail-closed" {
+		return fmt.Errorf("--failure-policy must be one of: fail-open, fail-closed")
+	}
+
+	for flag, timeout := range map[string]string{"--api-timeout": options.apiTimeout, "--pod-cache-timeout": options.podCacheTimeout} {
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+			return fmt.Errorf("%s must be a positive duration", flag)
+		}
+	}
 	return nil
 }
 
@@ -110,6 +130,14 @@ assumes that the 'linkerd install' command will be executed with the
 	cmd.PersistentFlags().StringVar(&options.destCNIBinDir, "dest-cni-bin-dir", options.destCNIBinDir, "Directory on the host where the CNI binary will be placed")
 	cmd.PersistentFlags().StringVar(&options.priorityClassName, "priority-class-name", options.priorityClassName, "Pod priorityClassName for CNI daemonset's pods")
 	cmd.PersistentFlags().BoolVar(&options.installNamespace, "install-namespace", options.installNamespace, "Whether to create the CNI namespace or not")
+	cmd.PersistentFlags().BoolVar(&options.enableIPv6, "enable-ipv6", options.enableIPv6, "Redirect IPv6 traffic to the proxy as well, through ip6tables, in dual-stack clusters")
+	cmd.PersistentFlags().StringVar(&options.apiTimeout, "api-timeout", options.apiTimeout, "Timeout of the requests the CNI plugin makes to the Kubernetes API")
+	cmd.PersistentFlags().StringVar(&options.failurePolicy, "failure-policy", options.failurePolicy, "Either fail-open, to leave the network of a pod untouched, or fail-closed, to fail its creation, when the CNI plugin can't retrieve its metadata")
+	cmd.PersistentFlags().BoolVar(&options.enablePodCache, "enable-pod-cache", options.enablePodCache, "Maintain a node-local cache of pod metadata, so that the CNI plugin doesn't query the Kubernetes API for every pod")
+	cmd.PersistentFlags().StringVar(&options.podCacheDir, "pod-cache-dir", options.podCacheDir, "Directory on the host where the pod cache is stored")
+	cmd.PersistentFlags().StringVar(&options.podCacheTimeout, "pod-cache-timeout", options.podCacheTimeout, "How long the CNI plugin waits for a pod to show up in the pod cache before querying the Kubernetes API")
+	cmd.PersistentFlags().BoolVar(&options.enableRepairController, "enable-repair-controller", options.enableRepairController, "Report, through events, the meshed pods started since the CNI plugin was installed on their node whose traffic isn't redirected to the proxy")
+	cmd.PersistentFlags().BoolVar(&options.repairControllerEvictPods, "repair-controller-evict-pods", options.repairControllerEvictPods, "Have the repair controller evict the pods it reports, so that they get recreated with their traffic redirected to the proxy")
 	cmd.PersistentFlags().BoolVar(
 		&options.useWaitFlag,
 		"use-wait-flag",
@@ -127,8 +155,8 @@ func newCNIInstallOptionsWithDefaults() (*cniPluginOptions, error) {
 	cniOptions := cniPluginOptions{
 		linkerdVersion:      version.Version,
 		dockerRegistry:      defaultDockerRegistry,
-		proxyControlPort:    4190,
-		proxyAdminPort:      4191,
+		proxyControlPort:    defaults.ProxyControlPort,
+		proxyAdminPort:      defaults.ProxyAdminPort,
 		inboundPort:         defaults.InboundProxyPort,
 		outboundPort:        defaults.OutboundProxyPort,
 		ignoreInboundPorts:  nil,
@@ -139,8 +167,17 @@ func newCNIInstallOptionsWithDefaults() (*cniPluginOptions, error) {
 		destCNINetDir:       defaults.DestCNINetDir,
 		destCNIBinDir:       defaults.DestCNIBinDir,
 		useWaitFlag:         defaults.UseWaitFlag,
+		enableIPv6:          defaults.EnableIPv6,
 		priorityClassName:   defaults.PriorityClassName,
 		installNamespace:    defaults.InstallNamespace,
+		apiTimeout:          defaults.APITimeout,
+		failurePolicy:       defaults.FailurePolicy,
+		enablePodCache:      defaults.EnablePodCache,
+		podCacheDir:         defaults.PodCacheDir,
+		podCacheTimeout:     defaults.PodCacheTimeout,
+
+		enableRepairController:    defaults.EnableRepairController,
+		repairControllerEvictPods: defaults.RepairControllerEvictPods,
 	}
 
 	if defaults.IgnoreInboundPorts != "" {
@@ -160,13 +197,6 @@ func (options *cniPluginOptions) buildValues() (*cnicharts.Values, error) {
 		return nil, err
 	}
 
-	ignoreInboundPorts := []string{
-		fmt.Sprintf("%d", options.proxyControlPort),
-		fmt.Sprintf("%d", options.proxyAdminPort),
-	}
-
-	ignoreInboundPorts = append(ignoreInboundPorts, options.ignoreInboundPorts...)
-
 	portsToRedirect := []string{}
 	for _, p := range options.portsToRedirect {
 		portsToRedirect = append(portsToRedirect, fmt.Sprintf("%d", p))
@@ -177,16 +207,26 @@ func (options *cniPluginOptions) buildValues() (*cnicharts.Values, error) {
 	installValues.LogLevel = options.logLevel
 	installValues.InboundProxyPort = options.inboundPort
 	installValues.OutboundProxyPort = options.outboundPort
-	installValues.IgnoreInboundPorts = strings.Join(ignoreInboundPorts, ",")
+	installValues.ProxyControlPort = options.proxyControlPort
+	installValues.ProxyAdminPort = options.proxyAdminPort
+	installValues.IgnoreInboundPorts = strings.Join(options.ignoreInboundPorts, ",")
 	installValues.IgnoreOutboundPorts = strings.Join(options.ignoreOutboundPorts, ",")
 	installValues.PortsToRedirect = strings.Join(portsToRedirect, ",")
 	installValues.ProxyUID = options.proxyUID
 	installValues.DestCNINetDir = options.destCNINetDir
 	installValues.DestCNIBinDir = options.destCNIBinDir
 	installValues.UseWaitFlag = options.useWaitFlag
+	installValues.EnableIPv6 = options.enableIPv6
 	installValues.Namespace = cniNamespace
 	installValues.PriorityClassName = options.priorityClassName
 	installValues.InstallNamespace = options.installNamespace
+	installValues.APITimeout = options.apiTimeout
+	installValues.FailurePolicy = options.failurePolicy
+	installValues.EnablePodCache = options.enablePodCache
+	installValues.PodCacheDir = options.podCacheDir
+	installValues.PodCacheTimeout = options.podCacheTimeout
+	installValues.EnableRepairController = options.enableRepairController
+	installValues.RepairControllerEvictPods = options.repairControllerEvictPods
 	return installValues, nil
 }
 
@@ -29,6 +29,15 @@ func TestRenderCNIPlugin(t *testing.T) {
 		destCNIBinDir:       "/opt/my-cni/bin",
 		priorityClassName:   "system-node-critical",
 		installNamespace:    true,
+		apiTimeout:          "5s",
+		failurePolicy:       "fail-open",
+		enablePodCache:      true,
+		enableIPv6:          true,
+		podCacheDir:         "/var/run/my-cni/pod-cache",
+		podCacheTimeout:     "1s",
+
+		enableRepairController:    true,
+		repairControllerEvictPods: true,
 	}
 
 	otherNamespace := "other"
@@ -49,6 +58,9 @@ func TestRenderCNIPlugin(t *testing.T) {
 		destCNIBinDir:       "/etc/kubernetes/cni/net.d",
 		priorityClassName:   "system-node-critical",
 		installNamespace:    true,
+		apiTimeout:          "10s",
+		failurePolicy:       "fail-closed",
+		podCacheTimeout:     "2s",
 	}
 
 	fullyConfiguredOptionsNoNamespace := &cniPluginOptions{
@@ -67,6 +79,9 @@ func TestRenderCNIPlugin(t *testing.T) {
 		destCNIBinDir:       "/opt/my-cni/bin",
 		priorityClassName:   "system-node-critical",
 		installNamespace:    false,
+		apiTimeout:          "10s",
+		failurePolicy:       "fail-closed",
+		podCacheTimeout:     "2s",
 	}
 
 	defaultOptionsWithSkipPorts, err := newCNIInstallOptionsWithDefaults()
@@ -66,6 +66,7 @@ var (
 		"templates/heartbeat-rbac.yaml",
 		"templates/serviceprofile-crd.yaml",
 		"templates/trafficsplit-crd.yaml",
+		"templates/proxyconfig-crd.yaml",
 		"templates/proxy-injector-rbac.yaml",
 		"templates/psp.yaml",
 	}
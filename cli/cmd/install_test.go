@@ -37,6 +37,7 @@ func TestRender(t *testing.T) {
 		WebhookFailurePolicy:    "WebhookFailurePolicy",
 		OmitWebhookSideEffects:  false,
 		HeartbeatSchedule:       "1 2 3 4 5",
+		HeartbeatURL:            "HeartbeatURL",
 		InstallNamespace:        true,
 		Identity:                defaultValues.Identity,
 		NodeSelector:            defaultValues.NodeSelector,
@@ -166,6 +167,8 @@ func TestRender(t *testing.T) {
 		t.Fatalf("Unexpected error: %v\n", err)
 	}
 	withControlPlaneTracingValues.ControlPlaneTracing = true
+	withControlPlaneTracingValues.ControlPlaneTracingSampleRatio = 0.5
+	withControlPlaneTracingValues.ControlPlaneTracingResourceAttributes = "deployment.environment=test"
 	addFakeTLSSecrets(withControlPlaneTracingValues)
 
 	customRegistryOverride := "my.custom.registry/linkerd-io"
@@ -383,6 +386,24 @@ func TestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("Rejects invalid injection policy rules", func(t *testing.T) {
+		values, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		values.ProxyInjector.InjectionPolicy = []charts.InjectionRule{{Name: "bad", Action: "inject"}}
+		expected := "invalid injection policy rule \"bad\": unknown action \"inject\", valid actions are: [deny, allow, enforce]"
+
+		err = validateValues(context.Background(), nil, values)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
 	t.Run("Properly validates proxy log level", func(t *testing.T) {
 		testCases := []struct {
 			input string
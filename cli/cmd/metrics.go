@@ -18,15 +18,43 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+const textOutput = "text"
+
 type metricsOptions struct {
 	namespace string
 	pod       string
+	metrics   []string
+	labels    []string
+	aggregate bool
+	diff      time.Duration
+	output    string
 }
 
 func newMetricsOptions() *metricsOptions {
 	return &metricsOptions{
-		pod: "",
+		pod:       "",
+		metrics:   []string{},
+		labels:    []string{},
+		aggregate: false,
+		diff:      0,
+		output:    textOutput,
+	}
+}
+
+func (o *metricsOptions) validate() error {
+	if o.output != textOutput && o.output != tableOutput && o.output != jsonOutput {
+		return fmt.Errorf("--output currently only supports %s, %s and %s", textOutput, tableOutput, jsonOutput)
+	}
+	if o.diff < 0 {
+		return errors.New("--diff must be a positive duration")
 	}
+	return nil
+}
+
+// raw returns true if the metrics are printed as returned by the proxies,
+// without being parsed
+func (o *metricsOptions) raw(filter *metricsFilter) bool {
+	return o.output == textOutput && filter.empty() && !o.aggregate && o.diff == 0
 }
 
 func newCmdMetrics() *cobra.Command {
@@ -40,6 +68,12 @@ func newCmdMetrics() *cobra.Command {
   This command initiates a port-forward to a given pod or set of pods, and
   queries the /metrics endpoint on the Linkerd proxies.
 
+  The metrics can be filtered by name with --metric and by label with --label.
+  With --aggregate, the metrics with the same name and labels are summed
+  across pods, histograms being merged bucket by bucket. With --diff, two
+  snapshots are taken the given duration apart, and the increase of the
+  metrics between them is shown instead of their value.
+
   The RESOURCE argument specifies the target resource to query metrics for:
   (TYPE/NAME)
 
@@ -67,6 +101,14 @@ func newCmdMetrics() *cobra.Command {
   # Get metrics from the web deployment in the emojivoto namespace.
   linkerd diagnostics proxy-metrics -n emojivoto deploy/web
 
+  # Get the inbound request totals of the web deployment, summed across pods.
+  linkerd diagnostics proxy-metrics -n emojivoto deploy/web \
+    --metric request_total --label direction=inbound --aggregate -o table
+
+  # Get the responses of the web deployment during the next 30 seconds.
+  linkerd diagnostics proxy-metrics -n emojivoto deploy/web \
+    --metric 'response_.*' --aggregate --diff 30s -o json
+
   # Get metrics from the linkerd-destination pod in the linkerd namespace.
   linkerd diagnostics proxy-metrics -n linkerd $(
     kubectl --namespace linkerd get pod \
@@ -78,6 +120,13 @@ func newCmdMetrics() *cobra.Command {
 			if options.namespace == "" {
 				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
 			}
+			if err := options.validate(); err != nil {
+				return err
+			}
+			filter, err := newMetricsFilter(options.metrics, options.labels)
+			if err != nil {
+				return err
+			}
 			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
 			if err != nil {
 				return err
@@ -90,6 +139,19 @@ func newCmdMetrics() *cobra.Command {
 
 			results := getMetrics(k8sAPI, pods, k8s.ProxyAdminPortName, 30*time.Second, verbose)
 
+			if !options.raw(filter) {
+				snapshot := parseMetricsResults(results, filter)
+				if options.diff > 0 {
+					time.Sleep(options.diff)
+					results = getMetrics(k8sAPI, pods, k8s.ProxyAdminPortName, 30*time.Second, verbose)
+					snapshot = diffMetrics(snapshot, parseMetricsResults(results, filter))
+				}
+				if options.aggregate {
+					snapshot = aggregateMetrics(snapshot)
+				}
+				return renderMetrics(stdout, stderr, snapshot, options)
+			}
+
 			var buf bytes.Buffer
 			for i, result := range results {
 				content := fmt.Sprintf("#\n# POD %s (%d of %d)\n#\n", result.pod, i+1, len(results))
@@ -107,6 +169,16 @@ func newCmdMetrics() *cobra.Command {
 	}
 
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of resource")
+	cmd.PersistentFlags().StringArrayVar(&options.metrics, "metric", options.metrics,
+		"Only show the metrics whose name fully matches this regular expression (can be repeated)")
+	cmd.PersistentFlags().StringArrayVar(&options.labels, "label", options.labels,
+		"Only show the metrics with this label, as key=value or key!=value (can be repeated)")
+	cmd.PersistentFlags().BoolVar(&options.aggregate, "aggregate", options.aggregate,
+		"Sum the metrics with the same name and labels across pods")
+	cmd.PersistentFlags().DurationVar(&options.diff, "diff", options.diff,
+		"Take two snapshots this far apart and show the increase of the metrics between them")
+	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
+		fmt.Sprintf("Output format; one of: \"%s\", \"%s\" or \"%s\"", textOutput, tableOutput, jsonOutput))
 
 	pkgcmd.ConfigureNamespaceFlagCompletion(cmd, []string{"namespace"},
 		kubeconfigPath, impersonate, impersonateGroup, kubeContext)
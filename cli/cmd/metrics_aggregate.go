@@ -0,0 +1,476 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// podMetrics holds the metric families parsed from the proxy of a pod, or
+// aggregated over the proxies of several pods
+type podMetrics struct {
+	pod      string
+	pods     int
+	families map[string]*dto.MetricFamily
+	err      error
+}
+
+// metricSample is a single sample of a metric family, as rendered by the
+// table and JSON outputs. Histograms and summaries are flattened into their
+// _bucket, _sum and _count samples, as in the Prometheus text format.
+type metricSample struct {
+	Pod    string            `json:"pod,omitempty"`
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Labels map[string]string `json:"labels"`
+	Value  float64           `json:"value"`
+}
+
+type labelMatcher struct {
+	name  string
+	value string
+	equal bool
+}
+
+// metricsFilter selects the metric families by name and their metrics by
+// label
+type metricsFilter struct {
+	names  []*regexp.Regexp
+	labels []labelMatcher
+}
+
+func newMetricsFilter(names []string, labels []string) (*metricsFilter, error) {
+	filter := &metricsFilter{}
+	for _, name := range names {
+		re, err := regexp.Compile("^(?:" + name + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric name regular expression \"%s\": %s", name, err)
+		}
+		filter.names = append(filter.names, re)
+	}
+	for _, label := range labels {
+		matcher := labelMatcher{equal: true}
+		kv := strings.SplitN(label, "!=", 2)
+		if len(kv) == 2 {
+			matcher.equal = false
+		} else {
+			kv = strings.SplitN(label, "=", 2)
+		}
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid label matcher \"%s\": must be key=value or key!=value", label)
+		}
+		matcher.name = kv[0]
+		matcher.value = kv[1]
+		filter.labels = append(filter.labels, matcher)
+	}
+	return filter, nil
+}
+
+func (f *metricsFilter) empty() bool {
+	return len(f.names) == 0 && len(f.labels) == 0
+}
+
+// apply removes from families the families and metrics not selected by the
+// filter
+func (f *metricsFilter) apply(families map[string]*dto.MetricFamily) {
+	for name, family := range families {
+		if !f.matchName(name) {
+			delete(families, name)
+			continue
+		}
+		metrics := []*dto.Metric{}
+		for _, metric := range family.GetMetric() {
+			if f.matchLabels(metric) {
+				metrics = append(metrics, metric)
+			}
+		}
+		if len(metrics) == 0 {
+			delete(families, name)
+			continue
+		}
+		family.Metric = metrics
+	}
+}
+
+func (f *metricsFilter) matchName(name string) bool {
+	if len(f.names) == 0 {
+		return true
+	}
+	for _, re := range f.names {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *metricsFilter) matchLabels(metric *dto.Metric) bool {
+	labels := map[string]string{}
+	for _, pair := range metric.GetLabel() {
+		labels[pair.GetName()] = pair.GetValue()
+	}
+	for _, matcher := range f.labels {
+		if (labels[matcher.name] == matcher.value) != matcher.equal {
+			return false
+		}
+	}
+	return true
+}
+
+// parseMetricsResults parses the Prometheus text format of the results and
+// applies the filter to them
+func parseMetricsResults(results []metricsResult, filter *metricsFilter) []podMetrics {
+	pods := make([]podMetrics, 0, len(results))
+	for _, result := range results {
+		pm := podMetrics{pod: result.pod, pods: 1, err: result.err}
+		if pm.err == nil {
+			var parser expfmt.TextParser
+			pm.families, pm.err = parser.TextToMetricFamilies(bytes.NewReader(result.metrics))
+		}
+		if pm.err == nil {
+			filter.apply(pm.families)
+		}
+		pods = append(pods, pm)
+	}
+	return pods
+}
+
+// aggregateMetrics sums the metrics with the same name and labels across pods.
+// Histograms are merged bucket by bucket, and the quantiles of summaries,
+// which can't be merged, are dropped. The pods that failed are returned as is,
+// followed by the aggregate of the others.
+func aggregateMetrics(pods []podMetrics) []podMetrics {
+	aggregated := []podMetrics{}
+	total := podMetrics{families: map[string]*dto.MetricFamily{}}
+	// the metrics of every family of the total, by labels
+	index := map[string]map[string]*dto.Metric{}
+	for _, pm := range pods {
+		if pm.err != nil {
+			aggregated = append(aggregated, pm)
+			continue
+		}
+		total.pods += pm.pods
+		for name, family := range pm.families {
+			dst, ok := total.families[name]
+			if !ok {
+				dst = &dto.MetricFamily{Name: family.Name, Help: family.Help, Type: family.Type}
+				total.families[name] = dst
+				index[name] = map[string]*dto.Metric{}
+			}
+			for _, metric := range family.GetMetric() {
+				mergeMetric(dst, index[name], metric)
+			}
+		}
+	}
+	return append(aggregated, total)
+}
+
+// mergeMetric adds the metric to the metric of the family with the same
+// labels, found in metrics, or appends a copy of it to the family
+func mergeMetric(family *dto.MetricFamily, metrics map[string]*dto.Metric, metric *dto.Metric) {
+	key := labelsKey(metric)
+	if dst, ok := metrics[key]; ok {
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			dst.Counter.Value = proto.Float64(dst.GetCounter().GetValue() + metric.GetCounter().GetValue())
+		case dto.MetricType_GAUGE:
+			dst.Gauge.Value = proto.Float64(dst.GetGauge().GetValue() + metric.GetGauge().GetValue())
+		case dto.MetricType_UNTYPED:
+			dst.Untyped.Value = proto.Float64(dst.GetUntyped().GetValue() + metric.GetUntyped().GetValue())
+		case dto.MetricType_SUMMARY:
+			dst.Summary.SampleCount = proto.Uint64(dst.GetSummary().GetSampleCount() + metric.GetSummary().GetSampleCount())
+			dst.Summary.SampleSum = proto.Float64(dst.GetSummary().GetSampleSum() + metric.GetSummary().GetSampleSum())
+		case dto.MetricType_HISTOGRAM:
+			dst.Histogram.SampleCount = proto.Uint64(dst.GetHistogram().GetSampleCount() + metric.GetHistogram().GetSampleCount())
+			dst.Histogram.SampleSum = proto.Float64(dst.GetHistogram().GetSampleSum() + metric.GetHistogram().GetSampleSum())
+			mergeBuckets(dst.Histogram, metric.GetHistogram())
+		}
+		return
+	}
+
+	clone := proto.Clone(metric).(*dto.Metric)
+	clone.TimestampMs = nil
+	if clone.Summary != nil {
+		clone.Summary.Quantile = nil
+	}
+	family.Metric = append(family.Metric, clone)
+	metrics[key] = clone
+}
+
+// mergeBuckets adds the cumulative counts of the src buckets to the dst
+// buckets with the same upper bound
+func mergeBuckets(dst *dto.Histogram, src *dto.Histogram) {
+	buckets := map[float64]*dto.Bucket{}
+	for _, bucket := range dst.GetBucket() {
+		buckets[bucket.GetUpperBound()] = bucket
+	}
+	for _, bucket := range src.GetBucket() {
+		if b, ok := buckets[bucket.GetUpperBound()]; ok {
+			b.CumulativeCount = proto.Uint64(b.GetCumulativeCount() + bucket.GetCumulativeCount())
+			continue
+		}
+		dst.Bucket = append(dst.Bucket, proto.Clone(bucket).(*dto.Bucket))
+	}
+	sort.Slice(dst.Bucket, func(i, j int) bool {
+		return dst.Bucket[i].GetUpperBound() < dst.Bucket[j].GetUpperBound()
+	})
+}
+
+// diffMetrics returns the difference between two snapshots of the metrics of
+// the same pods. Counters that decreased between the snapshots, because the
+// proxy restarted, are assumed to have been reset, and their value in the
+// second snapshot is used as the difference. Gauges are subtracted as is.
+func diffMetrics(before []podMetrics, after []podMetrics) []podMetrics {
+	previous := map[string]podMetrics{}
+	for _, pm := range before {
+		previous[pm.pod] = pm
+	}
+
+	diffs := make([]podMetrics, 0, len(after))
+	for _, pm := range after {
+		if pm.err != nil {
+			diffs = append(diffs, pm)
+			continue
+		}
+		prev, ok := previous[pm.pod]
+		if !ok {
+			diffs = append(diffs, podMetrics{pod: pm.pod, err: fmt.Errorf("pod not found in the first snapshot")})
+			continue
+		}
+		if prev.err != nil {
+			diffs = append(diffs, podMetrics{pod: pm.pod, err: fmt.Errorf("first snapshot failed: %s", prev.err)})
+			continue
+		}
+
+		diff := podMetrics{pod: pm.pod, pods: pm.pods, families: map[string]*dto.MetricFamily{}}
+		for name, family := range pm.families {
+			prevMetrics := map[string]*dto.Metric{}
+			for _, metric := range prev.families[name].GetMetric() {
+				prevMetrics[labelsKey(metric)] = metric
+			}
+			dst := &dto.MetricFamily{Name: family.Name, Help: family.Help, Type: family.Type}
+			for _, metric := range family.GetMetric() {
+				dst.Metric = append(dst.Metric, diffMetric(family.GetType(), prevMetrics[labelsKey(metric)], metric))
+			}
+			diff.families[name] = dst
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+func diffMetric(metricType dto.MetricType, before *dto.Metric, after *dto.Metric) *dto.Metric {
+	diff := proto.Clone(after).(*dto.Metric)
+	diff.TimestampMs = nil
+	if before == nil {
+		return diff
+	}
+
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		diff.Counter.Value = proto.Float64(counterDelta(before.GetCounter().GetValue(), after.GetCounter().GetValue()))
+	case dto.MetricType_GAUGE:
+		diff.Gauge.Value = proto.Float64(after.GetGauge().GetValue() - before.GetGauge().GetValue())
+	case dto.MetricType_UNTYPED:
+		diff.Untyped.Value = proto.Float64(after.GetUntyped().GetValue() - before.GetUntyped().GetValue())
+	case dto.MetricType_SUMMARY:
+		diff.Summary.Quantile = nil
+		diff.Summary.SampleSum = proto.Float64(counterDelta(before.GetSummary().GetSampleSum(), after.GetSummary().GetSampleSum()))
+		diff.Summary.SampleCount = proto.Uint64(uint64(counterDelta(
+			float64(before.GetSummary().GetSampleCount()), float64(after.GetSummary().GetSampleCount()))))
+	case dto.MetricType_HISTOGRAM:
+		if after.GetHistogram().GetSampleCount() < before.GetHistogram().GetSampleCount() {
+			// the histogram was reset
+			return diff
+		}
+		diff.Histogram.SampleSum = proto.Float64(after.GetHistogram().GetSampleSum() - before.GetHistogram().GetSampleSum())
+		diff.Histogram.SampleCount = proto.Uint64(after.GetHistogram().GetSampleCount() - before.GetHistogram().GetSampleCount())
+		prevBuckets := map[float64]uint64{}
+		for _, bucket := range before.GetHistogram().GetBucket() {
+			prevBuckets[bucket.GetUpperBound()] = bucket.GetCumulativeCount()
+		}
+		for _, bucket := range diff.Histogram.Bucket {
+			bucket.CumulativeCount = proto.Uint64(uint64(counterDelta(
+				float64(prevBuckets[bucket.GetUpperBound()]), float64(bucket.GetCumulativeCount()))))
+		}
+	}
+	return diff
+}
+
+func counterDelta(before float64, after float64) float64 {
+	if after < before {
+		return after
+	}
+	return after - before
+}
+
+// labelsKey returns a string identifying the label set of a metric
+func labelsKey(metric *dto.Metric) string {
+	return formatLabels(labelsMap(metric.GetLabel()))
+}
+
+func labelsMap(pairs []*dto.LabelPair) map[string]string {
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		labels[pair.GetName()] = pair.GetValue()
+	}
+	return labels
+}
+
+// formatLabels formats labels as in the Prometheus text format, sorted by
+// name
+func formatLabels(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, labels[name]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// samples flattens the metric families of a pod into samples, sorted by name
+// and labels
+func (pm podMetrics) samples() []metricSample {
+	samples := []metricSample{}
+	add := func(name string, family *dto.MetricFamily, metric *dto.Metric, value float64, extra ...string) {
+		labels := labelsMap(metric.GetLabel())
+		for i := 0; i+1 < len(extra); i += 2 {
+			labels[extra[i]] = extra[i+1]
+		}
+		samples = append(samples, metricSample{
+			Pod:    pm.pod,
+			Name:   name,
+			Type:   strings.ToLower(family.GetType().String()),
+			Labels: labels,
+			Value:  value,
+		})
+	}
+
+	for name, family := range pm.families {
+		for _, metric := range family.GetMetric() {
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, family, metric, metric.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, family, metric, metric.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add(name, family, metric, metric.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				for _, q := range metric.GetSummary().GetQuantile() {
+					add(name, family, metric, q.GetValue(), "quantile", formatFloat(q.GetQuantile()))
+				}
+				add(name+"_sum", family, metric, metric.GetSummary().GetSampleSum())
+				add(name+"_count", family, metric, float64(metric.GetSummary().GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				for _, b := range metric.GetHistogram().GetBucket() {
+					add(name+"_bucket", family, metric, float64(b.GetCumulativeCount()), "le", formatFloat(b.GetUpperBound()))
+				}
+				add(name+"_sum", family, metric, metric.GetHistogram().GetSampleSum())
+				add(name+"_count", family, metric, float64(metric.GetHistogram().GetSampleCount()))
+			}
+		}
+	}
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		if samples[i].Name != samples[j].Name {
+			return samples[i].Name < samples[j].Name
+		}
+		return formatLabels(samples[i].Labels) < formatLabels(samples[j].Labels)
+	})
+	return samples
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// renderMetrics writes the metrics of the pods in the requested output
+// format. Errors are written inline in the text output, and to werr
+// otherwise.
+func renderMetrics(wout io.Writer, werr io.Writer, pods []podMetrics, options *metricsOptions) error {
+	switch options.output {
+	case jsonOutput:
+		samples := []metricSample{}
+		for _, pm := range pods {
+			if pm.err != nil {
+				fmt.Fprintf(werr, "Error fetching the metrics of pod %s: %s\n", pm.pod, pm.err)
+				continue
+			}
+			samples = append(samples, pm.samples()...)
+		}
+		b, err := json.MarshalIndent(samples, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(wout, "%s\n", b)
+		return err
+
+	case tableOutput:
+		valueHeader := "VALUE"
+		if options.diff > 0 {
+			valueHeader = "DELTA"
+		}
+		w := tabwriter.NewWriter(wout, 0, 0, 2, ' ', 0)
+		if options.aggregate {
+			fmt.Fprintf(w, "NAME\tLABELS\t%s\n", valueHeader)
+		} else {
+			fmt.Fprintf(w, "POD\tNAME\tLABELS\t%s\n", valueHeader)
+		}
+		for _, pm := range pods {
+			if pm.err != nil {
+				fmt.Fprintf(werr, "Error fetching the metrics of pod %s: %s\n", pm.pod, pm.err)
+				continue
+			}
+			for _, s := range pm.samples() {
+				if options.aggregate {
+					fmt.Fprintf(w, "%s\t%s\t%s\n", s.Name, formatLabels(s.Labels), formatFloat(s.Value))
+				} else {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Pod, s.Name, formatLabels(s.Labels), formatFloat(s.Value))
+				}
+			}
+		}
+		return w.Flush()
+
+	default:
+		for i, pm := range pods {
+			switch {
+			case pm.err != nil:
+				fmt.Fprintf(wout, "#\n# POD %s (%d of %d)\n#\n# ERROR %s\n", pm.pod, i+1, len(pods), pm.err)
+				continue
+			case options.aggregate:
+				fmt.Fprintf(wout, "#\n# AGGREGATED OVER %d PODS\n#\n", pm.pods)
+			default:
+				fmt.Fprintf(wout, "#\n# POD %s (%d of %d)\n#\n", pm.pod, i+1, len(pods))
+			}
+			if options.diff > 0 {
+				fmt.Fprintf(wout, "# DIFF OVER %s\n", options.diff.Round(time.Second))
+			}
+			names := make([]string, 0, len(pm.families))
+			for name := range pm.families {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				if _, err := expfmt.MetricFamilyToText(wout, pm.families[name]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
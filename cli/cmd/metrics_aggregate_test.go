@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const podAMetrics = `# HELP request_total Total count of HTTP requests.
+# TYPE request_total counter
+request_total{direction="inbound",authority="web.emojivoto.svc.cluster.local:80"} 10
+request_total{direction="outbound",authority="emoji-svc.emojivoto.svc.cluster.local:8080"} 4
+# HELP response_latency_ms Elapsed times between a request's headers being received and its response stream completing
+# TYPE response_latency_ms histogram
+response_latency_ms_bucket{direction="inbound",le="1"} 2
+response_latency_ms_bucket{direction="inbound",le="10"} 8
+response_latency_ms_bucket{direction="inbound",le="+Inf"} 10
+response_latency_ms_sum{direction="inbound"} 50
+response_latency_ms_count{direction="inbound"} 10
+# HELP tcp_open_connections Number of currently open connections.
+# TYPE tcp_open_connections gauge
+tcp_open_connections{direction="inbound"} 3
+`
+
+const podBMetrics = `# HELP request_total Total count of HTTP requests.
+# TYPE request_total counter
+request_total{direction="inbound",authority="web.emojivoto.svc.cluster.local:80"} 5
+# HELP response_latency_ms Elapsed times between a request's headers being received and its response stream completing
+# TYPE response_latency_ms histogram
+response_latency_ms_bucket{direction="inbound",le="1"} 1
+response_latency_ms_bucket{direction="inbound",le="10"} 4
+response_latency_ms_bucket{direction="inbound",le="+Inf"} 5
+response_latency_ms_sum{direction="inbound"} 20
+response_latency_ms_count{direction="inbound"} 5
+# HELP tcp_open_connections Number of currently open connections.
+# TYPE tcp_open_connections gauge
+tcp_open_connections{direction="inbound"} 1
+`
+
+func mustFilter(t *testing.T, names []string, labels []string) *metricsFilter {
+	t.Helper()
+	filter, err := newMetricsFilter(names, labels)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return filter
+}
+
+func renderToString(t *testing.T, pods []podMetrics, options *metricsOptions) string {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	if err := renderMetrics(&out, &errOut, pods, options); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return out.String() + errOut.String()
+}
+
+func TestNewMetricsFilter(t *testing.T) {
+	for _, label := range []string{"direction", "=inbound"} {
+		if _, err := newMetricsFilter(nil, []string{label}); err == nil {
+			t.Errorf("Expected an error for label matcher %q", label)
+		}
+	}
+	if _, err := newMetricsFilter([]string{"request_total("}, nil); err == nil {
+		t.Error("Expected an error for an invalid regular expression")
+	}
+}
+
+func TestProxyMetricsFilter(t *testing.T) {
+	results := []metricsResult{{pod: "web-a", metrics: []byte(podAMetrics)}}
+	pods := parseMetricsResults(results, mustFilter(t, []string{"request_.*", "tcp_open_connections"}, []string{"direction!=outbound"}))
+
+	options := newMetricsOptions()
+	options.output = tableOutput
+	expected := `POD    NAME                  LABELS                                                                VALUE
+web-a  request_total         {authority="web.emojivoto.svc.cluster.local:80",direction="inbound"}  10
+web-a  tcp_open_connections  {direction="inbound"}                                                 3
+`
+	if output := renderToString(t, pods, options); output != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", output, expected)
+	}
+}
+
+func TestProxyMetricsAggregate(t *testing.T) {
+	results := []metricsResult{
+		{pod: "web-a", metrics: []byte(podAMetrics)},
+		{pod: "web-b", metrics: []byte(podBMetrics)},
+		{pod: "web-c", err: errors.New("pod not running: web-c")},
+	}
+	pods := aggregateMetrics(parseMetricsResults(results, mustFilter(t, nil, []string{"direction=inbound"})))
+
+	options := newMetricsOptions()
+	options.output = tableOutput
+	options.aggregate = true
+	expected := `NAME                        LABELS                                                                VALUE
+request_total               {authority="web.emojivoto.svc.cluster.local:80",direction="inbound"}  15
+response_latency_ms_bucket  {direction="inbound",le="+Inf"}                                       15
+response_latency_ms_bucket  {direction="inbound",le="1"}                                          3
+response_latency_ms_bucket  {direction="inbound",le="10"}                                         12
+response_latency_ms_count   {direction="inbound"}                                                 15
+response_latency_ms_sum     {direction="inbound"}                                                 70
+tcp_open_connections        {direction="inbound"}                                                 4
+Error fetching the metrics of pod web-c: pod not running: web-c
+`
+	if output := renderToString(t, pods, options); output != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", output, expected)
+	}
+
+	options.output = textOutput
+	expected = `#
+# POD web-c (1 of 2)
+#
+# ERROR pod not running: web-c
+#
+# AGGREGATED OVER 2 PODS
+#
+# HELP request_total Total count of HTTP requests.
+# TYPE request_total counter
+request_total{direction="inbound",authority="web.emojivoto.svc.cluster.local:80"} 15
+# HELP response_latency_ms Elapsed times between a request's headers being received and its response stream completing
+# TYPE response_latency_ms histogram
+response_latency_ms_bucket{direction="inbound",le="1"} 3
+response_latency_ms_bucket{direction="inbound",le="10"} 12
+response_latency_ms_bucket{direction="inbound",le="+Inf"} 15
+response_latency_ms_sum{direction="inbound"} 70
+response_latency_ms_count{direction="inbound"} 15
+# HELP tcp_open_connections Number of currently open connections.
+# TYPE tcp_open_connections gauge
+tcp_open_connections{direction="inbound"} 4
+`
+	if output := renderToString(t, pods, options); output != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", output, expected)
+	}
+}
+
+func TestProxyMetricsDiff(t *testing.T) {
+	filter := mustFilter(t, []string{"request_total", "tcp_open_connections"}, []string{"direction=inbound"})
+	before := parseMetricsResults([]metricsResult{
+		{pod: "web-a", metrics: []byte(podAMetrics)},
+		{pod: "web-b", metrics: []byte(podAMetrics)},
+	}, filter)
+	// web-a served 5 more requests and closed 2 connections, while the proxy
+	// of web-b restarted and served 5 requests since
+	after := parseMetricsResults([]metricsResult{
+		{pod: "web-a", metrics: []byte(podAMetrics)},
+		{pod: "web-b", metrics: []byte(podBMetrics)},
+		{pod: "web-c", metrics: []byte(podBMetrics)},
+	}, filter)
+	after[0].families["request_total"].Metric[0].Counter.Value = proto.Float64(15)
+	after[0].families["tcp_open_connections"].Metric[0].Gauge.Value = proto.Float64(1)
+
+	options := newMetricsOptions()
+	options.output = jsonOutput
+	options.diff = 10 * time.Second
+	expected := `[
+  {
+    "pod": "web-a",
+    "name": "request_total",
+    "type": "counter",
+    "labels": {
+      "authority": "web.emojivoto.svc.cluster.local:80",
+      "direction": "inbound"
+    },
+    "value": 5
+  },
+  {
+    "pod": "web-a",
+    "name": "tcp_open_connections",
+    "type": "gauge",
+    "labels": {
+      "direction": "inbound"
+    },
+    "value": -2
+  },
+  {
+    "pod": "web-b",
+    "name": "request_total",
+    "type": "counter",
+    "labels": {
+      "authority": "web.emojivoto.svc.cluster.local:80",
+      "direction": "inbound"
+    },
+    "value": 5
+  },
+  {
+    "pod": "web-b",
+    "name": "tcp_open_connections",
+    "type": "gauge",
+    "labels": {
+      "direction": "inbound"
+    },
+    "value": -2
+  }
+]
+Error fetching the metrics of pod web-c: pod not found in the first snapshot
+`
+	if output := renderToString(t, diffMetrics(before, after), options); output != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", output, expected)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/url"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/linkerd/linkerd2/pkg/trace"
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
@@ -85,6 +87,13 @@ func makeInstallUpgradeFlags(defaults *l5dcharts.Values) ([]flag.Flag, *pflag.Fl
 				return nil
 			}),
 
+		flag.NewStringFlag(installUpgradeFlags, "heartbeat-url", defaults.HeartbeatURL,
+			"URL the heartbeat cronjob sends its payload to (default https://versioncheck.linkerd.io/version.json)",
+			func(values *l5dcharts.Values, value string) error {
+				values.HeartbeatURL = value
+				return nil
+			}),
+
 		flag.NewDurationFlag(installUpgradeFlags, "identity-issuance-lifetime", issuanceLifetime,
 			"The amount of time for which the Identity issuer should certify identity",
 			func(values *l5dcharts.Values, value time.Duration) error {
@@ -509,6 +518,21 @@ func validateValues(ctx context.Context, k *k8s.KubernetesAPI, values *l5dcharts
 		}
 	}
 
+	if values.HeartbeatURL != "" {
+		if u, err := url.Parse(values.HeartbeatURL); err != nil || !u.IsAbs() || u.Host == "" {
+			return fmt.Errorf("--heartbeat-url must be an absolute URL: %s", values.HeartbeatURL)
+		}
+	}
+
+	if values.ControlPlaneTracing {
+		if values.ControlPlaneTracingProtocol != trace.ProtocolGRPC && values.ControlPlaneTracingProtocol != trace.ProtocolHTTP {
+			return fmt.Errorf("controlPlaneTracingProtocol must be one of: %s, %s", trace.ProtocolGRPC, trace.ProtocolHTTP)
+		}
+		if values.ControlPlaneTracingSampleRatio < 0 || values.ControlPlaneTracingSampleRatio > 1 {
+			return fmt.Errorf("controlPlaneTracingSampleRatio must be between 0 and 1: %v", values.ControlPlaneTracingSampleRatio)
+		}
+	}
+
 	// Validate only if its not empty
 	if values.IdentityTrustDomain != "" {
 		if errs := validation.IsDNS1123Subdomain(values.IdentityTrustDomain); len(errs) > 0 {
@@ -521,6 +545,15 @@ func validateValues(ctx context.Context, k *k8s.KubernetesAPI, values *l5dcharts
 		return err
 	}
 
+	if values.ProxyInjector != nil {
+		for i := range values.ProxyInjector.InjectionPolicy {
+			rule := &values.ProxyInjector.InjectionPolicy[i]
+			if err := inject.ValidateInjectionRule(rule); err != nil {
+				return fmt.Errorf("invalid injection policy rule %q: %s", inject.InjectionRuleID(rule, i), err)
+			}
+		}
+	}
+
 	if values.Identity.Issuer.Scheme == string(corev1.SecretTypeTLS) {
 		if values.Identity.Issuer.TLS.CrtPEM != "" {
 			return errors.New("--identity-issuer-certificate-file must not be specified if --identity-external-issuer=true")
@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	cpuProfile       = "cpu"
+	heapProfile      = "heap"
+	goroutineProfile = "goroutine"
+
+	defaultCPUProfileDuration = 30 * time.Second
+	mergedProfileName         = "merged"
+)
+
+type pprofOptions struct {
+	component   string
+	container   string
+	profileType string
+	duration    time.Duration
+	outputDir   string
+	merge       bool
+}
+
+// pprofResult holds the profile of a control plane container, and the file
+// it was written to
+type pprofResult struct {
+	pod       string
+	container string
+	profile   []byte
+	file      string
+	err       error
+}
+
+func newPprofOptions() *pprofOptions {
+	return &pprofOptions{
+		profileType: cpuProfile,
+		duration:    0,
+	}
+}
+
+func (options *pprofOptions) validate() error {
+	if options.component == "" {
+		return errors.New("--component must be set")
+	}
+	switch options.profileType {
+	case cpuProfile, heapProfile, goroutineProfile:
+	default:
+		return fmt.Errorf("--type must be one of: %s, %s, %s", cpuProfile, heapProfile, goroutineProfile)
+	}
+	if options.duration < 0 || (options.duration > 0 && options.duration%time.Second != 0) {
+		return errors.New("--duration must be a positive number of seconds")
+	}
+	return nil
+}
+
+// path returns the path and query of the pprof endpoint serving the profile
+// of the admin server. The CPU profile lasts for the duration, 30s by
+// default, while the heap and goroutine profiles are deltas over the
+// duration if it's set, and snapshots otherwise.
+func (options *pprofOptions) path() string {
+	seconds := int(options.duration / time.Second)
+	if options.profileType == cpuProfile {
+		if seconds == 0 {
+			seconds = int(defaultCPUProfileDuration / time.Second)
+		}
+		return fmt.Sprintf("/debug/pprof/profile?seconds=%d", seconds)
+	}
+	if seconds == 0 {
+		return fmt.Sprintf("/debug/pprof/%s", options.profileType)
+	}
+	return fmt.Sprintf("/debug/pprof/%s?seconds=%d", options.profileType, seconds)
+}
+
+func newCmdPprof() *cobra.Command {
+	options := newPprofOptions()
+
+	cmd := &cobra.Command{
+		Use:   "pprof [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Collect pprof profiles from the Linkerd control plane containers",
+		Long: `Collect pprof profiles from the Linkerd control plane containers.
+
+  This command initiates a port-forward to the admin server of each replica of
+  a control plane component, and collects their profiles in parallel from the
+  /debug/pprof endpoints. Each profile is written to a file named after its pod
+  and container, in the output directory. With --merge, the profiles of each
+  container are also merged into a single profile named after the container.
+
+  The CPU profile lasts for --duration, 30s by default. The heap and goroutine
+  profiles are snapshots, or deltas over --duration when it's set.
+
+  The profiles can be analyzed with "go tool pprof".`,
+		Example: `  # Collect a 30 seconds CPU profile from each destination replica.
+  linkerd diagnostics pprof --component destination
+
+  # Collect the heap profiles of the identity replicas, and merge them.
+  linkerd diagnostics pprof --component identity --type heap --merge -o profiles`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			if options.outputDir == "" {
+				options.outputDir = fmt.Sprintf("linkerd-pprof-%s-%s-%s", options.component, options.profileType, time.Now().UTC().Format("20060102-150405"))
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+			pods, err := k8sAPI.CoreV1().Pods(controlPlaneNamespace).List(cmd.Context(), metav1.ListOptions{
+				LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerComponentLabel, options.component),
+			})
+			if err != nil {
+				return err
+			}
+			if len(pods.Items) == 0 {
+				return fmt.Errorf("no pods found for component %s in namespace %s", options.component, controlPlaneNamespace)
+			}
+
+			if err := os.MkdirAll(options.outputDir, 0755); err != nil {
+				return err
+			}
+			if options.profileType == cpuProfile {
+				duration := options.duration
+				if duration == 0 {
+					duration = defaultCPUProfileDuration
+				}
+				fmt.Fprintf(stderr, "Collecting %s CPU profiles...\n", duration)
+			}
+
+			results := getProfiles(cmd.Context(), k8sAPI, pods.Items, options)
+			if len(results) == 0 {
+				return fmt.Errorf("no containers of component %s serve the %s port", options.component, adminHTTPPortName)
+			}
+			writeProfiles(options.outputDir, results, options.profileType)
+			profilesErr := printPprofResults(stdout, results)
+
+			if options.merge {
+				files, err := mergeProfiles(options.outputDir, results, options.profileType)
+				if err != nil {
+					return fmt.Errorf("failed to merge the profiles: %s", err)
+				}
+				fmt.Fprintln(stdout, "\nMerged the profiles into:")
+				for _, file := range files {
+					fmt.Fprintf(stdout, "  %s\n", file)
+				}
+			}
+			return profilesErr
+		},
+	}
+
+	cmd.Flags().StringVar(&options.component, "component", options.component,
+		"Control plane component to profile, e.g. destination, identity or proxy-injector")
+	cmd.Flags().StringVar(&options.container, "container", options.container,
+		"Only profile this container of the component pods (default: all the containers with an admin server)")
+	cmd.Flags().StringVar(&options.profileType, "type", options.profileType,
+		fmt.Sprintf("Type of profile, one of: %s, %s, %s", cpuProfile, heapProfile, goroutineProfile))
+	cmd.Flags().DurationVar(&options.duration, "duration", options.duration,
+		"Duration of the CPU profiles (default 30s), or of the heap and goroutine delta profiles (default: snapshots)")
+	cmd.Flags().StringVarP(&options.outputDir, "output-dir", "o", options.outputDir,
+		"Directory the profiles are written to (default: linkerd-pprof-<component>-<type>-<timestamp>)")
+	cmd.Flags().BoolVar(&options.merge, "merge", options.merge, "Also merge the profiles of each container into a single profile")
+
+	return cmd
+}
+
+// getProfiles concurrently collects the profile of each container of the pods
+// serving the admin server. The results are sorted by pod and container.
+func getProfiles(ctx context.Context, k8sAPI *k8s.KubernetesAPI, pods []corev1.Pod, options *pprofOptions) []pprofResult {
+	results := []pprofResult{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, pod := range pods {
+		containers, err := getAllContainersWithPort(pod, adminHTTPPortName)
+		if err != nil {
+			mu.Lock()
+			results = append(results, pprofResult{pod: pod.GetName(), err: err})
+			mu.Unlock()
+			continue
+		}
+		for _, container := range containers {
+			if options.container != "" && container.Name != options.container {
+				continue
+			}
+			wg.Add(1)
+			go func(pod corev1.Pod, container corev1.Container) {
+				defer wg.Done()
+				result := getProfile(ctx, k8sAPI, pod, container, options.path())
+				mu.Lock()
+				results = append(results, result)
+				mu.Unlock()
+			}(pod, container)
+		}
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].pod < results[j].pod ||
+			(results[i].pod == results[j].pod && results[i].container < results[j].container)
+	})
+	return results
+}
+
+func getProfile(ctx context.Context, k8sAPI *k8s.KubernetesAPI, pod corev1.Pod, container corev1.Container, path string) pprofResult {
+	result := pprofResult{pod: pod.GetName(), container: container.Name}
+
+	portForward, err := k8s.NewContainerMetricsForward(k8sAPI, pod, container, verbose, adminHTTPPortName)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer portForward.Stop()
+	if err := portForward.Init(); err != nil {
+		result.err = fmt.Errorf("error running port-forward: %s", err)
+		return result
+	}
+
+	result.profile, result.err = fetchProfile(ctx, portForward.URLFor(path))
+	return result
+}
+
+// fetchProfile returns the profile served at url
+func fetchProfile(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// writeProfiles writes the collected profiles to dir, in files named after
+// their pod and container, and sets the file of the results
+func writeProfiles(dir string, results []pprofResult, profileType string) {
+	for i := range results {
+		result := &results[i]
+		if result.err != nil {
+			continue
+		}
+		file := filepath.Join(dir, fmt.Sprintf("%s-%s.%s.pb.gz", result.pod, result.container, profileType))
+		if err := ioutil.WriteFile(file, result.profile, 0600); err != nil {
+			result.err = err
+			continue
+		}
+		result.file = file
+	}
+}
+
+// mergeProfiles merges the collected profiles of each container into a single
+// profile written to dir, and returns their files sorted by container. A pod
+// can serve several profiled containers, whose profiles can't be merged.
+func mergeProfiles(dir string, results []pprofResult, profileType string) ([]string, error) {
+	profiles := map[string][]*profile.Profile{}
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		p, err := profile.ParseData(result.profile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid profile of container %s of pod %s: %s", result.container, result.pod, err)
+		}
+		profiles[result.container] = append(profiles[result.container], p)
+	}
+	if len(profiles) == 0 {
+		return nil, errors.New("no profiles were collected")
+	}
+
+	containers := make([]string, 0, len(profiles))
+	for container := range profiles {
+		containers = append(containers, container)
+	}
+	sort.Strings(containers)
+
+	files := make([]string, 0, len(containers))
+	for _, container := range containers {
+		file := filepath.Join(dir, fmt.Sprintf("%s-%s.%s.pb.gz", mergedProfileName, container, profileType))
+		if err := writeMergedProfile(file, profiles[container]); err != nil {
+			return nil, fmt.Errorf("failed to merge the profiles of container %s: %s", container, err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func writeMergedProfile(file string, profiles []*profile.Profile) error {
+	merged, err := profile.Merge(profiles)
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return merged.Write(out)
+}
+
+func printPprofResults(w io.Writer, results []pprofResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "POD\tCONTAINER\tPROFILE")
+
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+			fmt.Fprintf(tw, "%s\t%s\t%s ERROR: %s\n", result.pod, result.container, failStatus, result.err)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.pod, result.container, result.file)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to collect %d of %d profiles", failed, len(results))
+	}
+	return nil
+}
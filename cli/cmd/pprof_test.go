@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/pprof/profile"
+)
+
+// newTestProfile returns a serialized goroutine profile with a single sample
+// of count goroutines in function
+func newTestProfile(t *testing.T, function string, count int64) []byte {
+	t.Helper()
+	fn := &profile.Function{ID: 1, Name: function}
+	loc := &profile.Location{ID: 1, Line: []profile.Line{{Function: fn}}}
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "goroutine", Unit: "count"}},
+		PeriodType: &profile.ValueType{Type: "goroutine", Unit: "count"},
+		Period:     1,
+		Function:   []*profile.Function{fn},
+		Location:   []*profile.Location{loc},
+		Sample:     []*profile.Sample{{Location: []*profile.Location{loc}, Value: []int64{count}}},
+	}
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestPprofOptions(t *testing.T) {
+	testCases := []struct {
+		profileType string
+		duration    time.Duration
+		path        string
+		err         string
+	}{
+		{cpuProfile, 0, "/debug/pprof/profile?seconds=30", ""},
+		{cpuProfile, time.Minute, "/debug/pprof/profile?seconds=60", ""},
+		{heapProfile, 0, "/debug/pprof/heap", ""},
+		{goroutineProfile, 10 * time.Second, "/debug/pprof/goroutine?seconds=10", ""},
+		{"trace", 0, "", "--type must be one of: cpu, heap, goroutine"},
+		{heapProfile, 1500 * time.Millisecond, "", "--duration must be a positive number of seconds"},
+	}
+
+	for _, tc := range testCases {
+		options := newPprofOptions()
+		options.component = "destination"
+		options.profileType = tc.profileType
+		options.duration = tc.duration
+		err := options.validate()
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("Expected error %q for %s over %s, got %v", tc.err, tc.profileType, tc.duration, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+		if path := options.path(); path != tc.path {
+			t.Errorf("Expected path %s, got %s", tc.path, path)
+		}
+	}
+
+	if err := newPprofOptions().validate(); err == nil {
+		t.Error("Expected an error without a component")
+	}
+}
+
+func TestFetchProfile(t *testing.T) {
+	data := newTestProfile(t, "main.serve", 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/debug/pprof/goroutine" {
+			http.Error(w, "unknown profile", http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	actual, err := fetchProfile(context.Background(), server.URL+"/debug/pprof/goroutine")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !bytes.Equal(actual, data) {
+		t.Error("Expected the served profile")
+	}
+
+	_, err = fetchProfile(context.Background(), server.URL+"/debug/pprof/unknown")
+	if err == nil || !strings.Contains(err.Error(), "unknown profile") {
+		t.Errorf("Expected an unknown profile error, got %v", err)
+	}
+}
+
+func TestWriteAndMergeProfiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pprof")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	results := []pprofResult{
+		{pod: "linkerd-destination-a", container: "destination", profile: newTestProfile(t, "main.serve", 3)},
+		{pod: "linkerd-destination-b", container: "destination", profile: newTestProfile(t, "main.serve", 4)},
+		{pod: "linkerd-destination-c", err: errors.New("pod not running: linkerd-destination-c")},
+	}
+	writeProfiles(dir, results, goroutineProfile)
+
+	expectedFile := filepath.Join(dir, "linkerd-destination-a-destination.goroutine.pb.gz")
+	if results[0].file != expectedFile {
+		t.Errorf("Expected file %s, got %s", expectedFile, results[0].file)
+	}
+	if _, err := os.Stat(expectedFile); err != nil {
+		t.Errorf("Expected the profile to be written: %s", err)
+	}
+
+	var buf bytes.Buffer
+	err = printPprofResults(&buf, results)
+	if err == nil || err.Error() != "failed to collect 1 of 3 profiles" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "ERROR: pod not running: linkerd-destination-c") {
+		t.Errorf("Expected the error of linkerd-destination-c, got:\n%s", buf.String())
+	}
+
+	files, err := mergeProfiles(dir, results, goroutineProfile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expectedFiles := []string{filepath.Join(dir, "merged-destination.goroutine.pb.gz")}
+	if !reflect.DeepEqual(files, expectedFiles) {
+		t.Fatalf("Expected merged files %v, got %v", expectedFiles, files)
+	}
+	if samples := readGoroutineSamples(t, files[0]); len(samples) != 1 || samples[0] != 7 {
+		t.Errorf("Expected a single sample of 7 goroutines, got %v", samples)
+	}
+
+	results = append(results,
+		pprofResult{pod: "linkerd-destination-a", container: "sp-validator", profile: newTestProfile(t, "main.validate", 2)},
+		pprofResult{pod: "linkerd-destination-b", container: "sp-validator", profile: newTestProfile(t, "main.validate", 5)},
+	)
+	files, err = mergeProfiles(dir, results, goroutineProfile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expectedFiles = []string{
+		filepath.Join(dir, "merged-destination.goroutine.pb.gz"),
+		filepath.Join(dir, "merged-sp-validator.goroutine.pb.gz"),
+	}
+	if !reflect.DeepEqual(files, expectedFiles) {
+		t.Fatalf("Expected merged files %v, got %v", expectedFiles, files)
+	}
+	if samples := readGoroutineSamples(t, files[1]); len(samples) != 1 || samples[0] != 7 {
+		t.Errorf("Expected a single sample of 7 goroutines, got %v", samples)
+	}
+
+	if _, err := mergeProfiles(dir, results[2:3], goroutineProfile); err == nil {
+		t.Error("Expected an error without profiles")
+	}
+}
+
+func readGoroutineSamples(t *testing.T, file string) []int64 {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	p, err := profile.ParseData(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	samples := []int64{}
+	for _, sample := range p.Sample {
+		samples = append(samples, sample.Value[0])
+	}
+	return samples
+}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	pkgcmd "github.com/linkerd/linkerd2/pkg/cmd"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const proxyLogLevelPath = "/proxy-log-level"
+
+type proxyLogLevelOptions struct {
+	namespace string
+	duration  time.Duration
+}
+
+// proxyLogLevelResult holds the log level of the proxy of a pod before and
+// after it was changed
+type proxyLogLevelResult struct {
+	pod      string
+	previous string
+	current  string
+	err      error
+}
+
+func newProxyLogLevelOptions() *proxyLogLevelOptions {
+	return &proxyLogLevelOptions{
+		duration: 0,
+	}
+}
+
+func newCmdProxyLogLevel() *cobra.Command {
+	options := newProxyLogLevelOptions()
+
+	cmd := &cobra.Command{
+		Use:   "proxy-log-level [flags] (RESOURCE) [LEVEL]",
+		Short: "Get or change the log level of running Linkerd proxies",
+		Long: `Get or change the log level of running Linkerd proxies.
+
+  This command initiates a port-forward to a given pod or set of pods, and
+  queries the /proxy-log-level endpoint of their Linkerd proxies. If LEVEL is
+  given, the log level of the proxies is changed to it without restarting the
+  pods; it uses the same syntax as the config.linkerd.io/proxy-log-level
+  annotation, which isn't updated.
+
+  With --duration, the command waits for the given duration, or until it's
+  interrupted, and then reverts the proxies to their previous log level.
+
+  The RESOURCE argument specifies the target resource to query:
+  (TYPE/NAME)
+
+  Examples:
+  * cronjob/my-cronjob
+  * deploy/my-deploy
+  * ds/my-daemonset
+  * job/my-job
+  * po/mypod1
+  * rc/my-replication-controller
+  * sts/my-statefulset`,
+		Example: `  # Get the log level of the proxies of the web deployment.
+  linkerd diagnostics proxy-log-level -n emojivoto deploy/web
+
+  # Enable debug logs in the proxies of the web deployment for 5 minutes.
+  linkerd diagnostics proxy-log-level -n emojivoto deploy/web \
+    warn,linkerd=debug --duration 5m`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.namespace == "" {
+				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			}
+			level := ""
+			if len(args) == 2 {
+				level = strings.TrimSpace(args[1])
+				if level == "" {
+					return errors.New("the log level can't be empty")
+				}
+			}
+			if options.duration < 0 || (options.duration > 0 && level == "") {
+				return errors.New("--duration must be a positive duration, and requires a LEVEL")
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+			pods, err := getPodsFor(cmd.Context(), k8sAPI, options.namespace, args[0])
+			if err != nil {
+				return err
+			}
+			if len(pods) == 0 {
+				return fmt.Errorf("no pods found for %s", args[0])
+			}
+
+			results := changeProxyLogLevels(k8sAPI, pods, func(string) string { return level })
+			if level == "" {
+				return printProxyLogLevels(stdout, results, false)
+			}
+			// the proxies that were changed are reverted even if others failed
+			changeErr := printProxyLogLevels(stdout, results, true)
+			if options.duration == 0 {
+				return changeErr
+			}
+
+			previous := map[string]string{}
+			for _, result := range results {
+				if result.err == nil {
+					previous[result.pod] = result.previous
+				}
+			}
+			if len(previous) == 0 {
+				return changeErr
+			}
+
+			fmt.Fprintf(stdout, "\nReverting the log level in %s, or when interrupted\n", options.duration)
+			waitOrInterrupt(cmd.Context(), options.duration)
+
+			reverted := []corev1.Pod{}
+			for _, pod := range pods {
+				if _, ok := previous[pod.GetName()]; ok {
+					reverted = append(reverted, pod)
+				}
+			}
+			fmt.Fprintln(stdout)
+			revertErr := printProxyLogLevels(stdout, changeProxyLogLevels(k8sAPI, reverted, func(pod string) string {
+				return previous[pod]
+			}), true)
+			if revertErr != nil {
+				return revertErr
+			}
+			return changeErr
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of resource")
+	cmd.PersistentFlags().DurationVar(&options.duration, "duration", options.duration,
+		"Revert the proxies to their previous log level after this duration (default: don't revert)")
+
+	pkgcmd.ConfigureNamespaceFlagCompletion(cmd, []string{"namespace"},
+		kubeconfigPath, impersonate, impersonateGroup, kubeContext)
+
+	return cmd
+}
+
+// changeProxyLogLevels concurrently sets the log level of the proxy of each
+// pod to levelFor(pod), or only gets it if levelFor(pod) is empty. The results
+// are sorted by pod.
+func changeProxyLogLevels(k8sAPI *k8s.KubernetesAPI, pods []corev1.Pod, levelFor func(pod string) string) []proxyLogLevelResult {
+	results := make([]proxyLogLevelResult, len(pods))
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		wg.Add(1)
+		go func(i int, pod corev1.Pod) {
+			defer wg.Done()
+			results[i] = changeProxyLogLevel(k8sAPI, pod, levelFor(pod.GetName()))
+		}(i, pod)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].pod < results[j].pod
+	})
+	return results
+}
+
+func changeProxyLogLevel(k8sAPI *k8s.KubernetesAPI, pod corev1.Pod, level string) proxyLogLevelResult {
+	result := proxyLogLevelResult{pod: pod.GetName()}
+
+	containers, err := getAllContainersWithPort(pod, k8s.ProxyAdminPortName)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	if len(containers) == 0 {
+		result.err = fmt.Errorf("no %s port found for pod %s, is it meshed?", k8s.ProxyAdminPortName, pod.GetName())
+		return result
+	}
+
+	portForward, err := k8s.NewContainerMetricsForward(k8sAPI, pod, containers[0], verbose, k8s.ProxyAdminPortName)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer portForward.Stop()
+	if err := portForward.Init(); err != nil {
+		result.err = fmt.Errorf("error running port-forward: %s", err)
+		return result
+	}
+
+	url := portForward.URLFor(proxyLogLevelPath)
+	result.previous, result.err = getProxyLogLevel(url)
+	if result.err != nil || level == "" {
+		result.current = result.previous
+		return result
+	}
+	result.err = setProxyLogLevel(url, level)
+	if result.err == nil {
+		result.current = level
+	}
+	return result
+}
+
+// getProxyLogLevel returns the log level of the proxy whose log level
+// endpoint is at url
+func getProxyLogLevel(url string) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// setProxyLogLevel changes the log level of the proxy whose log level
+// endpoint is at url
+func setProxyLogLevel(url string, level string) error {
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewBufferString(level))
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// waitOrInterrupt waits for duration, until the context is done, or until the
+// process receives SIGINT or SIGTERM
+func waitOrInterrupt(ctx context.Context, duration time.Duration) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-signals:
+	case <-ctx.Done():
+	}
+}
+
+func printProxyLogLevels(w io.Writer, results []proxyLogLevelResult, changed bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if changed {
+		fmt.Fprintln(tw, "POD\tPREVIOUS LEVEL\tLEVEL")
+	} else {
+		fmt.Fprintln(tw, "POD\tLEVEL")
+	}
+
+	failed := 0
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			failed++
+			fmt.Fprintf(tw, "%s\t%s ERROR: %s\n", result.pod, failStatus, result.err)
+		case changed:
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", result.pod, result.previous, result.current)
+		default:
+			fmt.Fprintf(tw, "%s\t%s\n", result.pod, result.current)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to reach the proxy of %d of %d pods", failed, len(results))
+	}
+	return nil
+}
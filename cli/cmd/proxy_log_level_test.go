@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeProxyAdmin serves the log level endpoint of a proxy admin server
+func fakeProxyAdmin(level *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != proxyLogLevelPath {
+			http.NotFound(w, req)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			w.Write([]byte(*level + "\n"))
+		case http.MethodPut:
+			body, _ := ioutil.ReadAll(req.Body)
+			if strings.Contains(string(body), " ") {
+				http.Error(w, "invalid log level", http.StatusBadRequest)
+				return
+			}
+			*level = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+}
+
+func TestProxyLogLevelEndpoint(t *testing.T) {
+	level := "warn,linkerd=info"
+	server := fakeProxyAdmin(&level)
+	defer server.Close()
+	url := server.URL + proxyLogLevelPath
+
+	current, err := getProxyLogLevel(url)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if current != "warn,linkerd=info" {
+		t.Errorf("Expected level warn,linkerd=info, got %q", current)
+	}
+
+	if err := setProxyLogLevel(url, "warn,linkerd=debug"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if level != "warn,linkerd=debug" {
+		t.Errorf("Expected the proxy level to be warn,linkerd=debug, got %q", level)
+	}
+
+	err = setProxyLogLevel(url, "not a level")
+	if err == nil || !strings.Contains(err.Error(), "invalid log level") {
+		t.Errorf("Expected an invalid log level error, got %v", err)
+	}
+
+	if _, err := getProxyLogLevel(server.URL + "/unknown"); err == nil {
+		t.Error("Expected an error for an unknown endpoint")
+	}
+}
+
+func TestPrintProxyLogLevels(t *testing.T) {
+	results := []proxyLogLevelResult{
+		{pod: "web-a", previous: "warn,linkerd=info", current: "warn,linkerd=debug"},
+		{pod: "web-b", err: errors.New("pod not running: web-b")},
+	}
+
+	var buf bytes.Buffer
+	err := printProxyLogLevels(&buf, results, true)
+	if err == nil || err.Error() != "failed to reach the proxy of 1 of 2 pods" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got:\n%s", buf.String())
+	}
+	if lines[0] != "POD    PREVIOUS LEVEL     LEVEL" {
+		t.Errorf("Unexpected header %q", lines[0])
+	}
+	if lines[1] != "web-a  warn,linkerd=info  warn,linkerd=debug" {
+		t.Errorf("Unexpected line %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "web-b") || !strings.Contains(lines[2], "ERROR: pod not running: web-b") {
+		t.Errorf("Unexpected line %q", lines[2])
+	}
+
+	buf.Reset()
+	if err := printProxyLogLevels(&buf, results[:1], false); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	expected := "POD    LEVEL\nweb-a  warn,linkerd=debug\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
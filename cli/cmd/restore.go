@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+var restoreChecks = []healthcheck.CategoryID{
+	healthcheck.KubernetesAPIChecks,
+	healthcheck.LinkerdConfigChecks,
+	healthcheck.LinkerdControlPlaneExistenceChecks,
+	healthcheck.LinkerdIdentity,
+}
+
+func newCmdRestore() *cobra.Command {
+	var passphraseFile string
+	wait := defaultApplyWait
+
+	cmd := &cobra.Command{
+		Use:   "restore [flags] FILE",
+		Args:  cobra.ExactArgs(1),
+		Short: "Restore the control plane configuration from an archive",
+		Long: `Restore the control plane configuration from an archive.
+
+The objects of an archive created by "linkerd backup" are applied with
+server-side apply to a cluster where the control plane is installed, after
+verifying that the issuer certificate is valid for the trust anchors of the
+archive. The control plane is then checked like "linkerd check" would.
+
+The objects living in a namespace that doesn't exist, like the Links of an
+extension that isn't installed, can't be restored: every failure is reported
+before the command fails.
+
+Run "linkerd upgrade --apply" afterwards to roll out the restored configuration
+to the control plane.`,
+		Example: `  # Restore the control plane from an encrypted archive.
+  linkerd restore linkerd-backup.tar.gz --passphrase-file passphrase.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passphrase, err := readPassphrase(passphraseFile)
+			if err != nil {
+				return err
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			backup, err := readBackup(file, passphrase)
+			if err != nil {
+				return err
+			}
+
+			if backup.metadata.LinkerdVersion != version.Version {
+				fmt.Fprintf(stderr, "%s The archive was created with Linkerd %s, the CLI is %s\n",
+					warnStatus, backup.metadata.LinkerdVersion, version.Version)
+			}
+
+			k, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+			return restoreBackup(cmd.Context(), k, backup, stdout, stderr, wait)
+		},
+	}
+
+	cmd.Flags().StringVar(&passphraseFile, "passphrase-file", "",
+		"Decrypt the Secrets of the archive with the passphrase read from this file")
+	cmd.Flags().DurationVar(&wait, "wait", wait,
+		"Wait for the control plane to be healthy after the restore, for this long at most")
+
+	return cmd
+}
+
+// restoreBackup applies the objects of the backup to the cluster and checks
+// the control plane afterwards
+func restoreBackup(ctx context.Context, k *k8s.KubernetesAPI, backup *controlPlaneBackup, wout, werr io.Writer, wait time.Duration) error {
+	if backup.metadata.Namespace != controlPlaneNamespace {
+		return fmt.Errorf("the archive holds the control plane of the %q namespace, please set --linkerd-namespace accordingly", backup.metadata.Namespace)
+	}
+	exists, err := k.NamespaceExists(ctx, controlPlaneNamespace)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("the %q namespace doesn't exist, please install the control plane before restoring it", controlPlaneNamespace)
+	}
+
+	if err := verifyBackup(backup); err != nil {
+		return err
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(k.Discovery()))
+	namespaces := map[string]bool{}
+	failures := 0
+	for _, file := range backupFiles {
+		restored := 0
+		for _, obj := range backup.objects[file] {
+			if err := restoreObject(ctx, k, mapper, namespaces, obj); err != nil {
+				fmt.Fprintf(werr, "%s failed to restore %s %s/%s: %s\n", failStatus, obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+				failures++
+				continue
+			}
+			restored++
+		}
+		if restored > 0 {
+			fmt.Fprintf(wout, "Restored %d objects from %s\n", restored, file)
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("failed to restore %d objects of the archive", failures)
+	}
+
+	if _, err := loadStoredValues(ctx, k); err != nil {
+		return fmt.Errorf("failed to load the restored configuration: %s", err)
+	}
+
+	fmt.Fprintln(wout, "\nChecking the control plane")
+	if !runControlPlaneChecks(wout, werr, restoreChecks, time.Now().Add(wait)) {
+		return errors.New("the control plane isn't healthy after the restore")
+	}
+	fmt.Fprintln(wout, "\nRun `linkerd upgrade --apply` to roll out the restored configuration to the control plane")
+	return nil
+}
+
+// restoreObject applies the object with server-side apply, if its namespace
+// exists. The existence of the namespaces is cached in namespaces.
+func restoreObject(ctx context.Context, k *k8s.KubernetesAPI, mapper meta.RESTMapper, namespaces map[string]bool, obj *unstructured.Unstructured) error {
+	resource, err := resourceFor(k.DynamicClient, mapper, obj)
+	if err != nil {
+		return err
+	}
+	if ns := obj.GetNamespace(); ns != "" {
+		exists, ok := namespaces[ns]
+		if !ok {
+			if exists, err = k.NamespaceExists(ctx, ns); err != nil {
+				return err
+			}
+			namespaces[ns] = exists
+		}
+		if !exists {
+			return fmt.Errorf("the %q namespace doesn't exist, install the extension it belongs to first", ns)
+		}
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	force := true
+	_, err = resource.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: applyFieldManager,
+		Force:        &force,
+	})
+	return err
+}
+
+// verifyBackup verifies that the configuration of the backup can be loaded
+// and that its issuer certificate is valid for its trust anchors
+func verifyBackup(backup *controlPlaneBackup) error {
+	var overrides, issuer *corev1.Secret
+	for _, obj := range backup.objects[backupSecretsFile] {
+		var secret corev1.Secret
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &secret); err != nil {
+			return err
+		}
+		switch secret.Name {
+		case configOverridesSecretName:
+			overrides = &secret
+		case k8s.IdentityIssuerSecretName:
+			issuer = &secret
+		}
+	}
+	if overrides == nil || issuer == nil {
+		return fmt.Errorf("invalid backup archive: the %s and %s secrets are required", configOverridesSecretName, k8s.IdentityIssuerSecretName)
+	}
+
+	values, err := valuesFromOverridesSecret(overrides)
+	if err != nil {
+		return fmt.Errorf("invalid backup archive: %s", err)
+	}
+	return verifyIssuer(values, issuer)
+}
@@ -26,6 +26,9 @@ const (
 	jsonOutput  = healthcheck.JSONOutput
 	tableOutput = healthcheck.TableOutput
 	shortOutput = healthcheck.ShortOutput
+	junitOutput = healthcheck.JUnitOutput
+	sarifOutput = healthcheck.SARIFOutput
+	yamlOutput  = "yaml"
 )
 
 var (
@@ -102,16 +105,19 @@ func init() {
 	RootCmd.PersistentFlags().StringVar(&apiAddr, "api-addr", "", "Override kubeconfig and communicate directly with the control plane at host:port (mostly for testing)")
 	RootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Turn on debug logging")
 	RootCmd.AddCommand(newCmdAlpha())
+	RootCmd.AddCommand(newCmdBackup())
 	RootCmd.AddCommand(newCmdCheck())
 	RootCmd.AddCommand(newCmdCompletion())
 	RootCmd.AddCommand(newCmdDiagnostics())
 	RootCmd.AddCommand(newCmdDoc())
+	RootCmd.AddCommand(newCmdHeartbeat())
 	RootCmd.AddCommand(newCmdIdentity())
 	RootCmd.AddCommand(newCmdInject())
 	RootCmd.AddCommand(newCmdInstall())
 	RootCmd.AddCommand(newCmdInstallCNIPlugin())
 	RootCmd.AddCommand(newCmdProfile())
 	RootCmd.AddCommand(newCmdRepair())
+	RootCmd.AddCommand(newCmdRestore())
 	RootCmd.AddCommand(newCmdUninject())
 	RootCmd.AddCommand(newCmdUpgrade())
 	RootCmd.AddCommand(newCmdVersion())
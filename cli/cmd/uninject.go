@@ -11,22 +11,26 @@ import (
 )
 
 type resourceTransformerUninject struct {
-	values *linkerd2.Values
+	values       *linkerd2.Values
+	reportFormat string
 }
 
 type resourceTransformerUninjectSilent struct {
-	values *linkerd2.Values
+	values       *linkerd2.Values
+	reportFormat string
 }
 
-func runUninjectCmd(inputs []io.Reader, errWriter, outWriter io.Writer, values *linkerd2.Values) int {
-	return transformInput(inputs, errWriter, outWriter, resourceTransformerUninject{values})
+func runUninjectCmd(inputs []io.Reader, errWriter, outWriter io.Writer, values *linkerd2.Values, reportFormat string) int {
+	return transformInput(inputs, errWriter, outWriter, resourceTransformerUninject{values, reportFormat})
 }
 
 func runUninjectSilentCmd(inputs []io.Reader, errWriter, outWriter io.Writer, values *linkerd2.Values) int {
-	return transformInput(inputs, errWriter, outWriter, resourceTransformerUninjectSilent{values})
+	return transformInput(inputs, errWriter, outWriter, resourceTransformerUninjectSilent{values, ""})
 }
 
 func newCmdUninject() *cobra.Command {
+	var reportFormat string
+
 	cmd := &cobra.Command{
 		Use:   "uninject [flags] CONFIG-FILE",
 		Short: "Remove the Linkerd proxy from a Kubernetes config",
@@ -48,17 +52,24 @@ sub-folders, or coming from stdin.`,
 				return fmt.Errorf("please specify a kubernetes resource file")
 			}
 
+			if err := validateReportFormat(reportFormat); err != nil {
+				return err
+			}
+
 			in, err := read(args[0])
 			if err != nil {
 				return err
 			}
 
-			exitCode := runUninjectCmd(in, os.Stderr, os.Stdout, nil)
+			exitCode := runUninjectCmd(in, os.Stderr, os.Stdout, nil, reportFormat)
 			os.Exit(exitCode)
 			return nil
 		},
 	}
 
+	cmd.Flags().StringVar(&reportFormat, "report-format", "",
+		"Print the report to stderr as one record per resource in the given format: json or yaml (default human readable)")
+
 	return cmd
 }
 
@@ -86,7 +97,18 @@ func (rt resourceTransformerUninjectSilent) transform(bytes []byte) ([]byte, []i
 	return resourceTransformerUninject(rt).transform(bytes)
 }
 
-func (resourceTransformerUninject) generateReport(reports []inject.Report, output io.Writer) {
+func (rt resourceTransformerUninject) generateReport(reports []inject.Report, output io.Writer) {
+	if rt.reportFormat != "" {
+		records := []reportRecord{}
+		for _, r := range reports {
+			records = append(records, newUninjectReportRecord(r))
+		}
+		if err := writeReportRecords(records, rt.reportFormat, output); err != nil {
+			fmt.Fprintf(output, "Error printing the report: %s\n", err)
+		}
+		return
+	}
+
 	// leading newline to separate from yaml output on stdout
 	output.Write([]byte("\n"))
 
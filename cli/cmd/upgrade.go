@@ -37,6 +37,8 @@ var (
 	force     bool
 )
 
+const defaultApplyWait = 5 * time.Minute
+
 /* The upgrade commands all follow the same flow:
  * 1. Load default values from the Linkerd2 chart
  * 2. Update the values with stored overrides
@@ -146,6 +148,8 @@ func newCmdUpgrade() *cobra.Command {
 	flags := flattenFlags(allStageFlags, installUpgradeFlags, proxyFlags)
 
 	upgradeFlagSet := makeUpgradeFlags()
+	var apply bool
+	applyOptions := upgradeApplyOptions{wait: defaultApplyWait}
 
 	cmd := &cobra.Command{
 		Use:   "upgrade [flags]",
@@ -164,14 +168,31 @@ A full list of configurable values can be found at https://www.github.com/linker
 		Example: `  # Default upgrade.
   linkerd upgrade | kubectl apply --prune -l linkerd.io/control-plane-ns=linkerd -f -
 
+  # Apply the upgrade directly, pruning the control plane resources that aren't
+  # part of the new version.
+  linkerd upgrade --apply
+
+  # Print the changes the upgrade would make, without applying them.
+  linkerd upgrade --apply --dry-run
+
   # Similar to install, upgrade may also be broken up into two stages, by user
   # privilege.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if apply && manifests != "" {
+				return errors.New("--apply can't be used with --from-manifests")
+			}
+			if !apply && (applyOptions.dryRun || applyOptions.yes) {
+				return errors.New("--dry-run and --yes can only be used with --apply")
+			}
 			k, err := k8sClient(manifests)
 			if err != nil {
 				return err
 			}
-			err = upgradeRunE(cmd.Context(), k, flags, "", options)
+			if apply {
+				err = upgradeApplyRunE(cmd.Context(), k, flags, options, applyOptions)
+			} else {
+				err = upgradeRunE(cmd.Context(), k, flags, "", options)
+			}
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err.Error())
 				os.Exit(1)
@@ -186,6 +207,15 @@ A full list of configurable values can be found at https://www.github.com/linker
 	cmd.PersistentFlags().AddFlagSet(upgradeFlagSet)
 	flagspkg.AddValueOptionsFlags(cmd.Flags(), &options)
 
+	cmd.Flags().BoolVar(&apply, "apply", apply,
+		"Apply the upgrade to the cluster with server-side apply, after printing the changes it makes, instead of outputting the manifest. The control plane resources that aren't part of the new version are pruned.")
+	cmd.Flags().DurationVar(&applyOptions.wait, "wait", applyOptions.wait,
+		"Wait for the control plane to be healthy after the upgrade is applied, for this long at most (only with --apply)")
+	cmd.Flags().BoolVar(&applyOptions.dryRun, "dry-run", false,
+		"Print the changes the upgrade makes to the cluster without applying them (only with --apply)")
+	cmd.Flags().BoolVarP(&applyOptions.yes, "yes", "y", false,
+		"Apply the changes without asking for confirmation (only with --apply)")
+
 	cmd.AddCommand(newCmdUpgradeConfig(values))
 	cmd.AddCommand(newCmdUpgradeControlPlane(values))
 
@@ -240,11 +270,7 @@ func upgradeRunE(ctx context.Context, k *k8s.KubernetesAPI, flags []flag.Flag, s
 		return err
 	}
 
-	for _, flag := range flags {
-		if flag.Name() == "identity-trust-anchors-file" && flag.IsSet() {
-			fmt.Fprintf(os.Stderr, "\n%s %s\n\n", warnStatus, trustRootChangeMessage)
-		}
-	}
+	warnTrustRootChange(flags)
 	if stage == configStage {
 		fmt.Fprintf(os.Stderr, "%s\n\n", controlPlaneMessage)
 	}
@@ -254,6 +280,28 @@ func upgradeRunE(ctx context.Context, k *k8s.KubernetesAPI, flags []flag.Flag, s
 	return nil
 }
 
+func upgradeApplyRunE(ctx context.Context, k *k8s.KubernetesAPI, flags []flag.Flag, options valuespkg.Options, applyOptions upgradeApplyOptions) error {
+	buf, err := upgrade(ctx, k, flags, "", options)
+	if err != nil {
+		return err
+	}
+
+	warnTrustRootChange(flags)
+
+	return upgradeApply(ctx, k, buf.Bytes(), os.Stdin, stdout, stderr, applyOptions)
+}
+
+// warnTrustRootChange warns about the rotation of the trust anchors if they
+// are changed by the flags
+func warnTrustRootChange(flags []flag.Flag) {
+	for _, flag := range flags {
+		if flag.Name() == "identity-trust-anchors-file" && flag.IsSet() {
+			fmt.Fprintf(os.Stderr, "\n%s %s\n\n", warnStatus, trustRootChangeMessage)
+			return
+		}
+	}
+}
+
 func upgrade(ctx context.Context, k *k8s.KubernetesAPI, flags []flag.Flag, stage string, options valuespkg.Options) (bytes.Buffer, error) {
 	values, err := loadStoredValues(ctx, k)
 	if err != nil {
@@ -314,12 +362,6 @@ the 'linkerd repair' command to repair the Linkerd config`)
 }
 
 func loadStoredValues(ctx context.Context, k *k8s.KubernetesAPI) (*charts.Values, error) {
-	// Load the default values from the chart.
-	values, err := charts.NewValues()
-	if err != nil {
-		return nil, err
-	}
-
 	// Load the stored overrides from the linkerd-config-overrides secret.
 	secret, err := k.CoreV1().Secrets(controlPlaneNamespace).Get(ctx, "linkerd-config-overrides", metav1.GetOptions{})
 	if kerrors.IsNotFound(err) {
@@ -329,6 +371,18 @@ func loadStoredValues(ctx context.Context, k *k8s.KubernetesAPI) (*charts.Values
 		return nil, err
 	}
 
+	return valuesFromOverridesSecret(secret)
+}
+
+// valuesFromOverridesSecret returns the default values of the chart merged
+// with the overrides stored in the linkerd-config-overrides secret
+func valuesFromOverridesSecret(secret *corev1.Secret) (*charts.Values, error) {
+	// Load the default values from the chart.
+	values, err := charts.NewValues()
+	if err != nil {
+		return nil, err
+	}
+
 	bytes, ok := secret.Data["linkerd-config-overrides"]
 	if !ok {
 		return nil, errors.New("secret/linkerd-config-overrides is missing linkerd-config-overrides data")
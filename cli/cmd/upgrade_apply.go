@@ -0,0 +1,597 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// applyFieldManager is the field manager of the fields set by
+	// `linkerd upgrade --apply`
+	applyFieldManager = "linkerd"
+
+	// clientSideApplyFieldManager is the field manager of the fields set by
+	// `kubectl apply` without --server-side, which is how the control plane
+	// was upgraded before --apply
+	clientSideApplyFieldManager = "kubectl-client-side-apply"
+
+	changeAdded     = "added"
+	changeChanged   = "changed"
+	changeRemoved   = "removed"
+	changeUnchanged = "unchanged"
+)
+
+// prunableKinds are the kinds of the objects the control plane may have
+// installed in the past, which are pruned along with the kinds of the
+// rendered objects when they carry the control plane labels
+var prunableKinds = []schema.GroupVersionKind{
+	{Version: "v1", Kind: "ConfigMap"},
+	{Version: "v1", Kind: "Secret"},
+	{Version: "v1", Kind: "Service"},
+	{Version: "v1", Kind: "ServiceAccount"},
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	{Group: "batch", Version: "v1beta1", Kind: "CronJob"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding"},
+	{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "MutatingWebhookConfiguration"},
+	{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingWebhookConfiguration"},
+	{Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIService"},
+}
+
+// neverPrunedKinds are the kinds of the objects whose deletion would delete
+// other objects with them
+var neverPrunedKinds = map[string]bool{
+	"CustomResourceDefinition": true,
+	"Namespace":                true,
+}
+
+// objectChange is the change an upgrade makes to an object of the cluster.
+// Fields lists the paths of the changed fields, never their values, as they
+// may be secret.
+type objectChange struct {
+	Action    string
+	Kind      string
+	Namespace string
+	Name      string
+	Fields    []string
+
+	object   *unstructured.Unstructured
+	live     *unstructured.Unstructured
+	resource dynamic.ResourceInterface
+}
+
+// upgradeApplyOptions holds the options of `linkerd upgrade --apply`
+type upgradeApplyOptions struct {
+	wait   time.Duration
+	dryRun bool
+	yes    bool
+}
+
+func (c objectChange) String() string {
+	name := c.Name
+	if c.Namespace != "" {
+		name = fmt.Sprintf("%s/%s", c.Namespace, c.Name)
+	}
+	return fmt.Sprintf("%s %s", c.Kind, name)
+}
+
+// upgradeApply applies the rendered manifest to the cluster with server-side
+// apply, after printing the changes it makes and having them confirmed, and
+// prunes the control plane objects the manifest doesn't hold anymore. The
+// health of the control plane is checked before and after.
+func upgradeApply(ctx context.Context, k *k8s.KubernetesAPI, manifest []byte, in io.Reader, wout, werr io.Writer, options upgradeApplyOptions) error {
+	fmt.Fprintln(wout, "Checking the control plane before the upgrade")
+	if !runControlPlaneChecks(wout, werr, preUpgradeChecks, time.Now()) {
+		return errors.New("the control plane isn't healthy, fix the failed checks before upgrading")
+	}
+
+	objects, err := parseManifest(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to parse the rendered manifest: %s", err)
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(k.Discovery()))
+	changes, err := planUpgrade(ctx, k.DynamicClient, mapper, objects)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(wout, "\nChanges to the cluster (field manager %q):\n", applyFieldManager)
+	printChanges(wout, changes)
+
+	if options.dryRun {
+		return nil
+	}
+	if !options.yes {
+		confirmed, err := confirm(in, wout, "\nApply these changes?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return errors.New("the upgrade was cancelled")
+		}
+	}
+
+	fmt.Fprintln(wout, "\nApplying the changes")
+	force := true
+	for _, change := range changes {
+		switch change.Action {
+		case changeAdded, changeChanged:
+			if change.live != nil {
+				if err := migrateClientSideApply(ctx, change); err != nil {
+					return fmt.Errorf("failed to migrate the fields of %s to server-side apply: %s", change, err)
+				}
+			}
+			data, err := json.Marshal(change.object)
+			if err != nil {
+				return err
+			}
+			_, err = change.resource.Patch(ctx, change.Name, types.ApplyPatchType, data, metav1.PatchOptions{
+				FieldManager: applyFieldManager,
+				Force:        &force,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to apply %s: %s", change, err)
+			}
+		case changeRemoved:
+			propagation := metav1.DeletePropagationBackground
+			err := change.resource.Delete(ctx, change.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+			if err != nil && !kerrors.IsNotFound(err) {
+				return fmt.Errorf("failed to prune %s: %s", change, err)
+			}
+		}
+	}
+
+	fmt.Fprintln(wout, "\nChecking the control plane after the upgrade")
+	if !runControlPlaneChecks(wout, werr, controlPlaneHealthChecks, time.Now().Add(options.wait)) {
+		return errors.New("the control plane isn't healthy after the upgrade")
+	}
+	return nil
+}
+
+var (
+	preUpgradeChecks = []healthcheck.CategoryID{
+		healthcheck.KubernetesAPIChecks,
+		healthcheck.KubernetesVersionChecks,
+		healthcheck.LinkerdConfigChecks,
+		healthcheck.LinkerdControlPlaneExistenceChecks,
+	}
+	controlPlaneHealthChecks = []healthcheck.CategoryID{
+		healthcheck.KubernetesAPIChecks,
+		healthcheck.LinkerdConfigChecks,
+		healthcheck.LinkerdControlPlaneExistenceChecks,
+		healthcheck.LinkerdIdentity,
+		healthcheck.LinkerdWebhooksAndAPISvcTLS,
+		healthcheck.LinkerdControlPlaneProxyChecks,
+	}
+)
+
+// runControlPlaneChecks runs the given healthcheck categories against the
+// control plane, retrying them until retryDeadline
+func runControlPlaneChecks(wout, werr io.Writer, checks []healthcheck.CategoryID, retryDeadline time.Time) bool {
+	hc := healthcheck.NewHealthChecker(checks, &healthcheck.Options{
+		ControlPlaneNamespace: controlPlaneNamespace,
+		CNINamespace:          cniNamespace,
+		KubeConfig:            kubeconfigPath,
+		KubeContext:           kubeContext,
+		Impersonate:           impersonate,
+		ImpersonateGroup:      impersonateGroup,
+		APIAddr:               apiAddr,
+		RetryDeadline:         retryDeadline,
+	})
+	return healthcheck.RunChecks(wout, werr, hc, tableOutput)
+}
+
+// planUpgrade returns the changes applying the objects makes to the cluster,
+// in the order they must be made. The changes of the live objects are
+// computed with a server-side dry-run, so that the defaulted fields don't
+// show up as changed.
+func planUpgrade(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, objects []*unstructured.Unstructured) ([]objectChange, error) {
+	changes := []objectChange{}
+	rendered := map[string]bool{}
+	kinds := map[schema.GroupVersionKind]bool{}
+	force := true
+
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		resource, err := resourceFor(client, mapper, obj)
+		if err != nil {
+			return nil, err
+		}
+		kinds[gvk] = true
+		rendered[objectKey(gvk.GroupKind(), obj.GetNamespace(), obj.GetName())] = true
+
+		change := objectChange{
+			Kind:      gvk.Kind,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			object:    obj,
+			resource:  resource,
+		}
+
+		live, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			change.Action = changeAdded
+			changes = append(changes, change)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %s", change, err)
+		}
+
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		applied, err := resource.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+			FieldManager: applyFieldManager,
+			Force:        &force,
+			DryRun:       []string{metav1.DryRunAll},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to dry-run the apply of %s: %s", change, err)
+		}
+
+		change.live = live
+		change.Fields = changedFields(live.Object, applied.Object)
+		change.Action = changeUnchanged
+		if len(change.Fields) > 0 {
+			change.Action = changeChanged
+		}
+		changes = append(changes, change)
+	}
+
+	for _, gvk := range prunableKinds {
+		kinds[gvk] = true
+	}
+	pruned, err := planPrune(ctx, client, mapper, kinds, rendered)
+	if err != nil {
+		return nil, err
+	}
+	return append(changes, pruned...), nil
+}
+
+// confirm asks the question on out and returns true if the answer read from
+// in is yes
+func confirm(in io.Reader, out io.Writer, question string) (bool, error) {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// migrateClientSideApply hands the fields of the live object of the change
+// owned by `kubectl apply` over to the linkerd field manager. Otherwise the
+// fields the new manifest doesn't set anymore would stay owned by kubectl,
+// and server-side apply wouldn't remove them.
+func migrateClientSideApply(ctx context.Context, change objectChange) error {
+	managedFields, migrated, err := migrateManagedFields(change.live.GetManagedFields())
+	if err != nil || !migrated {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"managedFields":   managedFields,
+			"resourceVersion": change.live.GetResourceVersion(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = change.resource.Patch(ctx, change.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// migrateManagedFields returns the managed fields with the entries of the
+// client-side apply field manager merged into the applied entries of the
+// linkerd field manager, and true if there were any
+func migrateManagedFields(entries []metav1.ManagedFieldsEntry) ([]metav1.ManagedFieldsEntry, bool, error) {
+	migrated := []metav1.ManagedFieldsEntry{}
+	clientSide := []metav1.ManagedFieldsEntry{}
+	for _, entry := range entries {
+		if entry.Manager == clientSideApplyFieldManager && entry.Operation == metav1.ManagedFieldsOperationUpdate {
+			clientSide = append(clientSide, entry)
+			continue
+		}
+		migrated = append(migrated, entry)
+	}
+	if len(clientSide) == 0 {
+		return entries, false, nil
+	}
+
+	for _, entry := range clientSide {
+		i := findAppliedEntry(migrated, entry.APIVersion)
+		if i < 0 {
+			entry.Manager = applyFieldManager
+			entry.Operation = metav1.ManagedFieldsOperationApply
+			migrated = append(migrated, entry)
+			continue
+		}
+		fields, err := unionFields(migrated[i].FieldsV1, entry.FieldsV1)
+		if err != nil {
+			return nil, false, err
+		}
+		migrated[i].FieldsV1 = fields
+	}
+	return migrated, true, nil
+}
+
+func findAppliedEntry(entries []metav1.ManagedFieldsEntry, apiVersion string) int {
+	for i, entry := range entries {
+		if entry.Manager == applyFieldManager && entry.Operation == metav1.ManagedFieldsOperationApply && entry.APIVersion == apiVersion {
+			return i
+		}
+	}
+	return -1
+}
+
+func unionFields(a, b *metav1.FieldsV1) (*metav1.FieldsV1, error) {
+	set := &fieldpath.Set{}
+	for _, fields := range []*metav1.FieldsV1{a, b} {
+		if fields == nil {
+			continue
+		}
+		other := &fieldpath.Set{}
+		if err := other.FromJSON(bytes.NewReader(fields.Raw)); err != nil {
+			return nil, err
+		}
+		set = set.Union(other)
+	}
+	raw, err := set.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.FieldsV1{Raw: raw}, nil
+}
+
+// planPrune returns the removal of the objects of the given kinds carrying
+// the control plane labels that aren't rendered anymore. The objects of the
+// extensions and the ones owned by other objects are never pruned.
+func planPrune(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, kinds map[schema.GroupVersionKind]bool, rendered map[string]bool) ([]objectChange, error) {
+	selector := fmt.Sprintf("%s=%s,!%s", k8s.ControllerNSLabel, controlPlaneNamespace, k8s.LinkerdExtensionLabel)
+
+	gvks := []schema.GroupVersionKind{}
+	for gvk := range kinds {
+		gvks = append(gvks, gvk)
+	}
+	sort.Slice(gvks, func(i, j int) bool { return gvks[i].String() < gvks[j].String() })
+
+	changes := []objectChange{}
+	for _, gvk := range gvks {
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if meta.IsNoMatchError(err) {
+			// the cluster doesn't serve this kind, so there's nothing to prune
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var list *unstructured.UnstructuredList
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			list, err = client.Resource(mapping.Resource).Namespace(controlPlaneNamespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		} else {
+			list, err = client.Resource(mapping.Resource).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the %s objects to prune: %s", gvk.Kind, err)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if !isPrunable(obj, rendered) {
+				continue
+			}
+			resource, err := resourceFor(client, mapper, obj)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, objectChange{
+				Action:    changeRemoved,
+				Kind:      gvk.Kind,
+				Namespace: obj.GetNamespace(),
+				Name:      obj.GetName(),
+				object:    obj,
+				resource:  resource,
+			})
+		}
+	}
+	return changes, nil
+}
+
+func isPrunable(obj *unstructured.Unstructured, rendered map[string]bool) bool {
+	if neverPrunedKinds[obj.GetKind()] || len(obj.GetOwnerReferences()) > 0 || obj.GetDeletionTimestamp() != nil {
+		return false
+	}
+	if _, ok := obj.GetLabels()[k8s.LinkerdExtensionLabel]; ok {
+		return false
+	}
+	key := objectKey(obj.GroupVersionKind().GroupKind(), obj.GetNamespace(), obj.GetName())
+	return !rendered[key]
+}
+
+func objectKey(gk schema.GroupKind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", gk, namespace, name)
+}
+
+// resourceFor returns the client of the resource of obj, and sets the
+// namespace of obj to the control plane one if it's namespaced without one
+func resourceFor(client dynamic.Interface, mapper meta.RESTMapper, obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the resource of %s: %s", gvk, err)
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(controlPlaneNamespace)
+		}
+		return client.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+	}
+	return client.Resource(mapping.Resource), nil
+}
+
+// parseManifest returns the objects of a multi-document YAML manifest,
+// skipping the empty documents
+func parseManifest(manifest []byte) ([]*unstructured.Unstructured, error) {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+	objects := []*unstructured.Unstructured{}
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return nil, err
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		u := &unstructured.Unstructured{Object: obj}
+		if u.GetKind() == "" || u.GetName() == "" {
+			return nil, fmt.Errorf("object without kind or name:\n%s", doc)
+		}
+		objects = append(objects, u)
+	}
+	return objects, nil
+}
+
+// ignoredFields are the fields maintained by the cluster, which don't take
+// part in the diff
+var ignoredFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "uid"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "selfLink"},
+	{"status"},
+}
+
+// changedFields returns the paths of the fields that differ between the live
+// and desired states of an object. Lists are compared item by item when their
+// lengths match, and as a whole otherwise.
+func changedFields(live, desired map[string]interface{}) []string {
+	live = deepCopy(live)
+	desired = deepCopy(desired)
+	removeServerFields(live)
+	removeServerFields(desired)
+
+	fields := []string{}
+	diffValues("", live, desired, &fields)
+	sort.Strings(fields)
+	return fields
+}
+
+// removeServerFields removes the fields maintained by the cluster from obj
+func removeServerFields(obj map[string]interface{}) {
+	for _, field := range ignoredFields {
+		unstructured.RemoveNestedField(obj, field...)
+	}
+}
+
+func deepCopy(obj map[string]interface{}) map[string]interface{} {
+	return (&unstructured.Unstructured{Object: obj}).DeepCopy().Object
+}
+
+func diffValues(path string, live, desired interface{}, fields *[]string) {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			*fields = append(*fields, path)
+			return
+		}
+		keys := map[string]bool{}
+		for key := range l {
+			keys[key] = true
+		}
+		for key := range d {
+			keys[key] = true
+		}
+		for key := range keys {
+			diffValues(joinPath(path, key), l[key], d[key], fields)
+		}
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			*fields = append(*fields, path)
+			return
+		}
+		for i := range d {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), l[i], d[i], fields)
+		}
+	default:
+		if !reflect.DeepEqual(live, desired) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		key = fmt.Sprintf("[%s]", key)
+	} else if path != "" {
+		key = "." + key
+	}
+	return path + key
+}
+
+func printChanges(w io.Writer, changes []objectChange) {
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Action]++
+		switch change.Action {
+		case changeAdded:
+			fmt.Fprintf(w, "  + %s\n", change)
+		case changeChanged:
+			fmt.Fprintf(w, "  ~ %s\n", change)
+			for _, field := range change.Fields {
+				fmt.Fprintf(w, "      %s\n", field)
+			}
+		case changeRemoved:
+			fmt.Fprintf(w, "  - %s\n", change)
+		}
+	}
+	fmt.Fprintf(w, "%d added, %d changed, %d removed, %d unchanged\n",
+		counts[changeAdded], counts[changeChanged], counts[changeRemoved], counts[changeUnchanged])
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestChangedFields(t *testing.T) {
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "linkerd-destination",
+			"resourceVersion": "1",
+			"managedFields":   []interface{}{"kubectl"},
+			"annotations": map[string]interface{}{
+				"linkerd.io/created-by": "linkerd/cli stable-2.10.2",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "destination", "image": "controller:stable-2.10.2"},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{"readyReplicas": int64(1)},
+	}
+	desired := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "linkerd-destination",
+			"resourceVersion": "2",
+			"managedFields":   []interface{}{"linkerd"},
+			"annotations": map[string]interface{}{
+				"linkerd.io/created-by": "linkerd/cli stable-2.11.0",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "destination", "image": "controller:stable-2.11.0"},
+						map[string]interface{}{"name": "policy", "image": "policy:stable-2.11.0"},
+					},
+				},
+			},
+			"strategy": map[string]interface{}{"type": "RollingUpdate"},
+		},
+	}
+
+	expected := []string{
+		"metadata.annotations[linkerd.io/created-by]",
+		"spec.strategy",
+		"spec.template.spec.containers",
+	}
+	if actual := changedFields(live, desired); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected changed fields %v, got %v", expected, actual)
+	}
+
+	if actual := changedFields(live, live); len(actual) != 0 {
+		t.Errorf("Expected no changed fields, got %v", actual)
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	manifest := `---
+# Source: linkerd2/templates/namespace.yaml
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: linkerd
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: linkerd-identity
+  namespace: linkerd
+`
+	objects, err := parseManifest([]byte(manifest))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("Expected 2 objects, got %d", len(objects))
+	}
+	if objects[0].GetKind() != "Namespace" || objects[1].GetName() != "linkerd-identity" {
+		t.Errorf("Unexpected objects: %v", objects)
+	}
+
+	if _, err := parseManifest([]byte("apiVersion: v1\nmetadata:\n  name: nameless\n")); err == nil {
+		t.Error("Expected an error for an object without kind")
+	}
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	clusterRole := schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}
+	configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	crd := schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(clusterRole, meta.RESTScopeRoot)
+	mapper.Add(configMap, meta.RESTScopeNamespace)
+	mapper.Add(crd, meta.RESTScopeRoot)
+
+	labels := map[string]string{"linkerd.io/control-plane-ns": "linkerd"}
+	live := []runtime.Object{
+		newUnstructured(clusterRole, "", "linkerd-linkerd-tap", labels),
+		newUnstructured(clusterRole, "", "linkerd-viz-tap", map[string]string{
+			"linkerd.io/control-plane-ns": "linkerd",
+			"linkerd.io/extension":        "viz",
+		}),
+		newUnstructured(clusterRole, "", "cluster-admin", nil),
+		newUnstructured(crd, "", "trafficsplits.split.smi-spec.io", labels),
+	}
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"}:         "ClusterRoleList",
+		{Version: "v1", Resource: "configmaps"}:                                               "ConfigMapList",
+		{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}: "CustomResourceDefinitionList",
+	}, live...)
+
+	objects := []*unstructured.Unstructured{
+		newUnstructured(configMap, "", "linkerd-config", labels),
+	}
+	changes, err := planUpgrade(context.Background(), client, mapper, objects)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	actual := []string{}
+	for _, change := range changes {
+		actual = append(actual, change.Action+" "+change.String())
+	}
+	expected := []string{
+		"added ConfigMap linkerd/linkerd-config",
+		"removed ClusterRole linkerd-linkerd-tap",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected changes %v, got %v", expected, actual)
+	}
+}
+
+func newUnstructured(gvk schema.GroupVersionKind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestMigrateManagedFields(t *testing.T) {
+	entries := []metav1.ManagedFieldsEntry{
+		{
+			Manager:    "kubectl-client-side-apply",
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			APIVersion: "apps/v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+		},
+		{
+			Manager:    "linkerd",
+			Operation:  metav1.ManagedFieldsOperationApply,
+			APIVersion: "apps/v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{}}}`)},
+		},
+		{
+			Manager:    "kube-controller-manager",
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			APIVersion: "apps/v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:status":{}}`)},
+		},
+	}
+
+	migrated, ok, err := migrateManagedFields(entries)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !ok {
+		t.Fatal("Expected the managed fields to be migrated")
+	}
+	if len(migrated) != 2 {
+		t.Fatalf("Expected 2 managed fields entries, got %v", migrated)
+	}
+	for _, entry := range migrated {
+		if entry.Manager == "kubectl-client-side-apply" {
+			t.Errorf("Expected the client-side apply entry to be removed, got %v", migrated)
+		}
+	}
+	expected := `{"f:metadata":{"f:labels":{}},"f:spec":{"f:replicas":{}}}`
+	if actual := string(migrated[0].FieldsV1.Raw); actual != expected {
+		t.Errorf("Expected the linkerd fields %s, got %s", expected, actual)
+	}
+
+	if _, ok, _ := migrateManagedFields(migrated); ok {
+		t.Error("Expected the migrated managed fields to be left as they are")
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	for input, expected := range map[string]bool{
+		"y\n":   true,
+		"Yes\n": true,
+		"n\n":   false,
+		"\n":    false,
+		"":      false,
+	} {
+		actual, err := confirm(bytes.NewBufferString(input), ioutil.Discard, "Apply?")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if actual != expected {
+			t.Errorf("Expected %t for the answer %q, got %t", expected, input, actual)
+		}
+	}
+}
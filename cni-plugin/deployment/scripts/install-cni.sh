@@ -210,6 +210,19 @@ mv "${TMP_CONF}" "${CNI_CONF_PATH}" || exit_with_error 'Failed to mv files.'
 
 echo "Created CNI config ${CNI_CONF_PATH}"
 
+# If a pod cache directory is specified, keep the cache of the metadata of
+# the pods of this node up to date, so that the plugin doesn't need to query
+# the Kubernetes API for every pod. This runs forever, like the sleep below.
+: "${POD_CACHE_DIR:=}"
+if [ "${POD_CACHE_DIR}" != "" ]; then
+  echo "Maintaining the pod cache in ${POD_CACHE_DIR}"
+  while true; do
+    linkerd-cni pod-cache -dir "${POD_CACHE_DIR}" -node-name "${NODE_NAME:-$(hostname)}" &
+    wait $! || echo 'The pod cache exited, restarting it'
+    sleep 1
+  done
+fi
+
 # Unless told otherwise, sleep forever.
 # This prevents Kubernetes from restarting the pod repeatedly.
 should_sleep=${SLEEP:-"true"}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/linkerd/linkerd2-proxy-init/iptables"
+	"github.com/linkerd/linkerd2-proxy-init/ports"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// These are the chains created by iptables.ConfigureFirewall
+	outputChainName   = "PROXY_INIT_OUTPUT"
+	redirectChainName = "PROXY_INIT_REDIRECT"
+
+	natTable = "nat"
+)
+
+// ipFamily holds the commands and addresses specific to an IP family
+type ipFamily struct {
+	name     string
+	save     string
+	restore  string
+	loopback string
+}
+
+var (
+	ipv4 = ipFamily{name: "IPv4", save: "iptables-save", restore: "iptables-restore", loopback: "127.0.0.1/32"}
+	ipv6 = ipFamily{name: "IPv6", save: "ip6tables-save", restore: "ip6tables-restore", loopback: "::1/128"}
+)
+
+// netnsFirewall inspects and tears down the iptables rules set up by
+// iptables.ConfigureFirewall in the network namespace of a pod. Like
+// ConfigureFirewall, it only logs the commands it would run when SimulateOnly
+// is set.
+type netnsFirewall struct {
+	netNs        string
+	useWaitFlag  bool
+	simulateOnly bool
+	log          *logrus.Entry
+}
+
+// rule is a rule of the nat table, as output by iptables-save
+type rule struct {
+	chain string
+	args  []string
+}
+
+func newNetnsFirewall(netNs string, useWaitFlag, simulateOnly bool, log *logrus.Entry) *netnsFirewall {
+	return &netnsFirewall{
+		netNs:        netNs,
+		useWaitFlag:  useWaitFlag,
+		simulateOnly: simulateOnly,
+		log:          log,
+	}
+}
+
+// configure sets up the rules iptables.ConfigureFirewall sets up for IPv4,
+// for the given family. It's used for IPv6, which ConfigureFirewall doesn't
+// support. Like ConfigureFirewall, it leaves existing rules untouched.
+func (fw *netnsFirewall) configure(family ipFamily, conf iptables.FirewallConfiguration) error {
+	save, err := fw.run("", family.save, "-t", natTable)
+	if err != nil {
+		return fmt.Errorf("could not list %s iptables rules: %s", family.name, err)
+	}
+	if _, found := withoutProxyInitRules(save); found {
+		fw.log.Debugf("linkerd-cni: found existing %s firewall configuration, skipping", family.name)
+		return nil
+	}
+
+	// --noflush appends the rules to the nat table instead of replacing it
+	args := []string{"--noflush"}
+	if fw.useWaitFlag {
+		args = append(args, "-w")
+	}
+	if _, err := fw.run(natRules(family, conf), family.restore, args...); err != nil {
+		return fmt.Errorf("could not add %s iptables rules: %s", family.name, err)
+	}
+	return nil
+}
+
+// teardown removes the PROXY_INIT_* chains and the rules jumping to them,
+// leaving any other rule of the nat table untouched.
+func (fw *netnsFirewall) teardown(family ipFamily) error {
+	save, err := fw.run("", family.save, "-t", natTable)
+	if err != nil {
+		return fmt.Errorf("could not list %s iptables rules: %s", family.name, err)
+	}
+
+	restore, changed := withoutProxyInitRules(save)
+	if !changed {
+		fw.log.Debugf("linkerd-cni: no %s iptables rules to remove", family.name)
+		return nil
+	}
+
+	// without --noflush, iptables-restore replaces the whole nat table,
+	// which deletes the chains that are not part of the input
+	args := []string{}
+	if fw.useWaitFlag {
+		args = append(args, "-w")
+	}
+	if _, err := fw.run(restore, family.restore, args...); err != nil {
+		return fmt.Errorf("could not remove %s iptables rules: %s", family.name, err)
+	}
+	return nil
+}
+
+// check verifies that the rules set up for the given firewall configuration
+// are present.
+func (fw *netnsFirewall) check(family ipFamily, conf iptables.FirewallConfiguration) error {
+	save, err := fw.run("", family.save, "-t", natTable)
+	if err != nil {
+		return fmt.Errorf("could not list %s iptables rules: %s", family.name, err)
+	}
+	if fw.simulateOnly {
+		fw.log.Debugf("linkerd-cni: simulating, skipping %s iptables rules verification", family.name)
+		return nil
+	}
+	if err := checkRules(conf, save); err != nil {
+		return fmt.Errorf("%s: %s", family.name, err)
+	}
+	return nil
+}
+
+// run executes the given command in the network namespace of the pod,
+// feeding it stdin, and returns its output.
+func (fw *netnsFirewall) run(stdin string, name string, args ...string) (string, error) {
+	if fw.netNs != "" {
+		// separate nsenter args from the rest with `--`, like
+		// iptables.ConfigureFirewall does
+		args = append([]string{fmt.Sprintf("--net=%s", fw.netNs), "--", name}, args...)
+		name = "nsenter"
+	}
+	fw.log.Debugf("linkerd-cni: :; %s %s", name, strings.Join(args, " "))
+	if fw.simulateOnly {
+		return "", nil
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// natRules returns the nat table rules, in the iptables-restore format, that
+// iptables.ConfigureFirewall sets up for the given configuration, adapted to
+// the given family.
+func natRules(family ipFamily, conf iptables.FirewallConfiguration) string {
+	comment := func(text string) string {
+		return fmt.Sprintf("-m comment --comment \"proxy-init/%s/%s\"", text, iptables.ExecutionTraceID)
+	}
+	var b strings.Builder
+	rule := func(format string, args ...interface{}) {
+		fmt.Fprintf(&b, format+"\n", args...)
+	}
+
+	rule("*%s", natTable)
+	rule(":%s - [0:0]", redirectChainName)
+	rule(":%s - [0:0]", outputChainName)
+
+	for _, destinations := range multiportDestinations(conf.InboundPortsToIgnore) {
+		rule("-A %s -p tcp -m multiport --dports %s -j RETURN %s", redirectChainName, destinations, comment("ignore-port-"+destinations))
+	}
+	switch conf.Mode {
+	case iptables.RedirectAllMode:
+		rule("-A %s -p tcp -j REDIRECT --to-ports %d %s", redirectChainName, conf.ProxyInboundPort, comment("redirect-all-incoming-to-proxy-port"))
+	case iptables.RedirectListedMode:
+		for _, port := range conf.PortsToRedirectInbound {
+			rule("-A %s -p tcp --dport %d -j REDIRECT --to-ports %d %s", redirectChainName, port, conf.ProxyInboundPort, comment(fmt.Sprintf("redirect-port-%d-to-proxy-port", port)))
+		}
+	}
+	rule("-A %s -j %s %s", iptables.IptablesPreroutingChainName, redirectChainName, comment("install-proxy-init-prerouting"))
+
+	if conf.ProxyUID > 0 {
+		rule("-A %s -m owner --uid-owner %d -o lo ! -d %s -j %s %s", outputChainName, conf.ProxyUID, family.loopback, redirectChainName, comment("redirect-non-loopback-local-traffic"))
+		rule("-A %s -m owner --uid-owner %d -j RETURN %s", outputChainName, conf.ProxyUID, comment("ignore-proxy-user-id"))
+	}
+	rule("-A %s -o lo -j RETURN %s", outputChainName, comment("ignore-loopback"))
+	for _, destinations := range multiportDestinations(conf.OutboundPortsToIgnore) {
+		rule("-A %s -p tcp -m multiport --dports %s -j RETURN %s", outputChainName, destinations, comment("ignore-port-"+destinations))
+	}
+	rule("-A %s -p tcp -j REDIRECT --to-ports %d %s", outputChainName, conf.ProxyOutgoingPort, comment("redirect-all-outgoing-to-proxy-port"))
+	rule("-A %s -j %s %s", iptables.IptablesOutputChainName, outputChainName, comment("install-proxy-init-output"))
+
+	rule("COMMIT")
+	return b.String()
+}
+
+// multiportDestinations groups the given ports and port ranges in lists that
+// fit in a single multiport match, skipping the invalid ones like
+// iptables.ConfigureFirewall does.
+func multiportDestinations(portsToIgnore []string) []string {
+	groups := []string{}
+	group := []string{}
+	count := 0
+	for _, port := range portsToIgnore {
+		portRange, err := ports.ParsePortRange(port)
+		if err != nil {
+			continue
+		}
+		destination, portCount := asDestination(portRange), 2
+		if portRange.LowerBound == portRange.UpperBound {
+			portCount = 1
+		}
+		if count+portCount > iptables.IptablesMultiportLimit {
+			groups = append(groups, strings.Join(group, ","))
+			group, count = []string{}, 0
+		}
+		group = append(group, destination)
+		count += portCount
+	}
+	if len(group) > 0 {
+		groups = append(groups, strings.Join(group, ","))
+	}
+	return groups
+}
+
+// asDestination formats a port range the way iptables-save outputs it, with
+// single port ranges as a single port.
+func asDestination(portRange ports.PortRange) string {
+	if portRange.LowerBound == portRange.UpperBound {
+		return strconv.Itoa(portRange.LowerBound)
+	}
+	return fmt.Sprintf("%d:%d", portRange.LowerBound, portRange.UpperBound)
+}
+
+// withoutProxyInitRules returns the given nat table, as output by
+// iptables-save, without the PROXY_INIT_* chains and the rules referring to
+// them. It also returns whether there was anything to remove.
+func withoutProxyInitRules(save string) (string, bool) {
+	var b strings.Builder
+	changed := false
+	for _, line := range strings.Split(save, "\n") {
+		if isProxyInitLine(line) {
+			changed = true
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String(), changed
+}
+
+func isProxyInitLine(line string) bool {
+	for _, chain := range []string{outputChainName, redirectChainName} {
+		if strings.HasPrefix(line, ":"+chain+" ") {
+			return true
+		}
+		if strings.HasPrefix(line, "-A ") {
+			fields := strings.Fields(line)
+			for _, f := range fields {
+				if f == chain {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// parseRules returns the rules of the nat table, as output by iptables-save,
+// along with the chains it declares.
+func parseRules(save string) (map[string]struct{}, []rule) {
+	chains := make(map[string]struct{})
+	rules := []rule{}
+	for _, line := range strings.Split(save, "\n") {
+		switch {
+		case strings.HasPrefix(line, ":"):
+			fields := strings.Fields(line[1:])
+			if len(fields) > 0 {
+				chains[fields[0]] = struct{}{}
+			}
+		case strings.HasPrefix(line, "-A "):
+			fields := strings.Fields(line)
+			if len(fields) > 1 {
+				rules = append(rules, rule{chain: fields[1], args: fields[2:]})
+			}
+		}
+	}
+	return chains, rules
+}
+
+// value returns the value following the given flag in the rule.
+func (r rule) value(flag string) string {
+	for i, arg := range r.args {
+		if arg == flag && i+1 < len(r.args) {
+			return r.args[i+1]
+		}
+	}
+	return ""
+}
+
+// checkRules verifies that the nat table, as output by iptables-save,
+// contains the rules iptables.ConfigureFirewall sets up for the given
+// configuration.
+func checkRules(conf iptables.FirewallConfiguration, save string) error {
+	chains, rules := parseRules(save)
+
+	for _, chain := range []string{redirectChainName, outputChainName} {
+		if _, ok := chains[chain]; !ok {
+			return fmt.Errorf("chain %s not found", chain)
+		}
+	}
+
+	find := func(chain string, match func(rule) bool) bool {
+		for _, r := range rules {
+			if r.chain == chain && match(r) {
+				return true
+			}
+		}
+		return false
+	}
+	jumpsTo := func(target string) func(rule) bool {
+		return func(r rule) bool { return r.value("-j") == target }
+	}
+	redirectsTo := func(port int, dport string) func(rule) bool {
+		return func(r rule) bool {
+			return r.value("-j") == "REDIRECT" && r.value("--to-ports") == strconv.Itoa(port) && r.value("--dport") == dport
+		}
+	}
+	ignores := func(portRange ports.PortRange) func(rule) bool {
+		destination := asDestination(portRange)
+		return func(r rule) bool {
+			if r.value("-j") != "RETURN" {
+				return false
+			}
+			for _, p := range strings.Split(r.value("--dports"), ",") {
+				if p == destination {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	if !find(iptables.IptablesPreroutingChainName, jumpsTo(redirectChainName)) {
+		return fmt.Errorf("no jump from %s to %s", iptables.IptablesPreroutingChainName, redirectChainName)
+	}
+	if !find(iptables.IptablesOutputChainName, jumpsTo(outputChainName)) {
+		return fmt.Errorf("no jump from %s to %s", iptables.IptablesOutputChainName, outputChainName)
+	}
+
+	switch conf.Mode {
+	case iptables.RedirectAllMode:
+		if !find(redirectChainName, redirectsTo(conf.ProxyInboundPort, "")) {
+			return fmt.Errorf("inbound traffic is not redirected to port %d", conf.ProxyInboundPort)
+		}
+	case iptables.RedirectListedMode:
+		for _, port := range conf.PortsToRedirectInbound {
+			if !find(redirectChainName, redirectsTo(conf.ProxyInboundPort, strconv.Itoa(port))) {
+				return fmt.Errorf("inbound traffic to port %d is not redirected to port %d", port, conf.ProxyInboundPort)
+			}
+		}
+	}
+	if !find(outputChainName, redirectsTo(conf.ProxyOutgoingPort, "")) {
+		return fmt.Errorf("outbound traffic is not redirected to port %d", conf.ProxyOutgoingPort)
+	}
+
+	if conf.ProxyUID > 0 {
+		uid := strconv.Itoa(conf.ProxyUID)
+		if !find(outputChainName, func(r rule) bool { return r.value("--uid-owner") == uid && r.value("-j") == "RETURN" }) {
+			return fmt.Errorf("outbound traffic of the proxy (uid %s) is not ignored", uid)
+		}
+	}
+
+	for _, port := range conf.InboundPortsToIgnore {
+		// invalid ports are skipped by ConfigureFirewall as well
+		if portRange, err := ports.ParsePortRange(port); err == nil && !find(redirectChainName, ignores(portRange)) {
+			return fmt.Errorf("inbound port %s is not ignored", port)
+		}
+	}
+	for _, port := range conf.OutboundPortsToIgnore {
+		if portRange, err := ports.ParsePortRange(port); err == nil && !find(outputChainName, ignores(portRange)) {
+			return fmt.Errorf("outbound port %s is not ignored", port)
+		}
+	}
+
+	return nil
+}
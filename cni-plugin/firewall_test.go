@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/linkerd/linkerd2-proxy-init/iptables"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+const (
+	meshedNatTable = `# Generated by iptables-save v1.8.4 on Mon Oct 19 07:00:00 2026
+*nat
+:PREROUTING ACCEPT [0:0]
+:INPUT ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+:POSTROUTING ACCEPT [0:0]
+:PROXY_INIT_OUTPUT - [0:0]
+:PROXY_INIT_REDIRECT - [0:0]
+-A PREROUTING -m comment --comment "proxy-init/install-proxy-init-prerouting/1603090000" -j PROXY_INIT_REDIRECT
+-A OUTPUT -m comment --comment "proxy-init/install-proxy-init-output/1603090000" -j PROXY_INIT_OUTPUT
+-A POSTROUTING -s 10.0.0.0/8 -j MASQUERADE
+-A PROXY_INIT_OUTPUT ! -d 127.0.0.1/32 -o lo -m owner --uid-owner 2102 -m comment --comment "proxy-init/redirect-non-loopback-local-traffic/1603090000" -j PROXY_INIT_REDIRECT
+-A PROXY_INIT_OUTPUT -m owner --uid-owner 2102 -m comment --comment "proxy-init/ignore-proxy-user-id/1603090000" -j RETURN
+-A PROXY_INIT_OUTPUT -o lo -m comment --comment "proxy-init/ignore-loopback/1603090000" -j RETURN
+-A PROXY_INIT_OUTPUT -p tcp -m multiport --dports 443,8000:8010 -m comment --comment "proxy-init/ignore-port-443,8000:8010/1603090000" -j RETURN
+-A PROXY_INIT_OUTPUT -p tcp -m comment --comment "proxy-init/redirect-all-outgoing-to-proxy-port/1603090000" -j REDIRECT --to-ports 4140
+-A PROXY_INIT_REDIRECT -p tcp -m multiport --dports 4190,4191 -m comment --comment "proxy-init/ignore-port-4190,4191/1603090000" -j RETURN
+-A PROXY_INIT_REDIRECT -p tcp -m comment --comment "proxy-init/redirect-all-incoming-to-proxy-port/1603090000" -j REDIRECT --to-ports 4143
+COMMIT
+# Completed on Mon Oct 19 07:00:00 2026
+`
+
+	unmeshedNatTable = `*nat
+:PREROUTING ACCEPT [0:0]
+:INPUT ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+:POSTROUTING ACCEPT [0:0]
+-A POSTROUTING -s 10.0.0.0/8 -j MASQUERADE
+COMMIT
+`
+)
+
+func meshedConfiguration() iptables.FirewallConfiguration {
+	return iptables.FirewallConfiguration{
+		Mode:                  iptables.RedirectAllMode,
+		InboundPortsToIgnore:  []string{"4190", "4191"},
+		OutboundPortsToIgnore: []string{"443", "8000-8010"},
+		ProxyInboundPort:      4143,
+		ProxyOutgoingPort:     4140,
+		ProxyUID:              2102,
+	}
+}
+
+func TestWithoutProxyInitRules(t *testing.T) {
+	t.Run("removes the PROXY_INIT chains and the rules referring to them", func(t *testing.T) {
+		restore, changed := withoutProxyInitRules(meshedNatTable)
+		if !changed {
+			t.Fatal("Expected rules to be removed")
+		}
+		expected := `# Generated by iptables-save v1.8.4 on Mon Oct 19 07:00:00 2026
+*nat
+:PREROUTING ACCEPT [0:0]
+:INPUT ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+:POSTROUTING ACCEPT [0:0]
+-A POSTROUTING -s 10.0.0.0/8 -j MASQUERADE
+COMMIT
+# Completed on Mon Oct 19 07:00:00 2026
+`
+		if restore != expected {
+			t.Fatalf("Expected:\n%s\nGot:\n%s", expected, restore)
+		}
+	})
+
+	t.Run("leaves tables without PROXY_INIT chains alone", func(t *testing.T) {
+		if _, changed := withoutProxyInitRules(unmeshedNatTable); changed {
+			t.Fatal("Expected no rules to be removed")
+		}
+	})
+}
+
+func TestCheckRules(t *testing.T) {
+	t.Run("accepts the rules set up by proxy-init", func(t *testing.T) {
+		if err := checkRules(meshedConfiguration(), meshedNatTable); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	for _, tc := range []struct {
+		description string
+		conf        func(*iptables.FirewallConfiguration)
+		table       string
+		expectedErr string
+	}{
+		{
+			description: "rejects missing chains",
+			table:       unmeshedNatTable,
+			expectedErr: "chain PROXY_INIT_REDIRECT not found",
+		},
+		{
+			description: "rejects a missing jump to the redirect chain",
+			table:       strings.Replace(meshedNatTable, "-A PREROUTING", "-A INPUT", 1),
+			expectedErr: "no jump from PREROUTING to PROXY_INIT_REDIRECT",
+		},
+		{
+			description: "rejects a different inbound proxy port",
+			conf:        func(c *iptables.FirewallConfiguration) { c.ProxyInboundPort = 5143 },
+			expectedErr: "inbound traffic is not redirected to port 5143",
+		},
+		{
+			description: "rejects missing redirects of listed ports",
+			conf: func(c *iptables.FirewallConfiguration) {
+				c.Mode = iptables.RedirectListedMode
+				c.PortsToRedirectInbound = []int{8080}
+			},
+			expectedErr: "inbound traffic to port 8080 is not redirected to port 4143",
+		},
+		{
+			description: "rejects a different proxy uid",
+			conf:        func(c *iptables.FirewallConfiguration) { c.ProxyUID = 1234 },
+			expectedErr: "outbound traffic of the proxy (uid 1234) is not ignored",
+		},
+		{
+			description: "rejects missing ignored ports",
+			conf: func(c *iptables.FirewallConfiguration) {
+				c.OutboundPortsToIgnore = append(c.OutboundPortsToIgnore, "25")
+			},
+			expectedErr: "outbound port 25 is not ignored",
+		},
+	} {
+		tc := tc // pin
+		t.Run(tc.description, func(t *testing.T) {
+			conf := meshedConfiguration()
+			if tc.conf != nil {
+				tc.conf(&conf)
+			}
+			table := tc.table
+			if table == "" {
+				table = meshedNatTable
+			}
+			err := checkRules(conf, table)
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("Expected error %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestNetnsFirewallSimulate(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	fw := newNetnsFirewall("/var/run/netns/pod", true, true, logrus.NewEntry(logger))
+
+	if err := fw.teardown(ipv4); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := fw.check(ipv4, meshedConfiguration()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"linkerd-cni: :; nsenter --net=/var/run/netns/pod -- iptables-save -t nat",
+		"linkerd-cni: no IPv4 iptables rules to remove",
+		"linkerd-cni: :; nsenter --net=/var/run/netns/pod -- iptables-save -t nat",
+		"linkerd-cni: simulating, skipping IPv4 iptables rules verification",
+	}
+	entries := hook.AllEntries()
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d log entries, got %d", len(expected), len(entries))
+	}
+	for i, entry := range entries {
+		if entry.Message != expected[i] {
+			t.Fatalf("Expected log entry %q, got %q", expected[i], entry.Message)
+		}
+	}
+}
+
+func TestCmdDelSimulate(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	err := cmdDel(&skel.CmdArgs{
+		ContainerID: "container",
+		Netns:       "/var/run/netns/pod",
+		StdinData:   []byte(`{"cniVersion": "0.4.0", "name": "linkerd-cni", "type": "linkerd-cni", "log_level": "debug", "linkerd": {"simulate": true}}`),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := "linkerd-cni: :; nsenter --net=/var/run/netns/pod -- iptables-save -t nat"
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == expected {
+			return
+		}
+	}
+	t.Fatalf("Expected log entry %q, got %s", expected, fmt.Sprint(hook.AllEntries()))
+}
+
+func TestNatRules(t *testing.T) {
+	t.Run("sets up the rules checked for each family", func(t *testing.T) {
+		for _, family := range []ipFamily{ipv4, ipv6} {
+			conf := meshedConfiguration()
+			if err := checkRules(conf, natRules(family, conf)); err != nil {
+				t.Fatalf("Unexpected error for %s: %s", family.name, err)
+			}
+		}
+	})
+
+	t.Run("uses the loopback address of the family", func(t *testing.T) {
+		rules := natRules(ipv6, meshedConfiguration())
+		expected := fmt.Sprintf(`-A PROXY_INIT_OUTPUT -m owner --uid-owner 2102 -o lo ! -d ::1/128 -j PROXY_INIT_REDIRECT -m comment --comment "proxy-init/redirect-non-loopback-local-traffic/%s"`, iptables.ExecutionTraceID)
+		if !strings.Contains(rules, expected+"\n") {
+			t.Fatalf("Expected rule %q in:\n%s", expected, rules)
+		}
+		if strings.Contains(rules, "127.0.0.1") {
+			t.Fatalf("Unexpected IPv4 address in:\n%s", rules)
+		}
+	})
+
+	t.Run("redirects listed ports only", func(t *testing.T) {
+		conf := meshedConfiguration()
+		conf.Mode = iptables.RedirectListedMode
+		conf.PortsToRedirectInbound = []int{8080, 9090}
+		rules := natRules(ipv6, conf)
+		if err := checkRules(conf, rules); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if strings.Contains(rules, "redirect-all-incoming-to-proxy-port") {
+			t.Fatalf("Unexpected redirection of all inbound traffic in:\n%s", rules)
+		}
+	})
+}
+
+func TestMultiportDestinations(t *testing.T) {
+	ports := []string{"not-a-port", "8000-8010"}
+	for i := 1; i <= 14; i++ {
+		ports = append(ports, fmt.Sprintf("%d", i))
+	}
+	groups := multiportDestinations(ports)
+	expected := []string{
+		"8000:8010,1,2,3,4,5,6,7,8,9,10,11,12,13",
+		"14",
+	}
+	if strings.Join(groups, " ") != strings.Join(expected, " ") {
+		t.Fatalf("Expected %v, got %v", expected, groups)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/containernetworking/cni/pkg/skel"
@@ -31,10 +32,10 @@ import (
 	"github.com/containernetworking/cni/pkg/version"
 	"github.com/linkerd/linkerd2-proxy-init/cmd"
 	"github.com/linkerd/linkerd2-proxy-init/iptables"
+	"github.com/linkerd/linkerd2/cni-plugin/podcache"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/proxyinit"
 	"github.com/sirupsen/logrus"
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ProxyInit is the configuration for the proxy-init binary
@@ -47,12 +48,37 @@ type ProxyInit struct {
 	OutboundPortsToIgnore []string `json:"outbound-ports-to-ignore"`
 	Simulate              bool     `json:"simulate"`
 	UseWaitFlag           bool     `json:"use-wait-flag"`
+	// ProxyControlPort and ProxyAdminPort are skipped by the inbound
+	// redirection, like proxy-init does
+	ProxyControlPort int `json:"proxy-control-port"`
+	ProxyAdminPort   int `json:"proxy-admin-port"`
+	// IPv6 enables the redirection of IPv6 traffic, through ip6tables, in
+	// addition to IPv4 traffic
+	IPv6 bool `json:"ipv6"`
+}
+
+// firewallOptions are the options to configure the firewall of a pod with
+type firewallOptions struct {
+	cmd.RootOptions
+	IPv6 bool
+
+	pod *podcache.Pod
 }
 
 // Kubernetes a K8s specific struct to hold config
 type Kubernetes struct {
 	K8sAPIRoot string `json:"k8s_api_root"`
 	Kubeconfig string `json:"kubeconfig"`
+	// APITimeout bounds the requests to the Kubernetes API (e.g. "10s")
+	APITimeout string `json:"api_timeout"`
+}
+
+// PodCache is the configuration of the node-local cache of pod metadata
+// maintained by the install-cni container. When enabled, the API is only
+// queried for the pods that don't show up in the cache within Timeout.
+type PodCache struct {
+	Dir     string `json:"dir"`
+	Timeout string `json:"timeout"`
 }
 
 // K8sArgs is the valid CNI_ARGS used for Kubernetes
@@ -61,6 +87,7 @@ type K8sArgs struct {
 	types.CommonArgs
 	K8sPodName      types.UnmarshallableString
 	K8sPodNamespace types.UnmarshallableString
+	K8sPodUID       types.UnmarshallableString
 }
 
 // PluginConf is whatever JSON is passed via stdin.
@@ -75,10 +102,28 @@ type PluginConf struct {
 	LogLevel   string     `json:"log_level"`
 	ProxyInit  ProxyInit  `json:"linkerd"`
 	Kubernetes Kubernetes `json:"kubernetes"`
+	PodCache   PodCache   `json:"pod_cache"`
+	// FailurePolicy is either fail-open, to leave the pod network untouched,
+	// or fail-closed (the default), to fail the request, when the pod metadata
+	// can't be retrieved
+	FailurePolicy string `json:"failure_policy"`
+	// AnnotateRedirected makes the plugin record on the pods the IP families
+	// whose traffic it redirected, for the repair controller
+	AnnotateRedirected bool `json:"annotate_redirected"`
 }
 
 func main() {
-	skel.PluginMain(cmdAdd, cmdDel, version.All)
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case podCacheCommand:
+			runPodCache(os.Args[2:])
+			return
+		case repairCommand:
+			runRepair(os.Args[2:])
+			return
+		}
+	}
+	skel.PluginMain(cmdAdd, cmdCheck, cmdDel, version.All, "linkerd-cni")
 }
 
 func configureLogging(logLevel string) {
@@ -103,6 +148,9 @@ func parseConfig(stdin []byte) (*PluginConf, error) {
 	if err := json.Unmarshal(stdin, &conf); err != nil {
 		return nil, fmt.Errorf("linkerd-cni: failed to parse network configuration: %v", err)
 	}
+	if err := validateFailurePolicy(conf.FailurePolicy); err != nil {
+		return nil, err
+	}
 
 	if conf.RawPrevResult != nil {
 		resultBytes, err := json.Marshal(conf.RawPrevResult)
@@ -145,113 +193,47 @@ func cmdAdd(args *skel.CmdArgs) error {
 		}).Debug("linkerd-cni: cmdAdd, config parsed")
 	}
 
-	// Determine if running under k8s by checking the CNI args
-	k8sArgs := K8sArgs{}
-	args.Args = strings.Replace(args.Args, "K8S_POD_NAMESPACE", "K8sPodNamespace", 1)
-	args.Args = strings.Replace(args.Args, "K8S_POD_NAME", "K8sPodName", 1)
-	if err := types.LoadArgs(args.Args, &k8sArgs); err != nil {
+	options, logEntry, err := getFirewallOptions(args, conf)
+	if err != nil {
 		return err
 	}
 
-	namespace := string(k8sArgs.K8sPodNamespace)
-	podName := string(k8sArgs.K8sPodName)
-	logEntry := logrus.WithFields(logrus.Fields{
-		"ContainerID": args.ContainerID,
-		"Pod":         podName,
-		"Namespace":   namespace,
-	})
-
-	if namespace != "" && podName != "" {
-		ctx := context.Background()
-		client, err := k8s.NewAPI(conf.Kubernetes.Kubeconfig, "linkerd-cni-context", "", []string{}, 0)
+	if options != nil {
+		logEntry.Debug("linkerd-cni: setting up iptables firewall")
+		firewallConfiguration, err := cmd.BuildFirewallConfiguration(&options.RootOptions)
 		if err != nil {
+			logEntry.Errorf("linkerd-cni: could not create a Firewall Configuration from the options: %v", options)
 			return err
 		}
 
-		pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		err = iptables.ConfigureFirewall(*firewallConfiguration)
 		if err != nil {
+			logEntry.Errorf("linkerd-cni: could not configure firewall: %v", err)
 			return err
 		}
 
-		containsLinkerdProxy := false
-		for _, container := range pod.Spec.Containers {
-			if container.Name == k8s.ProxyContainerName {
-				containsLinkerdProxy = true
-				break
-			}
-		}
-
-		containsInitContainer := false
-		for _, container := range pod.Spec.InitContainers {
-			if container.Name == k8s.InitContainerName {
-				containsInitContainer = true
-				break
-			}
-		}
-
-		if containsLinkerdProxy && !containsInitContainer {
-			logEntry.Debug("linkerd-cni: setting up iptables firewall")
-			options := cmd.RootOptions{
-				IncomingProxyPort:     conf.ProxyInit.IncomingProxyPort,
-				OutgoingProxyPort:     conf.ProxyInit.OutgoingProxyPort,
-				ProxyUserID:           conf.ProxyInit.ProxyUID,
-				PortsToRedirect:       conf.ProxyInit.PortsToRedirect,
-				InboundPortsToIgnore:  conf.ProxyInit.InboundPortsToIgnore,
-				OutboundPortsToIgnore: conf.ProxyInit.OutboundPortsToIgnore,
-				SimulateOnly:          conf.ProxyInit.Simulate,
-				NetNs:                 args.Netns,
-				UseWaitFlag:           conf.ProxyInit.UseWaitFlag,
-			}
-
-			// Check if there are any overridden ports to be skipped
-			outboundSkipOverride, err := getAnnotationOverride(ctx, client, pod, k8s.ProxyIgnoreOutboundPortsAnnotation)
-			if err != nil {
-				logEntry.Errorf("linkerd-cni: could not retrieve overridden annotations: %v", err)
-				return err
-			}
-
-			if outboundSkipOverride != "" {
-				logEntry.Debugf("linkerd-cni: overriding OutboundPortsToIgnore to %s", outboundSkipOverride)
-				options.OutboundPortsToIgnore = strings.Split(outboundSkipOverride, ",")
-			}
-
-			inboundSkipOverride, err := getAnnotationOverride(ctx, client, pod, k8s.ProxyIgnoreInboundPortsAnnotation)
-			if err != nil {
-				logEntry.Errorf("linkerd-cni: could not retrieve overridden annotations: %v", err)
+		if options.IPv6 {
+			logEntry.Debug("linkerd-cni: setting up ip6tables firewall")
+			fw := newNetnsFirewall(args.Netns, options.UseWaitFlag, options.SimulateOnly, logEntry)
+			if err := fw.configure(ipv6, *firewallConfiguration); err != nil {
+				logEntry.Errorf("linkerd-cni: could not configure IPv6 firewall: %v", err)
 				return err
 			}
+		}
 
-			if inboundSkipOverride != "" {
-				logEntry.Debugf("linkerd-cni: overriding InboundPortsToIgnore to %s", inboundSkipOverride)
-				options.InboundPortsToIgnore = strings.Split(inboundSkipOverride, ",")
-			}
-
-			if pod.GetLabels()[k8s.ControllerComponentLabel] != "" {
-				// Skip 443 outbound port if its a control plane component
-				logEntry.Debug("linkerd-cni: adding 443 to OutboundPortsToIgnore as its a control plane component")
-				options.OutboundPortsToIgnore = append(options.OutboundPortsToIgnore, "443")
-			}
-
-			firewallConfiguration, err := cmd.BuildFirewallConfiguration(&options)
-			if err != nil {
-				logEntry.Errorf("linkerd-cni: could not create a Firewall Configuration from the options: %v", options)
-				return err
-			}
-
-			err = iptables.ConfigureFirewall(*firewallConfiguration)
-			if err != nil {
-				logEntry.Errorf("linkerd-cni: could not configure firewall: %v", err)
-				return err
+		if conf.AnnotateRedirected && !options.SimulateOnly {
+			families := []string{"ipv4"}
+			if options.IPv6 {
+				families = append(families, "ipv6")
 			}
-		} else {
-			if containsInitContainer {
-				logEntry.Debug("linkerd-cni: linkerd-init initContainer is present, skipping.")
-			} else {
-				logEntry.Debug("linkerd-cni: linkerd-proxy is not present, skipping.")
+			// the repair controller relies on this annotation to find the
+			// pods whose traffic bypasses the proxy, but failing to set it
+			// doesn't warrant failing the pod
+			lookup := newPodLookup(conf, logEntry)
+			if err := lookup.markRedirected(context.Background(), options.pod, families); err != nil {
+				logEntry.Warnf("linkerd-cni: could not annotate pod as redirected: %v", err)
 			}
 		}
-	} else {
-		logEntry.Debug("linkerd-cni: no Kubernetes namespace or pod name found, skipping.")
 	}
 
 	logrus.Debug("linkerd-cni: plugin is finished")
@@ -264,27 +246,189 @@ func cmdAdd(args *skel.CmdArgs) error {
 	return nil
 }
 
-// cmdDel is called for DELETE requests
+// cmdCheck is called by the CNI runtime for CHECK requests. It verifies that
+// the firewall configuration set up by cmdAdd is still present in the pod
+// network namespace.
+func cmdCheck(args *skel.CmdArgs) error {
+	logrus.Debug("linkerd-cni: cmdCheck, parsing config")
+	conf, err := parseConfig(args.StdinData)
+	if err != nil {
+		return err
+	}
+	configureLogging(conf.LogLevel)
+
+	options, logEntry, err := getFirewallOptions(args, conf)
+	if err != nil {
+		return err
+	}
+	if options == nil {
+		return nil
+	}
+
+	firewallConfiguration, err := cmd.BuildFirewallConfiguration(&options.RootOptions)
+	if err != nil {
+		logEntry.Errorf("linkerd-cni: could not create a Firewall Configuration from the options: %v", options)
+		return err
+	}
+
+	families := []ipFamily{ipv4}
+	if options.IPv6 {
+		families = append(families, ipv6)
+	}
+	fw := newNetnsFirewall(args.Netns, conf.ProxyInit.UseWaitFlag, conf.ProxyInit.Simulate, logEntry)
+	for _, family := range families {
+		if err := fw.check(family, *firewallConfiguration); err != nil {
+			logEntry.Errorf("linkerd-cni: firewall is misconfigured: %v", err)
+			return fmt.Errorf("linkerd-cni: firewall is misconfigured: %s", err)
+		}
+	}
+
+	logEntry.Debug("linkerd-cni: firewall configuration verified")
+	return nil
+}
+
+// cmdDel is called for DELETE requests. It removes the PROXY_INIT_* chains
+// set up by cmdAdd from the pod network namespace, if it's still around.
 func cmdDel(args *skel.CmdArgs) error {
-	logrus.Debug("linkerd-cni: cmdDel not implemented")
+	logrus.Debug("linkerd-cni: cmdDel, parsing config")
+	conf, err := parseConfig(args.StdinData)
+	if err != nil {
+		return err
+	}
+	configureLogging(conf.LogLevel)
+
+	logEntry := logrus.WithFields(logrus.Fields{
+		"ContainerID": args.ContainerID,
+	})
+
+	// DEL can be called several times, and after the network namespace is
+	// gone, in which case there is nothing left to clean up
+	if args.Netns == "" {
+		logEntry.Debug("linkerd-cni: no network namespace, skipping.")
+		return nil
+	}
+	if _, err := os.Stat(args.Netns); err != nil && !conf.ProxyInit.Simulate {
+		logEntry.Debugf("linkerd-cni: network namespace %s is gone, skipping.", args.Netns)
+		return nil
+	}
+
+	fw := newNetnsFirewall(args.Netns, conf.ProxyInit.UseWaitFlag, conf.ProxyInit.Simulate, logEntry)
+	if err := fw.teardown(ipv4); err != nil {
+		logEntry.Errorf("linkerd-cni: could not tear down firewall: %v", err)
+		return err
+	}
+	// IPv6 may have been enabled through an annotation, so the ip6tables rules
+	// are always looked for. ip6tables may be missing from hosts that don't
+	// use IPv6 though, in which case there is nothing to tear down.
+	if err := fw.teardown(ipv6); err != nil {
+		if conf.ProxyInit.IPv6 {
+			logEntry.Errorf("linkerd-cni: could not tear down IPv6 firewall: %v", err)
+			return err
+		}
+		logEntry.Debugf("linkerd-cni: skipping IPv6 firewall teardown: %v", err)
+	}
+
+	logrus.Debug("linkerd-cni: plugin is finished")
 	return nil
 }
 
-func getAnnotationOverride(ctx context.Context, api *k8s.KubernetesAPI, pod *v1.Pod, key string) (string, error) {
-	// Check if the annotation is present on the pod
-	if override := pod.GetObjectMeta().GetAnnotations()[key]; override != "" {
-		return override, nil
+// getFirewallOptions returns the options to configure the firewall of the
+// pod the CNI request is about, or nil if the pod should be skipped because
+// it isn't meshed or it configures its own firewall through the proxy-init
+// initContainer.
+func getFirewallOptions(args *skel.CmdArgs, conf *PluginConf) (*firewallOptions, *logrus.Entry, error) {
+	// Determine if running under k8s by checking the CNI args
+	k8sArgs := K8sArgs{}
+	cniArgs := strings.Replace(args.Args, "K8S_POD_NAMESPACE", "K8sPodNamespace", 1)
+	cniArgs = strings.Replace(cniArgs, "K8S_POD_NAME", "K8sPodName", 1)
+	cniArgs = strings.Replace(cniArgs, "K8S_POD_UID", "K8sPodUID", 1)
+	if err := types.LoadArgs(cniArgs, &k8sArgs); err != nil {
+		return nil, nil, err
+	}
+
+	namespace := string(k8sArgs.K8sPodNamespace)
+	podName := string(k8sArgs.K8sPodName)
+	logEntry := logrus.WithFields(logrus.Fields{
+		"ContainerID": args.ContainerID,
+		"Pod":         podName,
+		"Namespace":   namespace,
+	})
+
+	if namespace == "" || podName == "" {
+		logEntry.Debug("linkerd-cni: no Kubernetes namespace or pod name found, skipping.")
+		return nil, logEntry, nil
+	}
+
+	ctx := context.Background()
+	lookup := newPodLookup(conf, logEntry)
+	pod, err := lookup.pod(ctx, namespace, podName, string(k8sArgs.K8sPodUID))
+	if err != nil {
+		logEntry.Errorf("linkerd-cni: could not retrieve pod: %v", err)
+		return nil, logEntry, failurePolicyError(conf, logEntry, err)
 	}
 
-	// Check if the annotation is present on the namespace
-	ns, err := api.CoreV1().Namespaces().Get(ctx, pod.GetObjectMeta().GetNamespace(), metav1.GetOptions{})
+	containsLinkerdProxy := pod.HasContainer(k8s.ProxyContainerName)
+	containsInitContainer := pod.HasInitContainer(k8s.InitContainerName)
+
+	if !containsLinkerdProxy || containsInitContainer {
+		if containsInitContainer {
+			logEntry.Debug("linkerd-cni: linkerd-init initContainer is present, skipping.")
+		} else {
+			logEntry.Debug("linkerd-cni: linkerd-proxy is not present, skipping.")
+		}
+		return nil, logEntry, nil
+	}
+
+	annotations, err := lookup.annotations(ctx, pod)
 	if err != nil {
-		return "", err
+		logEntry.Errorf("linkerd-cni: could not retrieve overridden annotations: %v", err)
+		return nil, logEntry, failurePolicyError(conf, logEntry, err)
+	}
+
+	// Apply the annotations proxy-init honors the same way it does
+	redirect := proxyinit.Config{
+		InboundPort:         int32(conf.ProxyInit.IncomingProxyPort),
+		OutboundPort:        int32(conf.ProxyInit.OutgoingProxyPort),
+		ControlPort:         int32(conf.ProxyInit.ProxyControlPort),
+		AdminPort:           int32(conf.ProxyInit.ProxyAdminPort),
+		ProxyUID:            int64(conf.ProxyInit.ProxyUID),
+		IgnoreInboundPorts:  strings.Join(conf.ProxyInit.InboundPortsToIgnore, ","),
+		IgnoreOutboundPorts: strings.Join(conf.ProxyInit.OutboundPortsToIgnore, ","),
+	}
+	redirect.ApplyAnnotations(annotations)
+	logEntry.Debugf("linkerd-cni: redirecting with %+v", redirect)
+
+	options := firewallOptions{
+		RootOptions: cmd.RootOptions{
+			IncomingProxyPort:     int(redirect.InboundPort),
+			OutgoingProxyPort:     int(redirect.OutboundPort),
+			ProxyUserID:           int(redirect.ProxyUID),
+			PortsToRedirect:       conf.ProxyInit.PortsToRedirect,
+			InboundPortsToIgnore:  redirect.InboundPortsToIgnore(),
+			OutboundPortsToIgnore: redirect.OutboundPortsToIgnore(),
+			SimulateOnly:          conf.ProxyInit.Simulate,
+			NetNs:                 args.Netns,
+			UseWaitFlag:           conf.ProxyInit.UseWaitFlag,
+		},
+		IPv6: conf.ProxyInit.IPv6,
+		pod:  pod,
+	}
+
+	if ipv6Override := annotations[k8s.ProxyEnableIPv6Annotation]; ipv6Override != "" {
+		enabled, err := strconv.ParseBool(ipv6Override)
+		if err != nil {
+			logEntry.Warnf("linkerd-cni: ignoring invalid %s annotation: %s", k8s.ProxyEnableIPv6Annotation, ipv6Override)
+		} else {
+			logEntry.Debugf("linkerd-cni: overriding IPv6 to %t", enabled)
+			options.IPv6 = enabled
+		}
 	}
 
-	if override := ns.GetObjectMeta().GetAnnotations()[key]; override != "" {
-		return override, nil
+	if pod.Labels[k8s.ControllerComponentLabel] != "" {
+		// Skip 443 outbound port if its a control plane component
+		logEntry.Debug("linkerd-cni: adding 443 to OutboundPortsToIgnore as its a control plane component")
+		options.OutboundPortsToIgnore = append(options.OutboundPortsToIgnore, "443")
 	}
 
-	return "", nil
+	return &options, logEntry, nil
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/linkerd/linkerd2/cni-plugin/podcache"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/sirupsen/logrus"
+)
+
+// podCacheCommand is the first argument which makes the linkerd-cni binary
+// maintain the node-local pod cache instead of handling a CNI request. It's
+// run by the install-cni container.
+const podCacheCommand = "pod-cache"
+
+func runPodCache(args []string) {
+	cmd := flag.NewFlagSet(podCacheCommand, flag.ExitOnError)
+	dir := cmd.String("dir", "", "directory where the pod cache is stored")
+	nodeName := cmd.String("node-name", os.Getenv("NODE_NAME"), "name of the node whose pods are cached")
+	kubeconfig := cmd.String("kubeconfig", "", "path to the kubeconfig file; the in-cluster config is used if unset")
+	flags.ConfigureAndParse(cmd, args)
+
+	if *dir == "" || *nodeName == "" {
+		logrus.Fatal("-dir and -node-name are required")
+	}
+
+	client, err := k8s.NewAPI(*kubeconfig, "", "", []string{}, 0)
+	if err != nil {
+		logrus.Fatalf("failed to build the Kubernetes client: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	logrus.Infof("Maintaining the pod cache of node %s in %s", *nodeName, *dir)
+	if err := podcache.Watch(ctx, client, *nodeName, podcache.New(*dir)); err != nil {
+		logrus.Fatal(err)
+	}
+}
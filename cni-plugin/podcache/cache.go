@@ -0,0 +1,264 @@
+package podcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	podsDir       = "pods"
+	namespacesDir = "namespaces"
+
+	// pod and namespace names can't contain underscores, which makes it a
+	// safe separator in file names
+	separator = "_"
+)
+
+// ErrNotFound is returned when an object is not in the cache
+var ErrNotFound = errors.New("not found in the pod cache")
+
+// Pod holds the metadata of a pod the CNI plugin relies on to configure its
+// network. Pods are cached by namespace and name, and their UID tells apart
+// the successive pods of the same name, e.g. the ones of a StatefulSet.
+type Pod struct {
+	Name           string            `json:"name"`
+	Namespace      string            `json:"namespace"`
+	UID            string            `json:"uid,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	Annotations    map[string]string `json:"annotations,omitempty"`
+	Containers     []string          `json:"containers,omitempty"`
+	InitContainers []string          `json:"initContainers,omitempty"`
+}
+
+// Namespace holds the metadata of a namespace the CNI plugin relies on to
+// configure the network of its pods
+type Namespace struct {
+	Name        string            `json:"name"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Cache is a node-local cache of pod and namespace metadata, stored as one
+// JSON file per object under a directory shared with the CNI plugin
+type Cache struct {
+	dir string
+}
+
+// New returns a Cache stored under dir
+func New(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// NewPod returns the cached metadata of the given pod
+func NewPod(pod *corev1.Pod) *Pod {
+	p := &Pod{
+		Name:        pod.Name,
+		Namespace:   pod.Namespace,
+		UID:         string(pod.UID),
+		Labels:      pod.Labels,
+		Annotations: pod.Annotations,
+	}
+	for _, c := range pod.Spec.Containers {
+		p.Containers = append(p.Containers, c.Name)
+	}
+	for _, c := range pod.Spec.InitContainers {
+		p.InitContainers = append(p.InitContainers, c.Name)
+	}
+	return p
+}
+
+// NewNamespace returns the cached metadata of the given namespace
+func NewNamespace(ns *corev1.Namespace) *Namespace {
+	return &Namespace{
+		Name:        ns.Name,
+		Annotations: ns.Annotations,
+	}
+}
+
+// HasContainer returns true if the pod has a container with the given name
+func (p *Pod) HasContainer(name string) bool {
+	return contains(p.Containers, name)
+}
+
+// HasInitContainer returns true if the pod has an init container with the
+// given name
+func (p *Pod) HasInitContainer(name string) bool {
+	return contains(p.InitContainers, name)
+}
+
+// WritePod stores the metadata of the given pod
+func (c *Cache) WritePod(pod *Pod) error {
+	return c.write(c.podPath(pod.Namespace, pod.Name), pod)
+}
+
+// DeletePod removes the metadata of the given pod, unless it was replaced by
+// the one of a newer pod of the same name. An empty uid matches any pod.
+func (c *Cache) DeletePod(namespace, name, uid string) error {
+	if uid != "" {
+		pod, err := c.Pod(namespace, name, "")
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		if err == nil && pod.UID != "" && pod.UID != uid {
+			return nil
+		}
+	}
+	return remove(c.podPath(namespace, name))
+}
+
+// Pod returns the metadata of the given pod, or ErrNotFound. When uid isn't
+// empty, the metadata of another pod of the same name is ErrNotFound too.
+func (c *Cache) Pod(namespace, name, uid string) (*Pod, error) {
+	pod := &Pod{}
+	if err := c.read(c.podPath(namespace, name), pod); err != nil {
+		return nil, err
+	}
+	if uid != "" && pod.UID != uid {
+		return nil, ErrNotFound
+	}
+	return pod, nil
+}
+
+// WaitForPod polls the cache until the metadata of the given pod shows up,
+// which can lag behind the creation of its sandbox, or the context is done.
+func (c *Cache) WaitForPod(ctx context.Context, namespace, name, uid string, interval time.Duration) (*Pod, error) {
+	for {
+		pod, err := c.Pod(namespace, name, uid)
+		if !errors.Is(err, ErrNotFound) {
+			return pod, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ErrNotFound
+		case <-time.After(interval):
+		}
+	}
+}
+
+// WriteNamespace stores the metadata of the given namespace
+func (c *Cache) WriteNamespace(ns *Namespace) error {
+	return c.write(c.namespacePath(ns.Name), ns)
+}
+
+// DeleteNamespace removes the metadata of the given namespace
+func (c *Cache) DeleteNamespace(name string) error {
+	return remove(c.namespacePath(name))
+}
+
+// Namespace returns the metadata of the given namespace, or ErrNotFound
+func (c *Cache) Namespace(name string) (*Namespace, error) {
+	ns := &Namespace{}
+	if err := c.read(c.namespacePath(name), ns); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+// Prune removes the pods and namespaces that are not in the given sets, keyed
+// by namespace/name and name respectively. It's used to drop the objects that
+// were deleted while the cache wasn't maintained.
+func (c *Cache) Prune(pods map[string]struct{}, namespaces map[string]struct{}) error {
+	podFiles, err := ioutil.ReadDir(filepath.Join(c.dir, podsDir))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, f := range podFiles {
+		if strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		key := strings.Replace(strings.TrimSuffix(f.Name(), ".json"), separator, "/", 1)
+		if _, ok := pods[key]; !ok {
+			if err := remove(filepath.Join(c.dir, podsDir, f.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	nsFiles, err := ioutil.ReadDir(filepath.Join(c.dir, namespacesDir))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, f := range nsFiles {
+		if strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		if _, ok := namespaces[strings.TrimSuffix(f.Name(), ".json")]; !ok {
+			if err := remove(filepath.Join(c.dir, namespacesDir, f.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Cache) podPath(namespace, name string) string {
+	return filepath.Join(c.dir, podsDir, namespace+separator+name+".json")
+}
+
+func (c *Cache) namespacePath(name string) string {
+	return filepath.Join(c.dir, namespacesDir, name+".json")
+}
+
+// write stores obj at path atomically, so that the CNI plugin never reads a
+// partially written file
+func (c *Cache) write(path string, obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (c *Cache) read(path string, obj interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	if err := json.Unmarshal(data, obj); err != nil {
+		return fmt.Errorf("invalid pod cache entry %s: %s", path, err)
+	}
+	return nil
+}
+
+func remove(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package podcache
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func tempCache(t *testing.T) *Cache {
+	dir, err := ioutil.TempDir("", "pod-cache")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return New(dir)
+}
+
+func TestCache(t *testing.T) {
+	pod := NewPod(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-1",
+			Namespace:   "emojivoto",
+			Labels:      map[string]string{"app": "web"},
+			Annotations: map[string]string{"config.linkerd.io/skip-outbound-ports": "3306"},
+		},
+		Spec: corev1.PodSpec{
+			Containers:     []corev1.Container{{Name: "web"}, {Name: "linkerd-proxy"}},
+			InitContainers: []corev1.Container{{Name: "setup"}},
+		},
+	})
+
+	t.Run("reads back written pods and namespaces", func(t *testing.T) {
+		c := tempCache(t)
+		if err := c.WritePod(pod); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		got, err := c.Pod("emojivoto", "web-1", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(got, pod) {
+			t.Fatalf("Expected %+v, got %+v", pod, got)
+		}
+		if !got.HasContainer("linkerd-proxy") || got.HasInitContainer("linkerd-init") {
+			t.Fatalf("Unexpected containers in %+v", got)
+		}
+
+		ns := &Namespace{Name: "emojivoto", Annotations: map[string]string{"linkerd.io/inject": "enabled"}}
+		if err := c.WriteNamespace(ns); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		gotNs, err := c.Namespace("emojivoto")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(gotNs, ns) {
+			t.Fatalf("Expected %+v, got %+v", ns, gotNs)
+		}
+	})
+
+	t.Run("returns ErrNotFound for deleted pods", func(t *testing.T) {
+		c := tempCache(t)
+		if err := c.WritePod(pod); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := c.DeletePod("emojivoto", "web-1", ""); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := c.Pod("emojivoto", "web-1", ""); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Expected ErrNotFound, got %v", err)
+		}
+		// deleting twice is fine
+		if err := c.DeletePod("emojivoto", "web-1", ""); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("tells apart the pods of the same name", func(t *testing.T) {
+		c := tempCache(t)
+		old := *pod
+		old.UID = "uid-1"
+		current := *pod
+		current.UID = "uid-2"
+		if err := c.WritePod(&old); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := c.Pod("emojivoto", "web-1", "uid-2"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Expected ErrNotFound for a former pod, got %v", err)
+		}
+
+		if err := c.WritePod(&current); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		// the late deletion of the former pod keeps the current one
+		if err := c.DeletePod("emojivoto", "web-1", "uid-1"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		got, err := c.Pod("emojivoto", "web-1", "uid-2")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if got.UID != "uid-2" {
+			t.Fatalf("Expected pod uid-2, got %s", got.UID)
+		}
+		if err := c.DeletePod("emojivoto", "web-1", "uid-2"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := c.Pod("emojivoto", "web-1", ""); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("prunes the objects that are gone", func(t *testing.T) {
+		c := tempCache(t)
+		other := *pod
+		other.Name = "web-2"
+		for _, p := range []*Pod{pod, &other} {
+			if err := c.WritePod(p); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+		for _, name := range []string{"emojivoto", "default"} {
+			if err := c.WriteNamespace(&Namespace{Name: name}); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		err := c.Prune(map[string]struct{}{"emojivoto/web-2": {}}, map[string]struct{}{"emojivoto": {}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := c.Pod("emojivoto", "web-1", ""); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Expected web-1 to be pruned, got %v", err)
+		}
+		if _, err := c.Pod("emojivoto", "web-2", ""); err != nil {
+			t.Fatalf("Expected web-2 to be kept, got %v", err)
+		}
+		if _, err := c.Namespace("default"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Expected default to be pruned, got %v", err)
+		}
+	})
+
+	t.Run("waits for pods to be cached", func(t *testing.T) {
+		c := tempCache(t)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			c.WritePod(pod) //nolint:errcheck
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := c.WaitForPod(ctx, "emojivoto", "web-1", "", 5*time.Millisecond); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, err := c.WaitForPod(ctx, "emojivoto", "web-3", "", 5*time.Millisecond); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Expected ErrNotFound, got %v", err)
+		}
+	})
+}
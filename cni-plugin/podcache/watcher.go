@@ -0,0 +1,111 @@
+package podcache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const resyncPeriod = 10 * time.Minute
+
+// Watch keeps the cache in sync with the pods scheduled on the given node and
+// with all the namespaces, until the context is done. Stale entries are pruned
+// once the informers have synced.
+func Watch(ctx context.Context, client kubernetes.Interface, nodeName string, c *Cache) error {
+	podFactory := informers.NewSharedInformerFactoryWithOptions(client, resyncPeriod,
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", nodeName).String()
+		}),
+	)
+	nsFactory := informers.NewSharedInformerFactory(client, resyncPeriod)
+
+	pods := podFactory.Core().V1().Pods().Informer()
+	pods.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { writePod(c, obj) },
+		UpdateFunc: func(_, obj interface{}) { writePod(c, obj) },
+		DeleteFunc: func(obj interface{}) { deletePod(c, obj) },
+	})
+
+	namespaces := nsFactory.Core().V1().Namespaces().Informer()
+	namespaces.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { writeNamespace(c, obj) },
+		UpdateFunc: func(_, obj interface{}) { writeNamespace(c, obj) },
+		DeleteFunc: func(obj interface{}) { deleteNamespace(c, obj) },
+	})
+
+	podFactory.Start(ctx.Done())
+	nsFactory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), pods.HasSynced, namespaces.HasSynced) {
+		return fmt.Errorf("failed to sync the pod cache informers")
+	}
+
+	podKeys := make(map[string]struct{})
+	for _, key := range pods.GetStore().ListKeys() {
+		podKeys[key] = struct{}{}
+	}
+	nsKeys := make(map[string]struct{})
+	for _, key := range namespaces.GetStore().ListKeys() {
+		nsKeys[key] = struct{}{}
+	}
+	if err := c.Prune(podKeys, nsKeys); err != nil {
+		log.Errorf("Failed to prune the pod cache: %s", err)
+	}
+	log.Infof("Pod cache synced for node %s", nodeName)
+
+	<-ctx.Done()
+	return nil
+}
+
+func writePod(c *Cache, obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	if err := c.WritePod(NewPod(pod)); err != nil {
+		log.Errorf("Failed to cache pod %s/%s: %s", pod.Namespace, pod.Name, err)
+	}
+}
+
+func deletePod(c *Cache, obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	if err := c.DeletePod(pod.Namespace, pod.Name, string(pod.UID)); err != nil {
+		log.Errorf("Failed to remove pod %s/%s from the cache: %s", pod.Namespace, pod.Name, err)
+	}
+}
+
+func writeNamespace(c *Cache, obj interface{}) {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	if err := c.WriteNamespace(NewNamespace(ns)); err != nil {
+		log.Errorf("Failed to cache namespace %s: %s", ns.Name, err)
+	}
+}
+
+func deleteNamespace(c *Cache, obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	if err := c.DeleteNamespace(ns.Name); err != nil {
+		log.Errorf("Failed to remove namespace %s from the cache: %s", ns.Name, err)
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/cni-plugin/podcache"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	failOpen   = "fail-open"
+	failClosed = "fail-closed"
+
+	defaultAPITimeout      = 10 * time.Second
+	defaultPodCacheTimeout = 2 * time.Second
+	podCachePollInterval   = 50 * time.Millisecond
+)
+
+// podLookup fetches the metadata of pods and namespaces from the node-local
+// pod cache when it's enabled, and falls back to the Kubernetes API when an
+// object isn't cached (yet). The API client is only built when needed.
+type podLookup struct {
+	conf   *PluginConf
+	cache  *podcache.Cache
+	client *k8s.KubernetesAPI
+	log    *logrus.Entry
+}
+
+func newPodLookup(conf *PluginConf, log *logrus.Entry) *podLookup {
+	l := &podLookup{conf: conf, log: log}
+	if conf.PodCache.Dir != "" {
+		l.cache = podcache.New(conf.PodCache.Dir)
+	}
+	return l
+}
+
+// pod returns the metadata of the given pod. The pod cache is polled for up
+// to the pod cache timeout, since the pod may not have been cached by the
+// time its sandbox is created. The uid, when the container runtime passes
+// it, keeps the cache from returning a former pod of the same name.
+func (l *podLookup) pod(ctx context.Context, namespace, name, uid string) (*podcache.Pod, error) {
+	if l.cache != nil {
+		timeout, err := parseTimeout(l.conf.PodCache.Timeout, defaultPodCacheTimeout)
+		if err != nil {
+			return nil, err
+		}
+		waitCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		pod, err := l.cache.WaitForPod(waitCtx, namespace, name, uid, podCachePollInterval)
+		if err == nil {
+			return pod, nil
+		}
+		l.log.Debugf("linkerd-cni: could not read pod from the cache, querying the API: %s", err)
+	}
+
+	client, err := l.api()
+	if err != nil {
+		return nil, err
+	}
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return podcache.NewPod(pod), nil
+}
+
+// namespace returns the metadata of the given namespace.
+func (l *podLookup) namespace(ctx context.Context, name string) (*podcache.Namespace, error) {
+	if l.cache != nil {
+		ns, err := l.cache.Namespace(name)
+		if err == nil {
+			return ns, nil
+		}
+		l.log.Debugf("linkerd-cni: could not read namespace from the cache, querying the API: %s", err)
+	}
+
+	client, err := l.api()
+	if err != nil {
+		return nil, err
+	}
+	ns, err := client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return podcache.NewNamespace(ns), nil
+}
+
+func (l *podLookup) api() (*k8s.KubernetesAPI, error) {
+	if l.client != nil {
+		return l.client, nil
+	}
+	timeout, err := parseTimeout(l.conf.Kubernetes.APITimeout, defaultAPITimeout)
+	if err != nil {
+		return nil, err
+	}
+	client, err := k8s.NewAPI(l.conf.Kubernetes.Kubeconfig, "linkerd-cni-context", "", []string{}, timeout)
+	if err != nil {
+		return nil, err
+	}
+	l.client = client
+	return client, nil
+}
+
+// markRedirected records on the pod that its traffic is redirected to the
+// proxy for the given IP families, unless it already is.
+func (l *podLookup) markRedirected(ctx context.Context, pod *podcache.Pod, families []string) error {
+	value := strings.Join(families, ",")
+	if pod.Annotations[k8s.CNIRedirectedAnnotation] == value {
+		return nil
+	}
+
+	client, err := l.api()
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{k8s.CNIRedirectedAnnotation: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// annotations returns the annotations of the pod, falling back to the ones
+// of its namespace, as the proxy injector does: an empty pod annotation
+// doesn't override the namespace one.
+func (l *podLookup) annotations(ctx context.Context, pod *podcache.Pod) (map[string]string, error) {
+	ns, err := l.namespace(ctx, pod.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	annotations := make(map[string]string)
+	for k, v := range ns.Annotations {
+		annotations[k] = v
+	}
+	for k, v := range pod.Annotations {
+		if _, ok := annotations[k]; ok && v == "" {
+			continue
+		}
+		annotations[k] = v
+	}
+	return annotations, nil
+}
+
+// failOpen returns true if the CNI request must succeed, leaving the pod
+// network untouched, when the metadata of the pod can't be retrieved.
+func (conf *PluginConf) failOpen() bool {
+	return conf.FailurePolicy == failOpen
+}
+
+// failurePolicyError returns the error to fail the CNI request with, which is
+// nil under the fail-open policy.
+func failurePolicyError(conf *PluginConf, log *logrus.Entry, err error) error {
+	if conf.failOpen() {
+		log.Warn("linkerd-cni: leaving the pod network untouched as per the fail-open policy")
+		return nil
+	}
+	return err
+}
+
+func validateFailurePolicy(policy string) error {
+	switch policy {
+	case "", failOpen, failClosed:
+		return nil
+	}
+	return fmt.Errorf("linkerd-cni: invalid failure_policy %q, must be one of %s, %s", policy, failOpen, failClosed)
+}
+
+func parseTimeout(value string, defaultTimeout time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("linkerd-cni: invalid timeout %q: %s", value, err)
+	}
+	if timeout <= 0 {
+		return 0, errors.New("linkerd-cni: timeouts must be positive")
+	}
+	return timeout, nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/linkerd/linkerd2/cni-plugin/podcache"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestGetFirewallOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pod-cache")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache := podcache.New(dir)
+	for _, pod := range []*podcache.Pod{
+		{
+			Name:        "web",
+			Namespace:   "emojivoto",
+			Annotations: map[string]string{k8s.ProxyIgnoreInboundPortsAnnotation: "8080"},
+			Containers:  []string{"web", k8s.ProxyContainerName},
+		},
+		{
+			Name:      "emoji",
+			Namespace: "emojivoto",
+			Annotations: map[string]string{
+				k8s.ProxyInboundPortAnnotation: "5143",
+				k8s.ProxyAdminPortAnnotation:   "5191",
+				k8s.ProxyUIDAnnotation:         "3000",
+			},
+			Containers: []string{"emoji", k8s.ProxyContainerName},
+		},
+		{
+			Name:      "api",
+			Namespace: "emojivoto",
+			Annotations: map[string]string{
+				k8s.ProxyIgnoreOutboundPortsAnnotation: "",
+				k8s.ProxyEnableIPv6Annotation:          "",
+			},
+			Containers: []string{"api", k8s.ProxyContainerName},
+		},
+		{
+			Name:       "vote-bot",
+			Namespace:  "emojivoto",
+			Containers: []string{"vote-bot"},
+		},
+	} {
+		if err := cache.WritePod(pod); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	ns := &podcache.Namespace{
+		Name: "emojivoto",
+		Annotations: map[string]string{
+			k8s.ProxyIgnoreOutboundPortsAnnotation: "3306",
+			k8s.ProxyEnableIPv6Annotation:          "true",
+		},
+	}
+	if err := cache.WriteNamespace(ns); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	newConf := func(failurePolicy string) *PluginConf {
+		return &PluginConf{
+			// the API is unreachable, only the cache can be used
+			Kubernetes:    Kubernetes{Kubeconfig: "/nonexistent/kubeconfig"},
+			PodCache:      PodCache{Dir: dir, Timeout: "10ms"},
+			FailurePolicy: failurePolicy,
+			ProxyInit: ProxyInit{
+				IncomingProxyPort: 4143,
+				OutgoingProxyPort: 4140,
+				ProxyUID:          2102,
+			},
+		}
+	}
+	args := func(pod string) *skel.CmdArgs {
+		return &skel.CmdArgs{
+			ContainerID: "container",
+			Netns:       "/var/run/netns/pod",
+			Args:        "K8S_POD_NAMESPACE=emojivoto;K8S_POD_NAME=" + pod,
+		}
+	}
+
+	t.Run("reads the pod and namespace annotations from the cache", func(t *testing.T) {
+		options, _, err := getFirewallOptions(args("web"), newConf(""))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if options == nil {
+			t.Fatal("Expected the pod to be configured")
+		}
+		if !reflect.DeepEqual(options.InboundPortsToIgnore, []string{"8080"}) {
+			t.Fatalf("Expected inbound ports to ignore [8080], got %v", options.InboundPortsToIgnore)
+		}
+		if !reflect.DeepEqual(options.OutboundPortsToIgnore, []string{"3306"}) {
+			t.Fatalf("Expected outbound ports to ignore [3306], got %v", options.OutboundPortsToIgnore)
+		}
+		if !options.IPv6 {
+			t.Fatal("Expected IPv6 to be enabled by the namespace annotation")
+		}
+	})
+
+	t.Run("falls back to the namespace annotations when the pod ones are empty", func(t *testing.T) {
+		options, _, err := getFirewallOptions(args("api"), newConf(""))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(options.OutboundPortsToIgnore, []string{"3306"}) {
+			t.Fatalf("Expected outbound ports to ignore [3306], got %v", options.OutboundPortsToIgnore)
+		}
+		if !options.IPv6 {
+			t.Fatal("Expected IPv6 to be enabled by the namespace annotation")
+		}
+	})
+
+	t.Run("honors the annotations proxy-init honors", func(t *testing.T) {
+		conf := newConf("")
+		conf.ProxyInit.ProxyControlPort = 4190
+		conf.ProxyInit.ProxyAdminPort = 4191
+		options, _, err := getFirewallOptions(args("emoji"), conf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if options.IncomingProxyPort != 5143 || options.ProxyUserID != 3000 {
+			t.Fatalf("Expected the inbound port and UID to be overridden, got %+v", options.RootOptions)
+		}
+		if !reflect.DeepEqual(options.InboundPortsToIgnore, []string{"4190", "5191"}) {
+			t.Fatalf("Expected inbound ports to ignore [4190 5191], got %v", options.InboundPortsToIgnore)
+		}
+	})
+
+	t.Run("skips pods without a proxy", func(t *testing.T) {
+		options, _, err := getFirewallOptions(args("vote-bot"), newConf(""))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if options != nil {
+			t.Fatalf("Expected the pod to be skipped, got %+v", options)
+		}
+	})
+
+	t.Run("fails closed when the pod can't be retrieved", func(t *testing.T) {
+		if _, _, err := getFirewallOptions(args("voting"), newConf(failClosed)); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+
+	t.Run("fails open when the pod can't be retrieved", func(t *testing.T) {
+		options, _, err := getFirewallOptions(args("voting"), newConf(failOpen))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if options != nil {
+			t.Fatalf("Expected the pod to be skipped, got %+v", options)
+		}
+	})
+}
+
+func TestParseConfigFailurePolicy(t *testing.T) {
+	if _, err := parseConfig([]byte(`{"failure_policy": "fail-open"}`)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := parseConfig([]byte(`{"failure_policy": "ignore"}`)); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/linkerd/linkerd2/cni-plugin/repair"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// repairCommand is the first argument which makes the linkerd-cni binary run
+// the repair controller of a node instead of handling a CNI request. It's run
+// by the repair-controller container of the linkerd-cni DaemonSet.
+const repairCommand = "repair"
+
+func runRepair(args []string) {
+	cmd := flag.NewFlagSet(repairCommand, flag.ExitOnError)
+	nodeName := cmd.String("node-name", os.Getenv("NODE_NAME"), "name of the node whose pods are repaired")
+	kubeconfig := cmd.String("kubeconfig", "", "path to the kubeconfig file; the in-cluster config is used if unset")
+	evict := cmd.Bool("evict-pods", false, "evict the pods whose traffic isn't redirected to the proxy, besides reporting them")
+	flags.ConfigureAndParse(cmd, args)
+
+	if *nodeName == "" {
+		logrus.Fatal("-node-name is required")
+	}
+
+	client, err := k8s.NewAPI(*kubeconfig, "", "", []string{}, 0)
+	if err != nil {
+		logrus.Fatalf("failed to build the Kubernetes client: %s", err)
+	}
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: client.CoreV1().Events(""),
+	})
+	defer eventBroadcaster.Shutdown()
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "linkerd-cni-repair", Host: *nodeName})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	if err := repair.NewController(client, recorder, *evict).Run(ctx, *nodeName); err != nil {
+		logrus.Fatal(err)
+	}
+}
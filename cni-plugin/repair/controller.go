@@ -0,0 +1,125 @@
+package repair
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// resyncPeriod is also how often the eviction of a pod is retried, when
+	// it's blocked by a PodDisruptionBudget
+	resyncPeriod = time.Minute
+
+	// EventReasonMissingRedirect is the reason of the events emitted for the
+	// pods whose traffic isn't redirected to the proxy
+	EventReasonMissingRedirect = "CNIRedirectMissing"
+
+	// EventReasonEvicted is the reason of the events emitted for the pods
+	// evicted by the controller
+	EventReasonEvicted = "CNIRepairEvicted"
+)
+
+// Controller watches the pods of a node and reports the ones whose traffic
+// bypasses the proxy because their network was set up before the CNI plugin
+// was ready on the node. It optionally evicts them, so that they get
+// recreated with a properly set up network.
+type Controller struct {
+	client   kubernetes.Interface
+	recorder record.EventRecorder
+	evict    bool
+
+	// reported holds the pods already reported, so that their events aren't
+	// repeated on every update. Informer handlers are called sequentially, so
+	// it doesn't need a lock.
+	reported map[types.UID]struct{}
+	log      *log.Entry
+}
+
+// NewController returns a Controller that evicts the pods it reports if evict
+// is true
+func NewController(client kubernetes.Interface, recorder record.EventRecorder, evict bool) *Controller {
+	return &Controller{
+		client:   client,
+		recorder: recorder,
+		evict:    evict,
+		reported: make(map[types.UID]struct{}),
+		log:      log.WithField("component", "repair-controller"),
+	}
+}
+
+// Run watches the pods of the given node until the context is done
+func (c *Controller) Run(ctx context.Context, nodeName string) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(c.client, resyncPeriod,
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", nodeName).String()
+		}),
+	)
+	pods := factory.Core().V1().Pods().Informer()
+	pods.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.handle(ctx, obj) },
+		UpdateFunc: func(_, obj interface{}) { c.handle(ctx, obj) },
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pod, ok := obj.(*corev1.Pod); ok {
+				delete(c.reported, pod.UID)
+			}
+		},
+	})
+
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), pods.HasSynced) {
+		return fmt.Errorf("failed to sync the pods informer")
+	}
+	c.log.Infof("Watching the pods of node %s (evict: %t)", nodeName, c.evict)
+
+	<-ctx.Done()
+	return nil
+}
+
+func (c *Controller) handle(ctx context.Context, obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || !k8s.IsMissingCNIRedirect(*pod) {
+		return
+	}
+
+	if _, ok := c.reported[pod.UID]; !ok {
+		c.reported[pod.UID] = struct{}{}
+		c.log.Warnf("The traffic of pod %s/%s bypasses the proxy", pod.Namespace, pod.Name)
+		c.recorder.Event(pod, corev1.EventTypeWarning, EventReasonMissingRedirect,
+			"linkerd-cni didn't set up the redirection of the traffic of this pod, which bypasses the proxy; the pod needs to be recreated")
+	}
+
+	if !c.evict {
+		return
+	}
+
+	eviction := &policyv1beta1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	if err := c.client.PolicyV1beta1().Evictions(pod.Namespace).Evict(ctx, eviction); err != nil {
+		// retried on the next resync
+		c.log.Errorf("Failed to evict pod %s/%s: %s", pod.Namespace, pod.Name, err)
+		return
+	}
+	c.log.Infof("Evicted pod %s/%s", pod.Namespace, pod.Name)
+	c.recorder.Event(pod, corev1.EventTypeNormal, EventReasonEvicted,
+		"Evicted so that linkerd-cni sets up the redirection of the traffic of the recreated pod")
+}
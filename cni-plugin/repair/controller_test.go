@@ -0,0 +1,152 @@
+package repair
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+var pluginStart = time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+
+func unredirectedPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "emojivoto",
+			UID:       types.UID("uid-" + name),
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "web"}, {Name: k8s.ProxyContainerName}},
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodRunning,
+			StartTime: &metav1.Time{Time: pluginStart.Add(time.Hour)},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  k8s.ProxyContainerName,
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				},
+			},
+		},
+	}
+}
+
+func evictions(client *fake.Clientset) int {
+	count := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "create" && action.GetSubresource() == "eviction" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("reports unredirected pods once", func(t *testing.T) {
+		pod := unredirectedPod("web")
+		client := fake.NewSimpleClientset(pod)
+		recorder := record.NewFakeRecorder(10)
+		c := NewController(client, recorder, false)
+
+		c.handle(context.Background(), pod)
+		c.handle(context.Background(), pod)
+
+		if len(recorder.Events) != 1 {
+			t.Fatalf("Expected 1 event, got %d", len(recorder.Events))
+		}
+		if evictions(client) != 0 {
+			t.Fatal("Expected no eviction")
+		}
+	})
+
+	t.Run("evicts unredirected pods when enabled", func(t *testing.T) {
+		pod := unredirectedPod("web")
+		client := fake.NewSimpleClientset(pod)
+		recorder := record.NewFakeRecorder(10)
+		c := NewController(client, recorder, true)
+
+		c.handle(context.Background(), pod)
+
+		if len(recorder.Events) != 2 {
+			t.Fatalf("Expected 2 events, got %d", len(recorder.Events))
+		}
+		if evictions(client) != 1 {
+			t.Fatalf("Expected 1 eviction, got %d", evictions(client))
+		}
+	})
+
+	t.Run("ignores redirected pods", func(t *testing.T) {
+		pod := unredirectedPod("web")
+		pod.Annotations = map[string]string{k8s.CNIRedirectedAnnotation: "ipv4"}
+		client := fake.NewSimpleClientset(pod)
+		recorder := record.NewFakeRecorder(10)
+		c := NewController(client, recorder, true)
+
+		c.handle(context.Background(), pod)
+
+		if len(recorder.Events) != 0 {
+			t.Fatalf("Expected no event, got %d", len(recorder.Events))
+		}
+		if evictions(client) != 0 {
+			t.Fatal("Expected no eviction")
+		}
+	})
+
+	t.Run("evicts unredirected pods started before the plugin", func(t *testing.T) {
+		pod := unredirectedPod("web")
+		pod.Status.StartTime = &metav1.Time{Time: pluginStart.Add(-time.Hour)}
+		client := fake.NewSimpleClientset(pod)
+		recorder := record.NewFakeRecorder(10)
+		c := NewController(client, recorder, true)
+
+		c.handle(context.Background(), pod)
+
+		if len(recorder.Events) != 2 {
+			t.Fatalf("Expected 2 events, got %d", len(recorder.Events))
+		}
+		if evictions(client) != 1 {
+			t.Fatalf("Expected 1 eviction, got %d", evictions(client))
+		}
+	})
+
+	t.Run("ignores uninjected pods", func(t *testing.T) {
+		pod := unredirectedPod("web")
+		pod.Spec.Containers = []corev1.Container{{Name: "web"}}
+		client := fake.NewSimpleClientset(pod)
+		recorder := record.NewFakeRecorder(10)
+		c := NewController(client, recorder, true)
+
+		c.handle(context.Background(), pod)
+
+		if len(recorder.Events) != 0 {
+			t.Fatalf("Expected no event, got %d", len(recorder.Events))
+		}
+		if evictions(client) != 0 {
+			t.Fatal("Expected no eviction")
+		}
+	})
+
+	t.Run("ignores host network pods", func(t *testing.T) {
+		pod := unredirectedPod("web")
+		pod.Spec.HostNetwork = true
+		client := fake.NewSimpleClientset(pod)
+		recorder := record.NewFakeRecorder(10)
+		c := NewController(client, recorder, true)
+
+		c.handle(context.Background(), pod)
+
+		if len(recorder.Events) != 0 {
+			t.Fatalf("Expected no event, got %d", len(recorder.Events))
+		}
+		if evictions(client) != 0 {
+			t.Fatal("Expected no eviction")
+		}
+	})
+}
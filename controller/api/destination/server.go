@@ -14,8 +14,12 @@ import (
 	"github.com/linkerd/linkerd2/controller/k8s"
 	labels "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/prometheus"
+	"github.com/linkerd/linkerd2/pkg/trace"
 	"github.com/linkerd/linkerd2/pkg/util"
 	logging "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
@@ -24,6 +28,10 @@ import (
 	coreinformers "k8s.io/client-go/informers/core/v1"
 )
 
+// tracerName is the name of the OpenTelemetry tracer of the destination
+// service
+const tracerName = "github.com/linkerd/linkerd2/controller/api/destination"
+
 type (
 	server struct {
 		endpoints     *watcher.EndpointsWatcher
@@ -105,7 +113,10 @@ func NewServer(
 	return s, nil
 }
 
-func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) error {
+func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) (err error) {
+	endSpan := trace.EndSpanOnce(startSpan(stream.Context(), "destination.Get", dest))
+	defer func() { endSpan(err) }()
+
 	client, _ := peer.FromContext(stream.Context())
 	log := s.log
 	if client != nil {
@@ -160,6 +171,10 @@ func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) e
 	}
 	defer s.endpoints.Unsubscribe(service, port, instanceID, translator)
 
+	// The span covers the lookup up to the subscription, not the lifetime of
+	// the stream.
+	endSpan(nil)
+
 	select {
 	case <-s.shutdown:
 	case <-stream.Context().Done():
@@ -169,7 +184,10 @@ func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) e
 	return nil
 }
 
-func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetProfileServer) error {
+func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetProfileServer) (err error) {
+	endSpan := trace.EndSpanOnce(startSpan(stream.Context(), "destination.GetProfile", dest))
+	defer func() { endSpan(err) }()
+
 	log := s.log
 	client, _ := peer.FromContext(stream.Context())
 	if client != nil {
@@ -217,6 +235,8 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 				return err
 			}
 
+			endSpan(nil)
+
 			select {
 			case <-s.shutdown:
 			case <-stream.Context().Done():
@@ -248,6 +268,8 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 				return err
 			}
 
+			endSpan(nil)
+
 			select {
 			case <-s.shutdown:
 			case <-stream.Context().Done():
@@ -328,6 +350,8 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 	}
 	defer s.profiles.Unsubscribe(profile, secondary)
 
+	endSpan(nil)
+
 	select {
 	case <-s.shutdown:
 	case <-stream.Context().Done():
@@ -589,6 +613,13 @@ func profileID(authority string, ctxToken contextToken, clusterDomain string) (w
 	return id, nil
 }
 
+// startSpan starts the span of a destination lookup
+func startSpan(ctx context.Context, name string, dest *pb.GetDestination) oteltrace.Span {
+	_, span := otel.Tracer(tracerName).Start(ctx, name,
+		oteltrace.WithAttributes(attribute.String("destination.path", dest.GetPath())))
+	return span
+}
+
 func getHostAndPort(authority string) (string, watcher.Port, error) {
 	hostPort := strings.Split(authority, ":")
 	if len(hostPort) > 2 {
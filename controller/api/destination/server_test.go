@@ -11,7 +11,9 @@ import (
 	"github.com/linkerd/linkerd2/controller/api/util"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/addr"
+	"github.com/linkerd/linkerd2/pkg/trace"
 	logging "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const fullyQualifiedName = "name1.ns.svc.mycluster.local"
@@ -466,6 +468,34 @@ func TestGet(t *testing.T) {
 			t.Fatalf("Expected TLS identity for %s to be nil but got %+v", path, addrs[0].TlsIdentity)
 		}
 	})
+
+	t.Run("Records a span of the lookup", func(t *testing.T) {
+		exporter := trace.InitializeInMemoryTracing()
+		server := makeServer(t)
+		stream := &bufferingGetStream{
+			updates:          []*pb.Update{},
+			MockServerStream: util.NewMockServerStream(),
+		}
+		stream.Cancel()
+
+		path := fmt.Sprintf("%s:%d", fullyQualifiedName, port)
+		err := server.Get(&pb.GetDestination{Scheme: "k8s", Path: path}, stream)
+		if err != nil {
+			t.Fatalf("Got error: %s", err)
+		}
+
+		spans := exporter.GetSpans()
+		if len(spans) != 1 {
+			t.Fatalf("Expected 1 span but got %d", len(spans))
+		}
+		if spans[0].Name != "destination.Get" {
+			t.Fatalf("Expected span destination.Get but got %s", spans[0].Name)
+		}
+		expected := attribute.String("destination.path", path)
+		if len(spans[0].Attributes) != 1 || spans[0].Attributes[0] != expected {
+			t.Fatalf("Expected attributes [%v] but got %v", expected, spans[0].Attributes)
+		}
+	})
 }
 
 func TestGetProfiles(t *testing.T) {
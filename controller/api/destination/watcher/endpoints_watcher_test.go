@@ -1728,6 +1728,96 @@ subsets:
 	}
 }
 
+func TestEndpointsWatcherServiceMirrorsWithEndpointSlices(t *testing.T) {
+	for _, tt := range []struct {
+		serviceType       string
+		k8sConfigs        []string
+		id                ServiceID
+		port              Port
+		expectedAddresses []string
+	}{
+		{
+			serviceType: "mirrored service with identity and EndpointSlice",
+			k8sConfigs: []string{`
+kind: APIResourceList
+apiVersion: v1
+groupVersion: discovery.k8s.io/v1beta1
+resources:
+  - name: endpointslices
+    singularName: endpointslice
+    namespaced: true
+    kind: EndpointSlice
+    verbs:
+      - delete
+      - deletecollection
+      - get
+      - list
+      - patch
+      - create
+      - update
+      - watch
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: name1-remote
+  namespace: ns
+spec:
+  type: LoadBalancer
+  ports:
+  - port: 8989`,
+				`
+addressType: IPv4
+apiVersion: discovery.k8s.io/v1beta1
+endpoints:
+- addresses:
+  - 172.17.0.12
+  conditions:
+    ready: true
+kind: EndpointSlice
+metadata:
+  annotations:
+    mirror.linkerd.io/remote-gateway-identity: "gateway-identity-1"
+    mirror.linkerd.io/remote-svc-fq-name: "name1-remote-fq"
+  labels:
+    kubernetes.io/service-name: name1-remote
+    mirror.linkerd.io/mirrored-service: "true"
+  name: name1-remote-abcde
+  namespace: ns
+ports:
+- name: ""
+  port: 9999`,
+			},
+			id:   ServiceID{Name: "name1-remote", Namespace: "ns"},
+			port: 8989,
+			expectedAddresses: []string{
+				"172.17.0.12:9999/gateway-identity-1/name1-remote-fq:8989",
+			},
+		},
+	} {
+		tt := tt // pin
+		t.Run("subscribes listener to "+tt.serviceType, func(t *testing.T) {
+			k8sAPI, err := k8s.NewFakeAPI(tt.k8sConfigs...)
+			if err != nil {
+				t.Fatalf("NewFakeAPI returned an error: %s", err)
+			}
+
+			watcher := NewEndpointsWatcher(k8sAPI, logging.WithField("test", t.Name()), true)
+
+			k8sAPI.Sync(nil)
+
+			listener := newBufferingEndpointListener()
+
+			err = watcher.Subscribe(tt.id, tt.port, "", listener)
+			if err != nil {
+				t.Fatalf("Expected no error, got [%s]", err)
+			}
+
+			listener.ExpectAdded(tt.expectedAddresses, t)
+		})
+	}
+}
+
 func testPod(resVersion string) *corev1.Pod {
 	return &corev1.Pod{
 		TypeMeta: metav1.TypeMeta{
@@ -33,7 +33,7 @@ func Main(args []string) {
 	clusterDomain := cmd.String("cluster-domain", "", "kubernetes cluster domain")
 	defaultOpaquePorts := cmd.String("default-opaque-ports", "", "configures the default opaque ports")
 
-	traceCollector := flags.AddTraceFlags(cmd)
+	traceConfig := flags.AddTraceFlags(cmd)
 
 	flags.ConfigureAndParse(cmd, args)
 
@@ -68,8 +68,8 @@ func Main(args []string) {
 
 	log.Infof("Using default opaque ports: %v", opaquePorts)
 
-	if *traceCollector != "" {
-		if err := trace.InitializeTracing("linkerd-destination", *traceCollector); err != nil {
+	if traceConfig.Enabled() {
+		if err := trace.InitializeTracing("linkerd-destination", traceConfig); err != nil {
 			log.Warnf("failed to initialize tracing: %s", err)
 		}
 	}
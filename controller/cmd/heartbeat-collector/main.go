@@ -0,0 +1,48 @@
+package heartbeatcollector
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/linkerd/linkerd2/controller/heartbeat/collector"
+	"github.com/linkerd/linkerd2/pkg/admin"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	log "github.com/sirupsen/logrus"
+)
+
+// Main executes the heartbeat-collector subcommand
+func Main(args []string) {
+	cmd := flag.NewFlagSet("heartbeat-collector", flag.ExitOnError)
+
+	addr := cmd.String("addr", ":8080", "address to serve heartbeats on")
+	metricsAddr := cmd.String("metrics-addr", fmt.Sprintf(":%d", 9996), "address to serve scrapable metrics on")
+
+	flags.ConfigureAndParse(cmd, args)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	server := &http.Server{
+		Addr:    *addr,
+		Handler: collector.New().Handler(),
+	}
+
+	go func() {
+		log.Infof("starting heartbeat collector on %s", *addr)
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			log.Fatalf("heartbeat collector failed: %s", err)
+		}
+	}()
+
+	go admin.StartServer(*metricsAddr)
+
+	<-stop
+
+	log.Infof("shutting down heartbeat collector on %s", *addr)
+	server.Shutdown(context.Background())
+}
@@ -3,7 +3,6 @@ package heartbeat
 import (
 	"context"
 	"flag"
-	"net/url"
 
 	"github.com/linkerd/linkerd2/controller/heartbeat"
 	"github.com/linkerd/linkerd2/pkg/flags"
@@ -21,42 +20,27 @@ func Main(args []string) {
 	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
 	prometheusURL := cmd.String("prometheus-url", "http://127.0.0.1:9090", "prometheus url")
 	controllerNamespace := cmd.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	heartbeatURL := cmd.String("heartbeat-url", version.CheckURL, "url the heartbeat is sent to")
 
 	flags.ConfigureAndParse(cmd, args)
 
-	// Gather the following fields:
-	// - version
-	// - source
-	// - uuid
-	// - k8s-version
-	// - install-time
-	// - rps
-	// - meshed-pods
-	// - proxy-injector-injections
-	// TODO:
-	// - k8s-env
-	v := url.Values{}
-	v.Set("version", version.Version)
-	v.Set("source", "heartbeat")
-
 	kubeAPI, err := k8s.NewAPI(*kubeConfigPath, "", "", []string{}, 0)
 	if err != nil {
 		log.Errorf("Failed to initialize k8s API: %s", err)
-	} else {
-		k8sV := heartbeat.K8sValues(context.Background(), kubeAPI, *controllerNamespace)
-		v = heartbeat.MergeValues(v, k8sV)
+		kubeAPI = nil
 	}
 
+	var promAPI promv1.API
 	prometheusClient, err := promApi.NewClient(promApi.Config{Address: *prometheusURL})
 	if err != nil {
 		log.Errorf("Failed to initialize Prometheus client: %s", err)
 	} else {
-		promAPI := promv1.NewAPI(prometheusClient)
-		promV := heartbeat.PromValues(promAPI, *controllerNamespace)
-		v = heartbeat.MergeValues(v, promV)
+		promAPI = promv1.NewAPI(prometheusClient)
 	}
 
-	err = heartbeat.Send(v)
+	v := heartbeat.Values(context.Background(), kubeAPI, promAPI, *controllerNamespace)
+
+	err = heartbeat.SendTo(*heartbeatURL, v)
 	if err != nil {
 		log.Fatalf("Failed to send heartbeat: %s", err)
 	}
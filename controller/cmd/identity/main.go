@@ -51,7 +51,7 @@ func Main(args []string) {
 
 	var issuerPathCrt string
 	var issuerPathKey string
-	traceCollector := flags.AddTraceFlags(cmd)
+	traceConfig := flags.AddTraceFlags(cmd)
 	componentName := "linkerd-identity"
 
 	flags.ConfigureAndParse(cmd, args)
@@ -177,8 +177,8 @@ func Main(args []string) {
 		log.Fatalf("Failed to listen on %s: %s", *addr, err)
 	}
 
-	if *traceCollector != "" {
-		if err := trace.InitializeTracing(componentName, *traceCollector); err != nil {
+	if traceConfig.Enabled() {
+		if err := trace.InitializeTracing(componentName, traceConfig); err != nil {
 			log.Warnf("failed to initialize tracing: %s", err)
 		}
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/linkerd/linkerd2/controller/cmd/destination"
 	"github.com/linkerd/linkerd2/controller/cmd/heartbeat"
+	heartbeatcollector "github.com/linkerd/linkerd2/controller/cmd/heartbeat-collector"
 	"github.com/linkerd/linkerd2/controller/cmd/identity"
 	proxyinjector "github.com/linkerd/linkerd2/controller/cmd/proxy-injector"
 	spvalidator "github.com/linkerd/linkerd2/controller/cmd/sp-validator"
@@ -23,6 +24,8 @@ func main() {
 		destination.Main(os.Args[2:])
 	case "heartbeat":
 		heartbeat.Main(os.Args[2:])
+	case "heartbeat-collector":
+		heartbeatcollector.Main(os.Args[2:])
 	case "identity":
 		identity.Main(os.Args[2:])
 	case "proxy-injector":
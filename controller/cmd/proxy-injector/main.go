@@ -9,6 +9,8 @@ import (
 	injector "github.com/linkerd/linkerd2/controller/proxy-injector"
 	"github.com/linkerd/linkerd2/controller/webhook"
 	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/trace"
+	log "github.com/sirupsen/logrus"
 )
 
 // Main executes the proxy-injector subcommand
@@ -17,11 +19,18 @@ func Main(args []string) {
 	metricsAddr := cmd.String("metrics-addr", fmt.Sprintf(":%d", 9995), "address to serve scrapable metrics on")
 	addr := cmd.String("addr", ":8443", "address to serve on")
 	kubeconfig := cmd.String("kubeconfig", "", "path to kubeconfig")
+	traceConfig := flags.AddTraceFlags(cmd)
 	flags.ConfigureAndParse(cmd, args)
 
+	if traceConfig.Enabled() {
+		if err := trace.InitializeTracing("linkerd-proxy-injector", traceConfig); err != nil {
+			log.Warnf("failed to initialize tracing: %s", err)
+		}
+	}
+
 	webhook.Launch(
 		context.Background(),
-		[]k8s.APIResource{k8s.NS, k8s.Deploy, k8s.RC, k8s.RS, k8s.Job, k8s.DS, k8s.SS, k8s.Pod, k8s.CJ},
+		[]k8s.APIResource{k8s.NS, k8s.Deploy, k8s.RC, k8s.RS, k8s.Job, k8s.DS, k8s.SS, k8s.Pod, k8s.CJ, k8s.PC},
 		injector.Inject,
 		"linkerd-proxy-injector",
 		*metricsAddr,
@@ -0,0 +1,245 @@
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	clustersPath = "/clusters"
+	summaryPath  = "/summary"
+
+	extensionPrefix = "ext-"
+
+	// defaultMaxClusters bounds the memory used by the collector: the least
+	// recently seen cluster is forgotten to make room for a new one
+	defaultMaxClusters = 10000
+	maxValues          = 64
+	maxKeyLength       = 64
+	maxValueLength     = 256
+
+	// otherVersion is the version label of the heartbeats whose version isn't
+	// a stable or edge release, so that the label has a bounded cardinality
+	otherVersion = "other"
+)
+
+var (
+	uuidRegexp    = regexp.MustCompile(`^[0-9a-z-]{1,64}$`)
+	keyRegexp     = regexp.MustCompile(`^[0-9a-z][0-9a-z-]*$`)
+	releaseRegexp = regexp.MustCompile(`^(stable|edge)-[0-9]+\.[0-9]+\.[0-9]+$`)
+)
+
+var heartbeatsReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "heartbeat_collector_heartbeats_total",
+	Help: "A counter for the number of heartbeats received by the collector.",
+}, []string{"version"})
+
+type (
+	// Cluster holds the latest heartbeat received from a Linkerd cluster
+	Cluster struct {
+		UUID       string            `json:"uuid"`
+		Version    string            `json:"version"`
+		FirstSeen  time.Time         `json:"firstSeen"`
+		LastSeen   time.Time         `json:"lastSeen"`
+		Heartbeats int               `json:"heartbeats"`
+		Values     map[string]string `json:"values"`
+	}
+
+	// Summary aggregates the latest heartbeats of all the clusters
+	Summary struct {
+		Clusters   int            `json:"clusters"`
+		Versions   map[string]int `json:"versions"`
+		Extensions map[string]int `json:"extensions"`
+		MeshedPods float64        `json:"meshedPods"`
+		TotalRPS   float64        `json:"totalRPS"`
+	}
+
+	// Collector aggregates in memory the heartbeats sent by the heartbeat
+	// cronjob of Linkerd clusters, as an alternative to
+	// versioncheck.linkerd.io for environments that can't or don't want to
+	// reach it
+	Collector struct {
+		sync.RWMutex
+		clusters    map[string]*Cluster
+		maxClusters int
+		now         func() time.Time
+	}
+)
+
+// New returns an empty Collector
+func New() *Collector {
+	return &Collector{
+		clusters:    make(map[string]*Cluster),
+		maxClusters: defaultMaxClusters,
+		now:         time.Now,
+	}
+}
+
+// Record stores a heartbeat, replacing the previous heartbeat of the same
+// cluster. Heartbeats with an invalid uuid, too many parameters, or invalid
+// parameters are rejected.
+func (c *Collector) Record(v url.Values) error {
+	values, err := normalize(v)
+	if err != nil {
+		return err
+	}
+	uuid := values["uuid"]
+	if uuid == "" {
+		return errors.New("heartbeat without uuid")
+	}
+	if !uuidRegexp.MatchString(uuid) {
+		return fmt.Errorf("invalid uuid %q", uuid)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	now := c.now()
+	cluster, ok := c.clusters[uuid]
+	if !ok {
+		if len(c.clusters) >= c.maxClusters {
+			c.evictLeastRecentlySeen()
+		}
+		cluster = &Cluster{UUID: uuid, FirstSeen: now}
+		c.clusters[uuid] = cluster
+	}
+	cluster.Version = values["version"]
+	cluster.LastSeen = now
+	cluster.Heartbeats++
+	cluster.Values = values
+
+	heartbeatsReceived.WithLabelValues(versionLabel(cluster.Version)).Inc()
+	return nil
+}
+
+// normalize returns the first value of every parameter of the heartbeat,
+// with lowercased keys and trimmed values
+func normalize(v url.Values) (map[string]string, error) {
+	if len(v) > maxValues {
+		return nil, fmt.Errorf("heartbeat with %d parameters, at most %d are allowed", len(v), maxValues)
+	}
+	values := make(map[string]string, len(v))
+	for key := range v {
+		normalized := strings.ToLower(strings.TrimSpace(key))
+		if len(normalized) > maxKeyLength || !keyRegexp.MatchString(normalized) {
+			return nil, fmt.Errorf("invalid parameter %q", key)
+		}
+		value := strings.TrimSpace(v.Get(key))
+		if len(value) > maxValueLength {
+			return nil, fmt.Errorf("the value of the parameter %q is longer than %d characters", key, maxValueLength)
+		}
+		values[normalized] = value
+	}
+	values["uuid"] = strings.ToLower(values["uuid"])
+	return values, nil
+}
+
+// evictLeastRecentlySeen forgets the cluster that sent a heartbeat the
+// longest time ago. It must be called with the lock held.
+func (c *Collector) evictLeastRecentlySeen() {
+	var oldest *Cluster
+	for _, cluster := range c.clusters {
+		if oldest == nil || cluster.LastSeen.Before(oldest.LastSeen) {
+			oldest = cluster
+		}
+	}
+	if oldest != nil {
+		delete(c.clusters, oldest.UUID)
+	}
+}
+
+// versionLabel returns the version as the label of the heartbeats counter if
+// it's a stable or edge release, and otherVersion otherwise
+func versionLabel(version string) string {
+	if releaseRegexp.MatchString(version) {
+		return version
+	}
+	return otherVersion
+}
+
+// Clusters returns the clusters that sent a heartbeat, sorted by UUID
+func (c *Collector) Clusters() []Cluster {
+	c.RLock()
+	defer c.RUnlock()
+	clusters := make([]Cluster, 0, len(c.clusters))
+	for _, cluster := range c.clusters {
+		clusters = append(clusters, *cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].UUID < clusters[j].UUID
+	})
+	return clusters
+}
+
+// Summary aggregates the latest heartbeat of every cluster
+func (c *Collector) Summary() Summary {
+	summary := Summary{
+		Versions:   make(map[string]int),
+		Extensions: make(map[string]int),
+	}
+	for _, cluster := range c.Clusters() {
+		summary.Clusters++
+		summary.Versions[cluster.Version]++
+		for key := range cluster.Values {
+			if strings.HasPrefix(key, extensionPrefix) {
+				summary.Extensions[strings.TrimPrefix(key, extensionPrefix)]++
+			}
+		}
+		summary.MeshedPods += parseFloat(cluster.Values["meshed-pods"])
+		summary.TotalRPS += parseFloat(cluster.Values["total-rps"])
+	}
+	return summary
+}
+
+// Handler returns the HTTP handler of the collector. The clusters and their
+// summary are served as JSON under /clusters and /summary; a GET request on
+// any other path is recorded as a heartbeat.
+func (c *Collector) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(clustersPath, func(w http.ResponseWriter, req *http.Request) {
+		writeJSON(w, c.Clusters())
+	})
+	mux.HandleFunc(summaryPath, func(w http.ResponseWriter, req *http.Request) {
+		writeJSON(w, c.Summary())
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := c.Record(req.URL.Query()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Debugf("Recorded heartbeat: %s", req.URL.RawQuery)
+		writeJSON(w, struct{}{})
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("Failed to write response: %s", err)
+	}
+}
+
+func parseFloat(s string) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
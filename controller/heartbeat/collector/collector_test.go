@@ -0,0 +1,140 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollector(t *testing.T) {
+	c := New()
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	ts := httptest.NewServer(c.Handler())
+	defer ts.Close()
+
+	heartbeats := []url.Values{
+		{"uuid": {"a"}, "version": {"stable-2.10.1"}, "meshed-pods": {"10"}, "total-rps": {"1.5"}},
+		{"uuid": {"a"}, "version": {"stable-2.10.2"}, "meshed-pods": {"12"}, "ext-viz": {"1"}},
+		{"uuid": {"b"}, "version": {"stable-2.10.2"}, "meshed-pods": {"3"}, "total-rps": {"2"}, "ext-viz": {"1"}},
+	}
+	for _, v := range heartbeats {
+		resp, err := http.Get(ts.URL + "/version.json?" + v.Encode())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+		}
+		now = now.Add(time.Hour)
+	}
+
+	resp, err := http.Get(ts.URL + "/version.json?version=stable-2.10.2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a heartbeat without uuid, got %d", resp.StatusCode)
+	}
+
+	var clusters []Cluster
+	getJSON(t, ts.URL+clustersPath, &clusters)
+	if len(clusters) != 2 {
+		t.Fatalf("Expected 2 clusters, got %d", len(clusters))
+	}
+	a := clusters[0]
+	if a.UUID != "a" || a.Version != "stable-2.10.2" || a.Heartbeats != 2 {
+		t.Errorf("Unexpected cluster: %+v", a)
+	}
+	if !a.FirstSeen.Equal(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)) ||
+		!a.LastSeen.Equal(time.Date(2021, 6, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected first and last seen times: %s, %s", a.FirstSeen, a.LastSeen)
+	}
+
+	var summary Summary
+	getJSON(t, ts.URL+summaryPath, &summary)
+	expected := Summary{
+		Clusters:   2,
+		Versions:   map[string]int{"stable-2.10.2": 2},
+		Extensions: map[string]int{"viz": 2},
+		MeshedPods: 15,
+		TotalRPS:   2,
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("Expected summary %+v, got %+v", expected, summary)
+	}
+}
+
+func getJSON(t *testing.T, url string, v interface{}) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestCollectorLimits(t *testing.T) {
+	c := New()
+	c.maxClusters = 2
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	for _, uuid := range []string{"a", "b", "a", "c"} {
+		if err := c.Record(url.Values{"uuid": {uuid}, "version": {"stable-2.10.2"}}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		now = now.Add(time.Hour)
+	}
+	clusters := c.Clusters()
+	if len(clusters) != 2 || clusters[0].UUID != "a" || clusters[1].UUID != "c" {
+		t.Errorf("Expected the least recently seen cluster to be evicted, got %+v", clusters)
+	}
+
+	tooMany := url.Values{"uuid": {"d"}}
+	for i := 0; i < maxValues; i++ {
+		tooMany.Set(fmt.Sprintf("param-%d", i), "1")
+	}
+	for name, v := range map[string]url.Values{
+		"invalid uuid":      {"uuid": {"not a uuid"}},
+		"invalid parameter": {"uuid": {"d"}, "foo bar": {"1"}},
+		"too long value":    {"uuid": {"d"}, "version": {strings.Repeat("x", maxValueLength+1)}},
+		"too many values":   tooMany,
+	} {
+		if err := c.Record(v); err == nil {
+			t.Errorf("Expected the heartbeat to be rejected: %s", name)
+		}
+	}
+
+	if err := c.Record(url.Values{"UUID": {" E "}, "Version": {"stable-2.10.2"}}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if clusters := c.Clusters(); clusters[1].UUID != "e" || clusters[1].Version != "stable-2.10.2" {
+		t.Errorf("Expected the heartbeat to be normalized, got %+v", clusters[1])
+	}
+}
+
+func TestVersionLabel(t *testing.T) {
+	for version, expected := range map[string]string{
+		"stable-2.10.2":     "stable-2.10.2",
+		"edge-21.6.1":       "edge-21.6.1",
+		"dev-0123abcd-user": otherVersion,
+		"":                  otherVersion,
+	} {
+		if actual := versionLabel(version); actual != expected {
+			t.Errorf("Expected label %q for version %q, got %q", expected, version, actual)
+		}
+	}
+}
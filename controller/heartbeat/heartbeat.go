@@ -25,6 +25,33 @@ type containerMeta struct {
 	ns   model.LabelValue
 }
 
+// Values gathers the full heartbeat payload:
+// - version
+// - source
+// - uuid
+// - k8s-version
+// - install-time
+// - rps
+// - meshed-pods
+// - proxy-injector-injections
+// The Kubernetes or Prometheus values are left out if the corresponding client
+// is nil.
+// TODO:
+// - k8s-env
+func Values(ctx context.Context, kubeAPI *k8s.KubernetesAPI, promAPI promv1.API, controlPlaneNamespace string) url.Values {
+	v := url.Values{}
+	v.Set("version", version.Version)
+	v.Set("source", "heartbeat")
+
+	if kubeAPI != nil {
+		v = MergeValues(v, K8sValues(ctx, kubeAPI, controlPlaneNamespace))
+	}
+	if promAPI != nil {
+		v = MergeValues(v, PromValues(promAPI, controlPlaneNamespace))
+	}
+	return v
+}
+
 // K8sValues gathers relevant heartbeat information from Kubernetes
 func K8sValues(ctx context.Context, kubeAPI *k8s.KubernetesAPI, controlPlaneNamespace string) url.Values {
 	v := url.Values{}
@@ -220,15 +247,35 @@ func MergeValues(v1, v2 url.Values) url.Values {
 
 // Send takes a map of url.Values and sends them to versioncheck.linkerd.io
 func Send(v url.Values) error {
-	return send(http.DefaultClient, version.CheckURL, v)
+	return SendTo(version.CheckURL, v)
+}
+
+// SendTo takes a map of url.Values and sends them to the heartbeat endpoint
+// at baseURL
+func SendTo(baseURL string, v url.Values) error {
+	return send(http.DefaultClient, baseURL, v)
+}
+
+// RequestURL returns the URL of the request that sends the heartbeat values
+// to baseURL
+func RequestURL(baseURL string, v url.Values) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL [%s]: %s", baseURL, err)
+	}
+	u.RawQuery = v.Encode()
+	return u.String(), nil
 }
 
 func send(client *http.Client, baseURL string, v url.Values) error {
-	req, err := http.NewRequest("GET", baseURL, nil)
+	reqURL, err := RequestURL(baseURL, v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("GET", reqURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create HTTP request for base URL [%s]: %s", baseURL, err)
 	}
-	req.URL.RawQuery = v.Encode()
 
 	log.Infof("Sending heartbeat: %s", req.URL.String())
 	resp, err := client.Do(req)
@@ -215,3 +215,22 @@ func TestSend(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestURL(t *testing.T) {
+	v := url.Values{
+		"version": []string{"stable-2.10.2"},
+		"uuid":    []string{"fake-uuid"},
+	}
+	actual, err := RequestURL("http://collector.example.com/heartbeat", v)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := "http://collector.example.com/heartbeat?uuid=fake-uuid&version=stable-2.10.2"
+	if actual != expected {
+		t.Errorf("Expected %s, got %s", expected, actual)
+	}
+
+	if _, err := RequestURL("http://[::1", v); err == nil {
+		t.Error("Expected an error for an invalid URL")
+	}
+}
@@ -28,6 +28,8 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	arinformers "k8s.io/client-go/informers/admissionregistration/v1beta1"
 	appv1informers "k8s.io/client-go/informers/apps/v1"
@@ -63,6 +65,7 @@ const (
 	Node
 	Secret
 	ES // EndpointSlice resource
+	PC // ProxyConfig custom resource
 )
 
 // API provides shared informers for all Kubernetes objects
@@ -87,11 +90,13 @@ type API struct {
 	ts       tsinformers.TrafficSplitInformer
 	node     coreinformers.NodeInformer
 	secret   coreinformers.SecretInformer
+	pc       informers.GenericInformer
 
-	syncChecks        []cache.InformerSynced
-	sharedInformers   informers.SharedInformerFactory
-	spSharedInformers sp.SharedInformerFactory
-	tsSharedInformers ts.SharedInformerFactory
+	syncChecks             []cache.InformerSynced
+	sharedInformers        informers.SharedInformerFactory
+	spSharedInformers      sp.SharedInformerFactory
+	tsSharedInformers      ts.SharedInformerFactory
+	dynamicSharedInformers dynamicinformer.DynamicSharedInformerFactory
 }
 
 // InitializeAPI creates Kubernetes clients and returns an initialized API wrapper.
@@ -160,7 +165,20 @@ func initAPI(ctx context.Context, k8sClient *k8s.KubernetesAPI, kubeConfig *rest
 			break
 		}
 	}
-	return NewAPI(k8sClient, spClient, tsClient, resources...), nil
+
+	// ProxyConfigs
+	var dynamicClient dynamic.Interface
+	for _, res := range resources {
+		if res == PC {
+			dynamicClient, err = dynamic.NewForConfig(kubeConfig)
+			if err != nil {
+				return nil, err
+			}
+
+			break
+		}
+	}
+	return NewAPI(k8sClient, spClient, tsClient, dynamicClient, resources...), nil
 }
 
 // NewAPI takes a Kubernetes client and returns an initialized API.
@@ -168,6 +186,7 @@ func NewAPI(
 	k8sClient kubernetes.Interface,
 	spClient spclient.Interface,
 	tsClient tsclient.Interface,
+	dynamicClient dynamic.Interface,
 	resources ...APIResource,
 ) *API {
 	sharedInformers := informers.NewSharedInformerFactory(k8sClient, 10*time.Minute)
@@ -182,12 +201,18 @@ func NewAPI(
 		tsSharedInformers = ts.NewSharedInformerFactory(tsClient, 10*time.Minute)
 	}
 
+	var dynamicSharedInformers dynamicinformer.DynamicSharedInformerFactory
+	if dynamicClient != nil {
+		dynamicSharedInformers = dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 10*time.Minute)
+	}
+
 	api := &API{
-		Client:            k8sClient,
-		syncChecks:        make([]cache.InformerSynced, 0),
-		sharedInformers:   sharedInformers,
-		spSharedInformers: spSharedInformers,
-		tsSharedInformers: tsSharedInformers,
+		Client:                 k8sClient,
+		syncChecks:             make([]cache.InformerSynced, 0),
+		sharedInformers:        sharedInformers,
+		spSharedInformers:      spSharedInformers,
+		tsSharedInformers:      tsSharedInformers,
+		dynamicSharedInformers: dynamicSharedInformers,
 	}
 
 	for _, resource := range resources {
@@ -252,6 +277,12 @@ func NewAPI(
 		case Secret:
 			api.secret = sharedInformers.Core().V1().Secrets()
 			api.syncChecks = append(api.syncChecks, api.secret.Informer().HasSynced)
+		case PC:
+			if dynamicSharedInformers == nil {
+				panic("PC shared informer not configured")
+			}
+			api.pc = dynamicSharedInformers.ForResource(k8s.ProxyConfigGVR)
+			api.syncChecks = append(api.syncChecks, api.pc.Informer().HasSynced)
 		}
 	}
 	return api
@@ -269,6 +300,10 @@ func (api *API) Sync(stopCh <-chan struct{}) {
 		api.tsSharedInformers.Start(stopCh)
 	}
 
+	if api.dynamicSharedInformers != nil {
+		api.dynamicSharedInformers.Start(stopCh)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
@@ -406,6 +441,15 @@ func (api *API) TS() tsinformers.TrafficSplitInformer {
 	return api.ts
 }
 
+// PC provides access to a shared informer and lister for ProxyConfigs, which
+// are unstructured.
+func (api *API) PC() informers.GenericInformer {
+	if api.pc == nil {
+		panic("PC informer not configured")
+	}
+	return api.pc
+}
+
 // Node provides access to a shared informer and lister for Nodes.
 func (api *API) Node() coreinformers.NodeInformer {
 	if api.node == nil {
@@ -3,19 +3,46 @@ package k8s
 import (
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
 )
 
 // NewFakeAPI provides a mock Kubernetes API for testing.
 func NewFakeAPI(configs ...string) (*API, error) {
-	clientSet, _, _, spClientSet, tsClientSet, err := k8s.NewFakeClientSets(configs...)
+	// ProxyConfigs are unstructured, so they're served by a fake dynamic
+	// client instead
+	pcObjs := []runtime.Object{}
+	typedConfigs := []string{}
+	for _, config := range configs {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(config), &obj.Object); err != nil {
+			return nil, err
+		}
+		if obj.GetKind() == k8s.ProxyConfigKind {
+			pcObjs = append(pcObjs, obj)
+		} else {
+			typedConfigs = append(typedConfigs, config)
+		}
+	}
+
+	clientSet, _, _, spClientSet, tsClientSet, err := k8s.NewFakeClientSets(typedConfigs...)
 	if err != nil {
 		return nil, err
 	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{k8s.ProxyConfigGVR: k8s.ProxyConfigKind + "List"},
+		pcObjs...,
+	)
 
 	return NewAPI(
 		clientSet,
 		spClientSet,
 		tsClientSet,
+		dynamicClient,
 		CJ,
 		CM,
 		Deploy,
@@ -33,6 +60,7 @@ func NewFakeAPI(configs ...string) (*API, error) {
 		TS,
 		Node,
 		ES,
+		PC,
 	), nil
 }
 
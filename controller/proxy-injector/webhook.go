@@ -11,8 +11,11 @@ import (
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -21,6 +24,7 @@ import (
 const (
 	eventTypeSkipped  = "InjectionSkipped"
 	eventTypeInjected = "Injected"
+	eventTypePolicy   = "InjectionPolicy"
 )
 
 // Inject returns an AdmissionResponse containing the patch, if any, to apply
@@ -48,6 +52,8 @@ func Inject(
 	resourceConfig := inject.NewResourceConfig(valuesConfig, inject.OriginWebhook).
 		WithOwnerRetriever(ownerRetriever(ctx, api, request.Namespace)).
 		WithNsAnnotations(nsAnnotations).
+		WithNamespace(namespace).
+		WithProxyConfigs(request.Namespace, proxyConfigs(api, valuesConfig.Namespace, request.Namespace)).
 		WithKind(request.Kind.Kind)
 
 	// Build the injection report.
@@ -76,9 +82,21 @@ func Inject(
 	configLabels := configToPrometheusLabels(resourceConfig)
 	proxyInjectionAdmissionRequests.With(admissionRequestLabels(ownerKind, request.Namespace, report.InjectAnnotationAt, configLabels)).Inc()
 
+	if decision := report.PolicyDecision(); decision != "" {
+		if parent != nil {
+			recorder.Eventf(*parent, v1.EventTypeNormal, eventTypePolicy, "Linkerd %s", decision)
+		}
+		log.Infof("%s: %s", report.ResName(), decision)
+	}
+
 	// If the resource is injectable then admit it after creating a patch that
 	// adds the proxy-init and proxy containers.
 	injectable, reasons := report.Injectable()
+	oteltrace.SpanFromContext(ctx).SetAttributes(
+		attribute.String("inject.resource", report.ResName()),
+		attribute.Bool("inject.injectable", injectable),
+		attribute.String("inject.skip_reasons", strings.Join(reasons, ",")),
+	)
 	if injectable {
 		resourceConfig.AppendPodAnnotation(pkgK8s.CreatedByAnnotation, fmt.Sprintf("linkerd/proxy-injector %s", version.Version))
 
@@ -150,6 +168,38 @@ func Inject(
 	}, nil
 }
 
+// proxyConfigs returns the ProxyConfigs of the given namespaces. The invalid
+// ones are skipped.
+func proxyConfigs(api *k8s.API, namespaces ...string) []inject.ProxyConfig {
+	configs := []inject.ProxyConfig{}
+	seen := make(map[string]struct{})
+	for _, ns := range namespaces {
+		if _, ok := seen[ns]; ok {
+			continue
+		}
+		seen[ns] = struct{}{}
+
+		objs, err := api.PC().Lister().ByNamespace(ns).List(labels.Everything())
+		if err != nil {
+			log.Errorf("failed to list the ProxyConfigs of namespace %s: %s", ns, err)
+			continue
+		}
+		for _, obj := range objs {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			config, err := inject.NewProxyConfig(*u)
+			if err != nil {
+				log.Warnf("ignoring invalid ProxyConfig %s/%s: %s", u.GetNamespace(), u.GetName(), err)
+				continue
+			}
+			configs = append(configs, config)
+		}
+	}
+	return configs
+}
+
 func ownerRetriever(ctx context.Context, api *k8s.API, ns string) inject.OwnerRetrieverFunc {
 	return func(p *v1.Pod) (string, string) {
 		p.SetNamespace(ns)
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"testing"
 
+	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/controller/proxy-injector/fake"
 	"github.com/linkerd/linkerd2/pkg/charts/linkerd2"
 	"github.com/linkerd/linkerd2/pkg/inject"
@@ -398,3 +400,57 @@ func unmarshalPatch(patchJSON []byte) (unmarshalledPatch, error) {
 
 	return actualPatch, nil
 }
+
+func TestProxyConfigs(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: config.linkerd.io/v1alpha1
+kind: ProxyConfig
+metadata:
+  name: default
+  namespace: linkerd
+spec:
+  annotations:
+    config.linkerd.io/proxy-log-level: warn`, `
+apiVersion: config.linkerd.io/v1alpha1
+kind: ProxyConfig
+metadata:
+  name: web
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: web
+  annotations:
+    config.linkerd.io/proxy-cpu-request: 100m`, `
+apiVersion: config.linkerd.io/v1alpha1
+kind: ProxyConfig
+metadata:
+  name: invalid
+  namespace: emojivoto
+spec:
+  annotations:
+    linkerd.io/inject: enabled`, `
+apiVersion: config.linkerd.io/v1alpha1
+kind: ProxyConfig
+metadata:
+  name: other
+  namespace: other
+spec:
+  annotations:
+    config.linkerd.io/proxy-log-level: debug`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+
+	configs := proxyConfigs(k8sAPI, "linkerd", "emojivoto", "emojivoto")
+	names := []string{}
+	for _, config := range configs {
+		names = append(names, config.String())
+	}
+	sort.Strings(names)
+	expected := []string{"ProxyConfig emojivoto/web", "ProxyConfig linkerd/default"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected ProxyConfigs %v, got %v", expected, names)
+	}
+}
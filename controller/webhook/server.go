@@ -11,8 +11,12 @@ import (
 	"github.com/linkerd/linkerd2/controller/k8s"
 	pkgk8s "github.com/linkerd/linkerd2/pkg/k8s"
 	pkgTls "github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/linkerd/linkerd2/pkg/trace"
 	"github.com/sirupsen/logrus"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +26,9 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// tracerName is the name of the OpenTelemetry tracer of the webhooks
+const tracerName = "github.com/linkerd/linkerd2/controller/webhook"
+
 // Handler is the signature for the functions that ultimately deal with
 // the admission request
 type Handler func(
@@ -162,7 +169,14 @@ func (s *Server) processReq(ctx context.Context, data []byte) *admissionv1beta1.
 	log.Infof("received admission review request %s", admissionReview.Request.UID)
 	log.Debugf("admission request: %+v", admissionReview.Request)
 
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "webhook.Admit", oteltrace.WithAttributes(
+		attribute.String("admission.uid", string(admissionReview.Request.UID)),
+		attribute.String("admission.kind", admissionReview.Request.Kind.Kind),
+		attribute.String("admission.namespace", admissionReview.Request.Namespace),
+		attribute.String("admission.operation", string(admissionReview.Request.Operation)),
+	))
 	admissionResponse, err := s.handler(ctx, s.api, admissionReview.Request, s.recorder)
+	trace.EndSpan(span, err)
 	if err != nil {
 		log.Error("failed to run webhook handler. Reason: ", err)
 		admissionReview.Response = &admissionv1beta1.AdmissionResponse{
@@ -11,6 +11,10 @@ import (
 	"time"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/pkg/trace"
+	"go.opentelemetry.io/otel/attribute"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/client-go/tools/record"
 )
 
 var mockHTTPServer = &http.Server{
@@ -58,3 +62,47 @@ func TestShutdown(t *testing.T) {
 		t.Fatal("Unexpected error: ", err)
 	}
 }
+
+func TestProcessReqSpan(t *testing.T) {
+	exporter := trace.InitializeInMemoryTracing()
+	handler := func(
+		ctx context.Context,
+		api *k8s.API,
+		req *admissionv1beta1.AdmissionRequest,
+		recorder record.EventRecorder,
+	) (*admissionv1beta1.AdmissionResponse, error) {
+		return &admissionv1beta1.AdmissionResponse{UID: req.UID, Allowed: true}, nil
+	}
+	testServer := getConfiguredServer(mockHTTPServer, nil, handler, nil)
+
+	review := testServer.processReq(context.Background(), []byte(`{
+  "apiVersion": "admission.k8s.io/v1beta1",
+  "kind": "AdmissionReview",
+  "request": {
+    "uid": "fake-uid",
+    "kind": {"group": "apps", "version": "v1", "kind": "Deployment"},
+    "namespace": "emojivoto",
+    "operation": "CREATE"
+  }
+}`))
+	if !review.Response.Allowed {
+		t.Fatalf("Expected the request to be allowed: %+v", review.Response)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name != "webhook.Admit" {
+		t.Errorf("Expected span webhook.Admit, got %s", spans[0].Name)
+	}
+	expected := []attribute.KeyValue{
+		attribute.String("admission.uid", "fake-uid"),
+		attribute.String("admission.kind", "Deployment"),
+		attribute.String("admission.namespace", "emojivoto"),
+		attribute.String("admission.operation", "CREATE"),
+	}
+	if !reflect.DeepEqual(spans[0].Attributes, expected) {
+		t.Errorf("Expected attributes %v, got %v", expected, spans[0].Attributes)
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/linkerd/linkerd2/cli/table"
+	servicemirror "github.com/linkerd/linkerd2/multicluster/service-mirror"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/k8s/resource"
+	mc "github.com/linkerd/linkerd2/pkg/multicluster"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// linkDryRun prints the mirror services that the service mirror controller of
+// the given link would create, update or delete in the source cluster, without
+// applying anything.
+func linkDryRun(ctx context.Context, target *k8s.KubernetesAPI, link *mc.Link, sourceContext string, w io.Writer) error {
+	source, err := k8s.NewAPI(kubeconfigPath, sourceContext, impersonate, impersonateGroup, 0)
+	if err != nil {
+		return err
+	}
+
+	remoteServices, err := target.CoreV1().Services(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list services in the target cluster: %s", err)
+	}
+
+	localMirrors, err := source.CoreV1().Services(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: servicemirror.MirroredResourcesSelector(link).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list mirror services in the source cluster: %s", err)
+	}
+
+	changes, err := servicemirror.PlanMirroring(link, remoteServices.Items, localMirrors.Items)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Linking cluster %s would apply the following changes to mirror services:\n\n", link.TargetClusterName)
+	renderMirrorChanges(changes, w)
+	return nil
+}
+
+// unlinkDryRun prints the resources that unlinking the given link would delete
+// from the source cluster, along with the namespaces created by its service
+// mirror controller that would be left behind.
+func unlinkDryRun(ctx context.Context, k *k8s.KubernetesAPI, link *mc.Link, resources []resource.Kubernetes, w io.Writer) error {
+	localMirrors, err := k.CoreV1().Services(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: servicemirror.MirroredResourcesSelector(link).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list mirror services: %s", err)
+	}
+
+	namespaces, err := k.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+		LabelSelector: servicemirror.MirroredResourcesSelector(link).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list mirrored namespaces: %s", err)
+	}
+
+	fmt.Fprintf(w, "Unlinking cluster %s would delete the following resources:\n\n", link.TargetClusterName)
+	for _, r := range resources {
+		if r.Namespace == "" {
+			fmt.Fprintf(w, "  %s %s\n", r.Kind, r.Name)
+		} else {
+			fmt.Fprintf(w, "  %s %s/%s\n", r.Kind, r.Namespace, r.Name)
+		}
+	}
+
+	fmt.Fprintln(w, "\nand the following mirror services:")
+	fmt.Fprintln(w)
+	renderMirrorChanges(servicemirror.PlanCleanup(link, localMirrors.Items), w)
+
+	if len(namespaces.Items) > 0 {
+		names := []string{}
+		for _, ns := range namespaces.Items {
+			names = append(names, ns.Name)
+		}
+		sort.Strings(names)
+		fmt.Fprintln(w, "\nThe following namespaces were created by the service mirror and would be orphaned:")
+		fmt.Fprintln(w)
+		for _, name := range names {
+			fmt.Fprintf(w, "  %s\n", name)
+		}
+	}
+	return nil
+}
+
+func renderMirrorChanges(changes []servicemirror.MirrorChange, w io.Writer) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes")
+		return
+	}
+
+	t := table.NewTable([]table.Column{
+		{Header: "ACTION", Width: 6, Flexible: true, LeftAlign: true},
+		{Header: "NAMESPACE", Width: 9, Flexible: true, LeftAlign: true},
+		{Header: "NAME", Width: 4, Flexible: true, LeftAlign: true},
+		{Header: "TARGET SERVICE", Width: 14, Flexible: true, LeftAlign: true},
+	}, []table.Row{})
+	for _, change := range changes {
+		remote := change.RemoteService
+		if remote == "" {
+			remote = "-"
+		}
+		t.Data = append(t.Data, []string{string(change.Action), change.Namespace, change.Name, remote})
+	}
+	t.Render(w)
+}
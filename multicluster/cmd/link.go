@@ -39,12 +39,17 @@ type (
 		gatewayName             string
 		gatewayNamespace        string
 		serviceMirrorRetryLimit uint32
+		enableEndpointSlices    bool
 		logLevel                string
 		controlPlaneVersion     string
 		dockerRegistry          string
 		selector                string
 		gatewayAddresses        string
 		gatewayPort             uint32
+		namespaceMapping        map[string]string
+		refresh                 bool
+		dryRun                  bool
+		sourceContext           string
 	}
 )
 
@@ -64,6 +69,12 @@ func newLinkCommand() *cobra.Command {
 		Example: `  # To link the west cluster to east
   linkerd --context=east multicluster link --cluster-name east | kubectl --context=west apply -f -
 
+  # To regenerate the credentials used by an existing link, e.g. after the service account token was rotated
+  linkerd --context=east multicluster link --cluster-name east --refresh | kubectl --context=west apply -f -
+
+  # To preview which services west would mirror from east
+  linkerd --context=east multicluster link --cluster-name east --dry-run --source-context=west
+
 The command can be configured by using the --set, --values, --set-string and --set-file flags.
 A full list of configurable values can be found at https://github.com/linkerd/linkerd2/blob/main/multicluster/charts/linkerd-multicluster-link/README.md
   `,
@@ -73,6 +84,10 @@ A full list of configurable values can be found at https://github.com/linkerd/li
 				return errors.New("You need to specify cluster name")
 			}
 
+			if opts.dryRun && opts.sourceContext == "" {
+				return errors.New("You need to specify the context of the source cluster with --source-context when using --dry-run")
+			}
+
 			configMap, err := getLinkerdConfigMap(cmd.Context())
 			if err != nil {
 				if kerrors.IsNotFound(err) {
@@ -171,6 +186,14 @@ A full list of configurable values can be found at https://github.com/linkerd/li
 				return err
 			}
 
+			// When refreshing, only the credentials secret is output. The
+			// service mirror controller watches it and picks up the new
+			// credentials without the link having to be recreated.
+			if opts.refresh {
+				stdout.Write(credsOut)
+				return nil
+			}
+
 			gateway, err := k.CoreV1().Services(opts.gatewayNamespace).Get(cmd.Context(), opts.gatewayName, metav1.GetOptions{})
 			if err != nil {
 				return err
@@ -221,6 +244,10 @@ A full list of configurable values can be found at https://github.com/linkerd/li
 				return err
 			}
 
+			if err := mc.ValidateNamespaceMapping(opts.namespaceMapping); err != nil {
+				return fmt.Errorf("invalid --namespace-mapping: %s", err)
+			}
+
 			link := mc.Link{
 				Name:                          opts.clusterName,
 				Namespace:                     opts.namespace,
@@ -233,12 +260,21 @@ A full list of configurable values can be found at https://github.com/linkerd/li
 				GatewayIdentity:               gatewayIdentity,
 				ProbeSpec:                     probeSpec,
 				Selector:                      *selector,
+				NamespaceMapping:              opts.namespaceMapping,
 			}
 
 			obj, err := link.ToUnstructured()
 			if err != nil {
 				return err
 			}
+			// Make sure the service mirror will be able to parse the Link
+			if _, err := mc.NewLink(obj); err != nil {
+				return err
+			}
+
+			if opts.dryRun {
+				return linkDryRun(cmd.Context(), k, &link, opts.sourceContext, stdout)
+			}
 			linkOut, err := yaml.Marshal(obj.Object)
 			if err != nil {
 				return err
@@ -326,11 +362,16 @@ A full list of configurable values can be found at https://github.com/linkerd/li
 	cmd.Flags().StringVar(&opts.gatewayName, "gateway-name", defaultGatewayName, "The name of the gateway service")
 	cmd.Flags().StringVar(&opts.gatewayNamespace, "gateway-namespace", defaultMulticlusterNamespace, "The namespace of the gateway service")
 	cmd.Flags().Uint32Var(&opts.serviceMirrorRetryLimit, "service-mirror-retry-limit", opts.serviceMirrorRetryLimit, "The number of times a failed update from the target cluster is allowed to be retried")
+	cmd.Flags().BoolVar(&opts.enableEndpointSlices, "enable-endpoint-slices", opts.enableEndpointSlices, "Write EndpointSlices for mirror services, in addition to Endpoints (should match the --enable-endpoint-slices setting of the control plane)")
 	cmd.Flags().StringVar(&opts.logLevel, "log-level", opts.logLevel, "Log level for the Multicluster components")
 	cmd.Flags().StringVar(&opts.dockerRegistry, "registry", opts.dockerRegistry, "Docker registry to pull service mirror controller image from")
 	cmd.Flags().StringVarP(&opts.selector, "selector", "l", opts.selector, "Selector (label query) to filter which services in the target cluster to mirror")
 	cmd.Flags().StringVar(&opts.gatewayAddresses, "gateway-addresses", opts.gatewayAddresses, "If specified, overwrites gateway addresses when gateway service is not type LoadBalancer (comma separated list)")
 	cmd.Flags().Uint32Var(&opts.gatewayPort, "gateway-port", opts.gatewayPort, "If specified, overwrites gateway port when gateway service is not type LoadBalancer")
+	cmd.Flags().BoolVar(&opts.refresh, "refresh", false, "Only output the credentials secret of the link, regenerated from the current service account token")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Instead of outputting resources, show the mirror services that would be created, updated or deleted in the source cluster")
+	cmd.Flags().StringVar(&opts.sourceContext, "source-context", "", "Name of the kubeconfig context of the source cluster, which mirrors services from this one (required with --dry-run)")
+	cmd.Flags().StringToStringVar(&opts.namespaceMapping, "namespace-mapping", opts.namespaceMapping, "Mirror services from a target cluster namespace into a different local namespace (e.g. prod=prod-east,staging=staging-east)")
 
 	pkgcmd.ConfigureNamespaceFlagCompletion(
 		cmd, []string{"namespace", "gateway-namespace"},
@@ -349,6 +390,7 @@ func newLinkOptionsWithDefault() (*linkOptions, error) {
 		namespace:               defaults.Namespace,
 		dockerRegistry:          defaultDockerRegistry,
 		serviceMirrorRetryLimit: defaults.ServiceMirrorRetryLimit,
+		enableEndpointSlices:    defaults.EnableEndpointSlices,
 		logLevel:                defaults.LogLevel,
 		selector:                k8s.DefaultExportedServiceSelector,
 		gatewayAddresses:        "",
@@ -382,6 +424,7 @@ func buildServiceMirrorValues(opts *linkOptions) (*multicluster.Values, error) {
 	defaults.TargetClusterName = opts.clusterName
 	defaults.Namespace = opts.namespace
 	defaults.ServiceMirrorRetryLimit = opts.serviceMirrorRetryLimit
+	defaults.EnableEndpointSlices = opts.enableEndpointSlices
 	defaults.LogLevel = opts.logLevel
 	defaults.ControllerImageVersion = opts.controlPlaneVersion
 	defaults.ControllerImage = fmt.Sprintf("%s/controller", opts.dockerRegistry)
@@ -1,6 +1,7 @@
 package servicemirror
 
 import (
+	"bytes"
 	"context"
 	"flag"
 	"fmt"
@@ -17,17 +18,39 @@ import (
 	"github.com/linkerd/linkerd2/pkg/multicluster"
 	sm "github.com/linkerd/linkerd2/pkg/servicemirror"
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	dynamic "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 )
 
-const linkWatchRestartAfter = 10 * time.Second
+const (
+	linkWatchRestartAfter = 10 * time.Second
+	credsWatchRetryMin    = 1 * time.Second
+	credsWatchRetryMax    = 2 * time.Minute
+	componentName         = "linkerd-service-mirror"
+)
 
 var (
 	clusterWatcher *servicemirror.RemoteClusterServiceWatcher
 	probeWorker    *servicemirror.ProbeWorker
+
+	// currentLink and currentCreds hold the configuration the running cluster
+	// watcher was started with, so that it can be restarted whenever the
+	// credentials secret changes
+	currentLink  *multicluster.Link
+	currentCreds []byte
+	credsWatch   watch.Interface
+
+	// credsRetry fires when the credentials secret watch failed to start and
+	// should be retried, after credsRetryBackoff
+	credsRetry        <-chan time.Time
+	credsRetryBackoff = credsWatchRetryMin
 )
 
 // Main executes the service-mirror controller
@@ -39,6 +62,7 @@ func Main(args []string) {
 	metricsAddr := cmd.String("metrics-addr", ":9999", "address to serve scrapable metrics on")
 	namespace := cmd.String("namespace", "", "namespace containing Link and credentials Secret")
 	repairPeriod := cmd.Duration("endpoint-refresh-period", 1*time.Minute, "frequency to refresh endpoint resolution")
+	enableEndpointSlices := cmd.Bool("enable-endpoint-slices", false, "Write EndpointSlices for mirror services, in addition to Endpoints")
 
 	flags.ConfigureAndParse(cmd, args)
 	linkName := cmd.Arg(0)
@@ -59,13 +83,19 @@ func Main(args []string) {
 	}
 
 	ctx := context.Background()
+	resources := []controllerK8s.APIResource{controllerK8s.NS, controllerK8s.Svc, controllerK8s.Endpoint}
+	if *enableEndpointSlices {
+		if err := k8s.EndpointSliceAccess(ctx, k8sAPI); err != nil {
+			log.Fatalf("Failed to start with EndpointSlices enabled: %s", err)
+		}
+		// nodes are needed to compute the topology hints of the slices
+		resources = append(resources, controllerK8s.ES, controllerK8s.Node)
+	}
 	controllerK8sAPI, err := controllerK8s.InitializeAPI(
 		ctx,
 		*kubeConfigPath,
 		false,
-		controllerK8s.NS,
-		controllerK8s.Svc,
-		controllerK8s.Endpoint,
+		resources...,
 	)
 	if err != nil {
 		log.Fatalf("Failed to initialize K8s API: %s", err)
@@ -76,6 +106,12 @@ func Main(args []string) {
 	metrics := servicemirror.NewProbeMetricVecs()
 	go admin.StartServer(*metricsAddr)
 
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: k8sAPI.CoreV1().Events(*namespace),
+	})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: componentName})
+
 	controllerK8sAPI.Sync(nil)
 
 main:
@@ -87,12 +123,51 @@ main:
 		}
 		results := linkWatch.ResultChan()
 
-		// Each time the link resource is updated, reload the config and restart the
-		// cluster watcher.
+		// Each time the link resource or its credentials secret is updated,
+		// reload the config and restart the cluster watcher.
 		for {
 			select {
 			case <-stop:
 				break main
+			case <-credsRetry:
+				credsRetry = nil
+				if currentLink != nil {
+					watchCredentials(ctx, *currentLink, *namespace, k8sAPI)
+				}
+			case event, ok := <-credsEvents():
+				if !ok {
+					log.Info("Credentials secret watch terminated; restarting watch")
+					credsWatch = nil
+					if currentLink != nil {
+						watchCredentials(ctx, *currentLink, *namespace, k8sAPI)
+					}
+					continue
+				}
+				secret, ok := event.Object.(*corev1.Secret)
+				if !ok || currentLink == nil || secret.Name != currentLink.ClusterCredentialsSecret {
+					continue
+				}
+				switch event.Type {
+				case watch.Added, watch.Modified:
+					creds, err := sm.ParseRemoteClusterSecret(secret)
+					if err != nil {
+						log.Errorf("Failed to parse credentials secret %s: %s", secret.Name, err)
+						continue
+					}
+					if bytes.Equal(creds, currentCreds) {
+						continue
+					}
+					log.Infof("Credentials secret %s changed; restarting cluster watcher", secret.Name)
+					err = restartClusterWatcher(ctx, *currentLink, *namespace, creds, controllerK8sAPI, *requeueLimit, *repairPeriod, *enableEndpointSlices, metrics, recorder)
+					if err != nil {
+						log.Error(err)
+						continue
+					}
+					recorder.Eventf(currentLink.ObjectReference(), corev1.EventTypeNormal, "CredentialsReloaded", "Reloaded credentials from secret %s", secret.Name)
+				case watch.Deleted:
+					log.Warnf("Credentials secret %s deleted", secret.Name)
+					recorder.Eventf(currentLink.ObjectReference(), corev1.EventTypeWarning, "CredentialsDeleted", "Credentials secret %s was deleted", secret.Name)
+				}
 			case event, ok := <-results:
 				if !ok {
 					log.Info("Link watch terminated; restarting watch")
@@ -109,11 +184,13 @@ main:
 								continue
 							}
 							log.Infof("Got updated link %s: %+v", linkName, link)
+							currentLink = &link
+							watchCredentials(ctx, link, *namespace, k8sAPI)
 							creds, err := loadCredentials(ctx, link, *namespace, k8sAPI)
 							if err != nil {
 								log.Errorf("Failed to load remote cluster credentials: %s", err)
 							}
-							err = restartClusterWatcher(ctx, link, *namespace, creds, controllerK8sAPI, *requeueLimit, *repairPeriod, metrics)
+							err = restartClusterWatcher(ctx, link, *namespace, creds, controllerK8sAPI, *requeueLimit, *repairPeriod, *enableEndpointSlices, metrics, recorder)
 							if err != nil {
 								// failed to restart cluster watcher; give a bit of slack
 								// and restart the link watch to give it another try
@@ -123,6 +200,9 @@ main:
 							}
 						case watch.Deleted:
 							log.Infof("Link %s deleted", linkName)
+							stopWatchingCredentials()
+							currentLink = nil
+							currentCreds = nil
 							if clusterWatcher != nil {
 								clusterWatcher.Stop(false)
 								clusterWatcher = nil
@@ -153,6 +233,44 @@ func loadCredentials(ctx context.Context, link multicluster.Link, namespace stri
 	return sm.ParseRemoteClusterSecret(secret)
 }
 
+// watchCredentials (re)starts the watch on the credentials secret referenced
+// by the given link. When the watch can't be started, it's retried with an
+// exponential backoff.
+func watchCredentials(ctx context.Context, link multicluster.Link, namespace string, k8sAPI *k8s.KubernetesAPI) {
+	stopWatchingCredentials()
+	w, err := k8sAPI.Interface.CoreV1().Secrets(namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", link.ClusterCredentialsSecret).String(),
+	})
+	if err != nil {
+		log.Errorf("Failed to watch credentials secret %s: %s; retrying in %s", link.ClusterCredentialsSecret, err, credsRetryBackoff)
+		credsRetry = time.After(credsRetryBackoff)
+		credsRetryBackoff *= 2
+		if credsRetryBackoff > credsWatchRetryMax {
+			credsRetryBackoff = credsWatchRetryMax
+		}
+		return
+	}
+	credsWatch = w
+	credsRetryBackoff = credsWatchRetryMin
+}
+
+func stopWatchingCredentials() {
+	credsRetry = nil
+	if credsWatch != nil {
+		credsWatch.Stop()
+		credsWatch = nil
+	}
+}
+
+// credsEvents returns the result channel of the credentials secret watch, or
+// nil (which blocks forever in a select) when there is no such watch.
+func credsEvents() <-chan watch.Event {
+	if credsWatch == nil {
+		return nil
+	}
+	return credsWatch.ResultChan()
+}
+
 func restartClusterWatcher(
 	ctx context.Context,
 	link multicluster.Link,
@@ -161,7 +279,9 @@ func restartClusterWatcher(
 	controllerK8sAPI *controllerK8s.API,
 	requeueLimit int,
 	repairPeriod time.Duration,
+	enableEndpointSlices bool,
 	metrics servicemirror.ProbeMetricVecs,
+	recorder record.EventRecorder,
 ) error {
 	if clusterWatcher != nil {
 		clusterWatcher.Stop(false)
@@ -183,6 +303,8 @@ func restartClusterWatcher(
 		&link,
 		requeueLimit,
 		repairPeriod,
+		recorder,
+		enableEndpointSlices,
 	)
 	if err != nil {
 		return fmt.Errorf("Unable to create cluster watcher: %s", err)
@@ -199,5 +321,7 @@ func restartClusterWatcher(
 	}
 	probeWorker = servicemirror.NewProbeWorker(fmt.Sprintf("probe-gateway-%s", link.TargetClusterName), &link.ProbeSpec, workerMetrics, link.TargetClusterName)
 	probeWorker.Start()
+
+	currentCreds = creds
 	return nil
 }
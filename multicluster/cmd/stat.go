@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/linkerd/linkerd2/cli/table"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	vizCmd "github.com/linkerd/linkerd2/viz/cmd"
+	"github.com/linkerd/linkerd2/viz/metrics-api/client"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/spf13/cobra"
+)
+
+type (
+	statOptions struct {
+		clusterName   string
+		fromNamespace string
+		byService     bool
+		byWorkload    bool
+		timeWindow    string
+	}
+)
+
+func newStatCommand() *cobra.Command {
+
+	opts := statOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "stat",
+		Short: "Display traffic stats for the requests sent to target clusters",
+		Long: `Display traffic stats for the requests sent to target clusters.
+
+The stats are computed from the requests sent by the meshed workloads of this
+cluster to the mirror services of each link, and are aggregated per target
+cluster by default.`,
+		Example: `  # Traffic sent to each target cluster
+  linkerd multicluster stat
+
+  # Traffic sent to each mirrored service of the east cluster
+  linkerd multicluster stat --cluster-name east --by-service
+
+  # Traffic sent by each deployment of the emojivoto namespace to every mirrored service
+  linkerd multicluster stat --namespace emojivoto --by-service --by-workload`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &pb.MulticlusterStatsRequest{
+				RemoteClusterName: opts.clusterName,
+				FromNamespace:     opts.fromNamespace,
+				TimeWindow:        opts.timeWindow,
+				ByService:         opts.byService,
+				ByWorkload:        opts.byWorkload,
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			vizNs, err := k8sAPI.GetNamespaceWithExtensionLabel(ctx, vizCmd.ExtensionName)
+			if err != nil {
+				return fmt.Errorf("make sure the linkerd-viz extension is installed, using 'linkerd viz install' (%s)", err)
+			}
+
+			client, err := client.NewExternalClient(ctx, vizNs.Name, k8sAPI)
+			if err != nil {
+				return err
+			}
+
+			resp, err := requestMulticlusterStatsFromAPI(client, req)
+			if err != nil {
+				fmt.Fprint(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+
+			renderMulticlusterStats(resp.GetOk().MulticlusterStatsTable.Rows, opts, stdout)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.clusterName, "cluster-name", "", "Only display the traffic sent to this target cluster")
+	cmd.Flags().StringVarP(&opts.fromNamespace, "namespace", "n", "", "Only display the traffic sent from this namespace")
+	cmd.Flags().BoolVar(&opts.byService, "by-service", false, "Break down the stats by mirrored service")
+	cmd.Flags().BoolVar(&opts.byWorkload, "by-workload", false, "Break down the stats by source deployment")
+	cmd.Flags().StringVarP(&opts.timeWindow, "time-window", "t", "1m", "Time window (for example: \"15s\", \"1m\", \"10m\", \"1h\"). Needs to be at least 15s.")
+
+	return cmd
+}
+
+func requestMulticlusterStatsFromAPI(client pb.ApiClient, req *pb.MulticlusterStatsRequest) (*pb.MulticlusterStatsResponse, error) {
+	resp, err := client.MulticlusterStats(context.Background(), req)
+	if err != nil {
+		return nil, fmt.Errorf("MulticlusterStats API error: %v", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, fmt.Errorf("MulticlusterStats API response error: %v", e.Error)
+	}
+	return resp, nil
+}
+
+func renderMulticlusterStats(rows []*pb.MulticlusterStatsTable_Row, opts statOptions, w io.Writer) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No traffic found.")
+		return
+	}
+
+	t := buildMulticlusterStatsTable(opts)
+	for _, row := range rows {
+		row := row // Copy to satisfy golint.
+		t.Data = append(t.Data, multiclusterStatsRowToTableRow(row, opts))
+	}
+	t.Render(w)
+}
+
+func buildMulticlusterStatsTable(opts statOptions) table.Table {
+	columns := []table.Column{
+		{Header: clusterNameHeader, Width: 7, Flexible: true, LeftAlign: true},
+	}
+	if opts.byService {
+		columns = append(columns, table.Column{Header: "SERVICE", Width: 7, Flexible: true, LeftAlign: true})
+	}
+	if opts.byWorkload {
+		columns = append(columns, table.Column{Header: "FROM", Width: 4, Flexible: true, LeftAlign: true})
+	}
+	columns = append(columns,
+		table.Column{Header: "SUCCESS", Width: 7},
+		table.Column{Header: "RPS", Width: 9},
+		table.Column{Header: latencyP50Header, Width: 11},
+		table.Column{Header: latencyP95Header, Width: 11},
+		table.Column{Header: latencyP99Header, Width: 11},
+	)
+	return table.NewTable(columns, []table.Row{})
+}
+
+func multiclusterStatsRowToTableRow(row *pb.MulticlusterStatsTable_Row, opts statOptions) []string {
+	tableRow := []string{row.ClusterName}
+	if opts.byService {
+		tableRow = append(tableRow, fmt.Sprintf("%s/%s", row.ServiceNamespace, row.ServiceName))
+	}
+	if opts.byWorkload {
+		tableRow = append(tableRow, fmt.Sprintf("%s/deploy/%s", row.FromNamespace, row.FromDeployment))
+	}
+
+	stats := row.GetStats()
+	return append(tableRow,
+		fmt.Sprintf("%.2f%%", getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount())*100),
+		fmt.Sprintf("%.1frps", getRequestRate(stats.GetSuccessCount(), stats.GetFailureCount(), row.TimeWindow)),
+		fmt.Sprintf("%dms", stats.GetLatencyMsP50()),
+		fmt.Sprintf("%dms", stats.GetLatencyMsP95()),
+		fmt.Sprintf("%dms", stats.GetLatencyMsP99()),
+	)
+}
+
+// getRequestRate calculates request rate from Public API BasicStats.
+func getRequestRate(success, failure uint64, timeWindow string) float64 {
+	windowLength, err := time.ParseDuration(timeWindow)
+	if err != nil {
+		return 0.0
+	}
+	return float64(success+failure) / windowLength.Seconds()
+}
+
+// getSuccessRate calculates success rate from Public API BasicStats.
+func getSuccessRate(success, failure uint64) float64 {
+	if success+failure == 0 {
+		return 0.0
+	}
+	return float64(success) / float64(success+failure)
+}
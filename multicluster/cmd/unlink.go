@@ -52,7 +52,7 @@ func newUnlinkCommand() *cobra.Command {
 				return err
 			}
 
-			_, err = mc.GetLink(cmd.Context(), k.DynamicClient, opts.namespace, opts.clusterName)
+			l, err := mc.GetLink(cmd.Context(), k.DynamicClient, opts.namespace, opts.clusterName)
 			if err != nil {
 				return err
 			}
@@ -72,6 +72,10 @@ func newUnlinkCommand() *cobra.Command {
 				role, roleBinding, serviceAccount, serviceMirror,
 			}
 
+			if opts.dryRun {
+				return unlinkDryRun(cmd.Context(), k, &l, resources, stdout)
+			}
+
 			selector := fmt.Sprintf("%s=%s,%s=%s",
 				k8s.MirroredResourceLabel, "true",
 				k8s.RemoteClusterNameLabel, opts.clusterName,
@@ -96,6 +100,7 @@ func newUnlinkCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.namespace, "namespace", defaultMulticlusterNamespace, "The namespace for the service account")
 	cmd.Flags().StringVar(&opts.clusterName, "cluster-name", "", "Cluster name")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Instead of outputting resources, show what would be deleted and what would be left behind")
 
 	pkgcmd.ConfigureNamespaceFlagCompletion(
 		cmd, []string{"namespace"},
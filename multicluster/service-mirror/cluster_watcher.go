@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -39,6 +41,9 @@ type (
 		eventsQueue            workqueue.RateLimitingInterface
 		requeueLimit           int
 		repairPeriod           time.Duration
+		recorder               record.EventRecorder
+		bearerToken            string
+		enableEndpointSlices   bool
 	}
 
 	// RemoteServiceCreated is generated whenever a remote service is created Observing
@@ -126,24 +131,14 @@ func NewRemoteClusterServiceWatcher(
 	link *multicluster.Link,
 	requeueLimit int,
 	repairPeriod time.Duration,
-
+	recorder record.EventRecorder,
+	enableEndpointSlices bool,
 ) (*RemoteClusterServiceWatcher, error) {
-	remoteAPI, err := k8s.InitializeAPIForConfig(ctx, cfg, false, k8s.Svc)
-	if err != nil {
-		return nil, fmt.Errorf("cannot initialize api for target cluster %s: %s", clusterName, err)
-	}
-	_, err = remoteAPI.Client.Discovery().ServerVersion()
-	if err != nil {
-		return nil, fmt.Errorf("cannot connect to api for target cluster %s: %s", clusterName, err)
-	}
-
-	stopper := make(chan struct{})
-	return &RemoteClusterServiceWatcher{
+	rcsw := &RemoteClusterServiceWatcher{
 		serviceMirrorNamespace: serviceMirrorNamespace,
 		link:                   link,
-		remoteAPIClient:        remoteAPI,
 		localAPIClient:         localAPI,
-		stopper:                stopper,
+		stopper:                make(chan struct{}),
 		log: logging.WithFields(logging.Fields{
 			"cluster":    clusterName,
 			"apiAddress": cfg.Host,
@@ -151,24 +146,58 @@ func NewRemoteClusterServiceWatcher(
 		eventsQueue:  workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
 		requeueLimit: requeueLimit,
 		repairPeriod: repairPeriod,
-	}, nil
+		recorder:     recorder,
+		bearerToken:  cfg.BearerToken,
+
+		enableEndpointSlices: enableEndpointSlices,
+	}
+
+	remoteAPI, err := k8s.InitializeAPIForConfig(ctx, withAuthFailureDetection(cfg, rcsw.onRemoteAuthFailure), false, k8s.Svc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize api for target cluster %s: %s", clusterName, err)
+	}
+	_, err = remoteAPI.Client.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to api for target cluster %s: %s", clusterName, err)
+	}
+	rcsw.remoteAPIClient = remoteAPI
+
+	return rcsw, nil
 }
 
 func (rcsw *RemoteClusterServiceWatcher) mirroredResourceName(remoteName string) string {
-	return fmt.Sprintf("%s-%s", remoteName, rcsw.link.TargetClusterName)
+	return mirroredResourceName(rcsw.link, remoteName)
 }
 
 func (rcsw *RemoteClusterServiceWatcher) originalResourceName(mirroredName string) string {
-	return strings.TrimSuffix(mirroredName, fmt.Sprintf("-%s", rcsw.link.TargetClusterName))
+	return originalResourceName(rcsw.link, mirroredName)
 }
 
 func (rcsw *RemoteClusterServiceWatcher) getMirroredServiceLabels() map[string]string {
+	return mirroredResourceLabels(rcsw.link)
+}
+
+func mirroredResourceName(link *multicluster.Link, remoteName string) string {
+	return fmt.Sprintf("%s-%s", remoteName, link.TargetClusterName)
+}
+
+func originalResourceName(link *multicluster.Link, mirroredName string) string {
+	return strings.TrimSuffix(mirroredName, fmt.Sprintf("-%s", link.TargetClusterName))
+}
+
+func mirroredResourceLabels(link *multicluster.Link) map[string]string {
 	return map[string]string{
 		consts.MirroredResourceLabel:  "true",
-		consts.RemoteClusterNameLabel: rcsw.link.TargetClusterName,
+		consts.RemoteClusterNameLabel: link.TargetClusterName,
 	}
 }
 
+// MirroredResourcesSelector selects the services and endpoints that the
+// service mirror controller of the given link has created.
+func MirroredResourcesSelector(link *multicluster.Link) labels.Selector {
+	return labels.Set(mirroredResourceLabels(link)).AsSelector()
+}
+
 func (rcsw *RemoteClusterServiceWatcher) getMirroredServiceAnnotations(remoteService *corev1.Service) map[string]string {
 	annotations := map[string]string{
 		consts.RemoteResourceVersionAnnotation: remoteService.ResourceVersion, // needed to detect real changes
@@ -216,7 +245,7 @@ func (rcsw *RemoteClusterServiceWatcher) mirrorNamespaceIfNecessary(ctx context.
 // on the service side of things. It all happens in the endpoints.
 func (rcsw *RemoteClusterServiceWatcher) getEndpointsPorts(service *corev1.Service) []corev1.EndpointPort {
 	var endpointsPorts []corev1.EndpointPort
-	for _, remotePort := range service.Spec.Ports {
+	for _, remotePort := range exportedServicePorts(service) {
 		endpointsPorts = append(endpointsPorts, corev1.EndpointPort{
 			Name:     remotePort.Name,
 			Protocol: remotePort.Protocol,
@@ -227,12 +256,7 @@ func (rcsw *RemoteClusterServiceWatcher) getEndpointsPorts(service *corev1.Servi
 }
 
 func (rcsw *RemoteClusterServiceWatcher) cleanupOrphanedServices(ctx context.Context) error {
-	matchLabels := map[string]string{
-		consts.MirroredResourceLabel:  "true",
-		consts.RemoteClusterNameLabel: rcsw.link.TargetClusterName,
-	}
-
-	servicesOnLocalCluster, err := rcsw.localAPIClient.Svc().Lister().List(labels.Set(matchLabels).AsSelector())
+	servicesOnLocalCluster, err := rcsw.localAPIClient.Svc().Lister().List(MirroredResourcesSelector(rcsw.link))
 	if err != nil {
 		innerErr := fmt.Errorf("failed to list services while cleaning up mirror services: %s", err)
 		if kerrors.IsNotFound(err) {
@@ -244,7 +268,7 @@ func (rcsw *RemoteClusterServiceWatcher) cleanupOrphanedServices(ctx context.Con
 
 	var errors []error
 	for _, srv := range servicesOnLocalCluster {
-		_, err := rcsw.remoteAPIClient.Svc().Lister().Services(srv.Namespace).Get(rcsw.originalResourceName(srv.Name))
+		_, err := rcsw.remoteAPIClient.Svc().Lister().Services(rcsw.link.RemoteNamespace(srv.Namespace)).Get(rcsw.originalResourceName(srv.Name))
 		if err != nil {
 			if kerrors.IsNotFound(err) {
 				// service does not exist anymore. Need to delete
@@ -269,11 +293,10 @@ func (rcsw *RemoteClusterServiceWatcher) cleanupOrphanedServices(ctx context.Con
 
 // Whenever we stop watching a cluster, we need to cleanup everything that we have
 // created. This piece of code is responsible for doing just that. It takes care of
-// services, endpoints and namespaces (if needed)
+// services, endpoints and namespaces (if needed). EndpointSlices are owned by
+// their service and get garbage collected along with it.
 func (rcsw *RemoteClusterServiceWatcher) cleanupMirroredResources(ctx context.Context) error {
-	matchLabels := rcsw.getMirroredServiceLabels()
-
-	services, err := rcsw.localAPIClient.Svc().Lister().List(labels.Set(matchLabels).AsSelector())
+	services, err := rcsw.localAPIClient.Svc().Lister().List(MirroredResourcesSelector(rcsw.link))
 	if err != nil {
 		innerErr := fmt.Errorf("could not retrieve mirrored services that need cleaning up: %s", err)
 		if kerrors.IsNotFound(err) {
@@ -295,7 +318,7 @@ func (rcsw *RemoteClusterServiceWatcher) cleanupMirroredResources(ctx context.Co
 		}
 	}
 
-	endpoints, err := rcsw.localAPIClient.Endpoint().Lister().List(labels.Set(matchLabels).AsSelector())
+	endpoints, err := rcsw.localAPIClient.Endpoint().Lister().List(MirroredResourcesSelector(rcsw.link))
 	if err != nil {
 		innerErr := fmt.Errorf("could not retrieve Endpoints that need cleaning up: %s", err)
 		if kerrors.IsNotFound(err) {
@@ -324,11 +347,12 @@ func (rcsw *RemoteClusterServiceWatcher) cleanupMirroredResources(ctx context.Co
 // Deletes a locally mirrored service as it is not present on the remote cluster anymore
 func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceDeleted(ctx context.Context, ev *RemoteServiceDeleted) error {
 	localServiceName := rcsw.mirroredResourceName(ev.Name)
-	rcsw.log.Infof("Deleting mirrored service %s/%s", ev.Namespace, localServiceName)
+	localNamespace := rcsw.link.LocalNamespace(ev.Namespace)
+	rcsw.log.Infof("Deleting mirrored service %s/%s", localNamespace, localServiceName)
 	var errors []error
-	if err := rcsw.localAPIClient.Client.CoreV1().Services(ev.Namespace).Delete(ctx, localServiceName, metav1.DeleteOptions{}); err != nil {
+	if err := rcsw.localAPIClient.Client.CoreV1().Services(localNamespace).Delete(ctx, localServiceName, metav1.DeleteOptions{}); err != nil {
 		if !kerrors.IsNotFound(err) {
-			errors = append(errors, fmt.Errorf("could not delete Service: %s/%s: %s", ev.Namespace, localServiceName, err))
+			errors = append(errors, fmt.Errorf("could not delete Service: %s/%s: %s", localNamespace, localServiceName, err))
 		}
 	}
 
@@ -336,7 +360,7 @@ func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceDeleted(ctx context.
 		return RetryableError{errors}
 	}
 
-	rcsw.log.Infof("Successfully deleted Service: %s/%s", ev.Namespace, localServiceName)
+	rcsw.log.Infof("Successfully deleted Service: %s/%s", localNamespace, localServiceName)
 	return nil
 }
 
@@ -361,19 +385,20 @@ func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceUpdated(ctx context.
 		copiedEndpoints.Annotations = make(map[string]string)
 	}
 	copiedEndpoints.Annotations[consts.RemoteGatewayIdentity] = rcsw.link.GatewayIdentity
+	copiedEndpoints.Labels = rcsw.withSkipMirror(copiedEndpoints.Labels)
 
-	if _, err := rcsw.localAPIClient.Client.CoreV1().Endpoints(copiedEndpoints.Namespace).Update(ctx, copiedEndpoints, metav1.UpdateOptions{}); err != nil {
+	if _, err := rcsw.localAPIClient.Client.CoreV1().Endpoints(copiedEndpoints.Namespace).Update(ctx, rcsw.endpointsToWrite(copiedEndpoints), metav1.UpdateOptions{}); err != nil {
 		return RetryableError{[]error{err}}
 	}
 
 	ev.localService.Labels = rcsw.getMirroredServiceLabels()
 	ev.localService.Annotations = rcsw.getMirroredServiceAnnotations(ev.remoteUpdate)
-	ev.localService.Spec.Ports = remapRemoteServicePorts(ev.remoteUpdate.Spec.Ports)
+	ev.localService.Spec.Ports = remapRemoteServicePorts(exportedServicePorts(ev.remoteUpdate))
 
 	if _, err := rcsw.localAPIClient.Client.CoreV1().Services(ev.localService.Namespace).Update(ctx, ev.localService, metav1.UpdateOptions{}); err != nil {
 		return RetryableError{[]error{err}}
 	}
-	return nil
+	return rcsw.mirrorEndpointSlices(ctx, ev.localService, copiedEndpoints)
 }
 
 func remapRemoteServicePorts(ports []corev1.ServicePort) []corev1.ServicePort {
@@ -391,6 +416,31 @@ func remapRemoteServicePorts(ports []corev1.ServicePort) []corev1.ServicePort {
 	return newPorts
 }
 
+// exportedServicePorts returns the ports of a remote service that should be
+// mirrored. If the service carries the exported ports annotation, only the
+// ports whose name or number is listed there are returned.
+func exportedServicePorts(service *corev1.Service) []corev1.ServicePort {
+	value, ok := service.GetAnnotations()[consts.ExportedPortsAnnotation]
+	if !ok {
+		return service.Spec.Ports
+	}
+
+	exported := make(map[string]struct{})
+	for _, port := range strings.Split(value, ",") {
+		exported[strings.TrimSpace(port)] = struct{}{}
+	}
+
+	var ports []corev1.ServicePort
+	for _, port := range service.Spec.Ports {
+		_, byName := exported[port.Name]
+		_, byNumber := exported[strconv.Itoa(int(port.Port))]
+		if (port.Name != "" && byName) || byNumber {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
 func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceCreated(ctx context.Context, ev *RemoteServiceCreated) error {
 	gatewayAddresses, err := rcsw.resolveGatewayAddress()
 	if err != nil {
@@ -400,31 +450,32 @@ func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceCreated(ctx context.
 	remoteService := ev.service.DeepCopy()
 	serviceInfo := fmt.Sprintf("%s/%s", remoteService.Namespace, remoteService.Name)
 	localServiceName := rcsw.mirroredResourceName(remoteService.Name)
+	localNamespace := rcsw.link.LocalNamespace(remoteService.Namespace)
 
-	if err := rcsw.mirrorNamespaceIfNecessary(ctx, remoteService.Namespace); err != nil {
+	if err := rcsw.mirrorNamespaceIfNecessary(ctx, localNamespace); err != nil {
 		return err
 	}
 
 	serviceToCreate := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        localServiceName,
-			Namespace:   remoteService.Namespace,
+			Namespace:   localNamespace,
 			Annotations: rcsw.getMirroredServiceAnnotations(remoteService),
 			Labels:      rcsw.getMirroredServiceLabels(),
 		},
 		Spec: corev1.ServiceSpec{
-			Ports: remapRemoteServicePorts(remoteService.Spec.Ports),
+			Ports: remapRemoteServicePorts(exportedServicePorts(remoteService)),
 		},
 	}
 
 	endpointsToCreate := &corev1.Endpoints{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      localServiceName,
-			Namespace: ev.service.Namespace,
-			Labels: map[string]string{
+			Namespace: localNamespace,
+			Labels: rcsw.withSkipMirror(map[string]string{
 				consts.MirroredResourceLabel:  "true",
 				consts.RemoteClusterNameLabel: rcsw.link.TargetClusterName,
-			},
+			}),
 			Annotations: map[string]string{
 				consts.RemoteServiceFqName: fmt.Sprintf("%s.%s.svc.%s", remoteService.Name, remoteService.Namespace, rcsw.link.TargetClusterDomain),
 			},
@@ -449,30 +500,64 @@ func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceCreated(ctx context.
 	}
 
 	rcsw.log.Infof("Creating a new service mirror for %s", serviceInfo)
-	if _, err := rcsw.localAPIClient.Client.CoreV1().Services(remoteService.Namespace).Create(ctx, serviceToCreate, metav1.CreateOptions{}); err != nil {
+	createdService, err := rcsw.localAPIClient.Client.CoreV1().Services(localNamespace).Create(ctx, serviceToCreate, metav1.CreateOptions{})
+	if err != nil {
 		if !kerrors.IsAlreadyExists(err) {
 			// we might have created it during earlier attempt, if that is not the case, we retry
 			return RetryableError{[]error{err}}
 		}
+		if createdService, err = rcsw.localAPIClient.Client.CoreV1().Services(localNamespace).Get(ctx, localServiceName, metav1.GetOptions{}); err != nil {
+			return RetryableError{[]error{err}}
+		}
 	}
 
 	rcsw.log.Infof("Creating a new Endpoints for %s", serviceInfo)
-	if _, err := rcsw.localAPIClient.Client.CoreV1().Endpoints(ev.service.Namespace).Create(ctx, endpointsToCreate, metav1.CreateOptions{}); err != nil {
+	if _, err := rcsw.localAPIClient.Client.CoreV1().Endpoints(localNamespace).Create(ctx, rcsw.endpointsToWrite(endpointsToCreate), metav1.CreateOptions{}); err != nil {
 		// we clean up after ourselves
-		rcsw.localAPIClient.Client.CoreV1().Services(ev.service.Namespace).Delete(ctx, localServiceName, metav1.DeleteOptions{})
+		rcsw.localAPIClient.Client.CoreV1().Services(localNamespace).Delete(ctx, localServiceName, metav1.DeleteOptions{})
 		// and retry
 		return RetryableError{[]error{err}}
 	}
-	return nil
+	return rcsw.mirrorEndpointSlices(ctx, createdService, endpointsToCreate)
 }
 
 func (rcsw *RemoteClusterServiceWatcher) isExportedService(service *corev1.Service) bool {
-	selector, err := metav1.LabelSelectorAsSelector(&rcsw.link.Selector)
+	matches, err := matchesLinkSelector(rcsw.link, service)
 	if err != nil {
 		rcsw.log.Errorf("Invalid service selector: %s", err)
 		return false
 	}
-	return selector.Matches(labels.Set(service.Labels))
+	if !matches {
+		return false
+	}
+	if hasNoExportedPorts(service) {
+		rcsw.log.Warnf("None of the ports of service %s/%s match its %s annotation", service.Namespace, service.Name, consts.ExportedPortsAnnotation)
+		return false
+	}
+	return true
+}
+
+func isExportedService(link *multicluster.Link, service *corev1.Service) (bool, error) {
+	matches, err := matchesLinkSelector(link, service)
+	if err != nil || !matches {
+		return false, err
+	}
+	return !hasNoExportedPorts(service), nil
+}
+
+func matchesLinkSelector(link *multicluster.Link, service *corev1.Service) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&link.Selector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(service.Labels)), nil
+}
+
+// hasNoExportedPorts returns true for a service whose exported ports
+// annotation does not match any of its ports, which has nothing left to mirror
+func hasNoExportedPorts(service *corev1.Service) bool {
+	_, ok := service.Annotations[consts.ExportedPortsAnnotation]
+	return ok && len(exportedServicePorts(service)) == 0
 }
 
 // this method is common to both CREATE and UPDATE because if we have been
@@ -480,9 +565,10 @@ func (rcsw *RemoteClusterServiceWatcher) isExportedService(service *corev1.Servi
 // observed before is simply a case of UPDATE
 func (rcsw *RemoteClusterServiceWatcher) createOrUpdateService(service *corev1.Service) error {
 	localName := rcsw.mirroredResourceName(service.Name)
+	localNamespace := rcsw.link.LocalNamespace(service.Namespace)
 
 	if rcsw.isExportedService(service) {
-		localService, err := rcsw.localAPIClient.Svc().Lister().Services(service.Namespace).Get(localName)
+		localService, err := rcsw.localAPIClient.Svc().Lister().Services(localNamespace).Get(localName)
 		if err != nil {
 			if kerrors.IsNotFound(err) {
 				rcsw.eventsQueue.Add(&RemoteServiceCreated{
@@ -495,7 +581,7 @@ func (rcsw *RemoteClusterServiceWatcher) createOrUpdateService(service *corev1.S
 		// if we have the local service present, we need to issue an update
 		lastMirroredRemoteVersion, ok := localService.Annotations[consts.RemoteResourceVersionAnnotation]
 		if ok && lastMirroredRemoteVersion != service.ResourceVersion {
-			endpoints, err := rcsw.localAPIClient.Endpoint().Lister().Endpoints(service.Namespace).Get(localName)
+			endpoints, err := rcsw.localAPIClient.Endpoint().Lister().Endpoints(localNamespace).Get(localName)
 			if err == nil {
 				rcsw.eventsQueue.Add(&RemoteServiceUpdated{
 					localService:   localService,
@@ -508,7 +594,7 @@ func (rcsw *RemoteClusterServiceWatcher) createOrUpdateService(service *corev1.S
 		}
 		return nil
 	}
-	localSvc, err := rcsw.localAPIClient.Svc().Lister().Services(service.Namespace).Get(localName)
+	localSvc, err := rcsw.localAPIClient.Svc().Lister().Services(localNamespace).Get(localName)
 	if err == nil {
 		if localSvc.Labels != nil {
 			_, isMirroredRes := localSvc.Labels[consts.MirroredResourceLabel]
@@ -525,12 +611,7 @@ func (rcsw *RemoteClusterServiceWatcher) createOrUpdateService(service *corev1.S
 }
 
 func (rcsw *RemoteClusterServiceWatcher) getMirrorServices() ([]*corev1.Service, error) {
-	matchLabels := map[string]string{
-		consts.MirroredResourceLabel:  "true",
-		consts.RemoteClusterNameLabel: rcsw.link.TargetClusterName,
-	}
-
-	services, err := rcsw.localAPIClient.Svc().Lister().List(labels.Set(matchLabels).AsSelector())
+	services, err := rcsw.localAPIClient.Svc().Lister().List(MirroredResourcesSelector(rcsw.link))
 	if err != nil {
 		return nil, err
 	}
@@ -628,6 +709,9 @@ func (rcsw *RemoteClusterServiceWatcher) processEvents(ctx context.Context) {
 // Start starts watching the remote cluster
 func (rcsw *RemoteClusterServiceWatcher) Start(ctx context.Context) error {
 	rcsw.remoteAPIClient.Sync(rcsw.stopper)
+	if err := rcsw.cleanupEndpointSlices(ctx); err != nil {
+		rcsw.log.Errorf("Failed to clean up the mirror EndpointSlices: %s", err)
+	}
 	rcsw.eventsQueue.Add(&OrphanedServicesGcTriggered{})
 	rcsw.remoteAPIClient.Svc().Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
@@ -661,6 +745,7 @@ func (rcsw *RemoteClusterServiceWatcher) Start(ctx context.Context) error {
 	// mirror endpoints.
 	ev := RepairEndpoints{}
 	rcsw.eventsQueue.Add(&ev)
+	rcsw.checkCredentialsExpiry()
 
 	go func() {
 		ticker := time.NewTicker(rcsw.repairPeriod)
@@ -669,6 +754,7 @@ func (rcsw *RemoteClusterServiceWatcher) Start(ctx context.Context) error {
 			case <-ticker.C:
 				ev := RepairEndpoints{}
 				rcsw.eventsQueue.Add(&ev)
+				rcsw.checkCredentialsExpiry()
 			case <-rcsw.stopper:
 				return
 			}
@@ -726,9 +812,9 @@ func (rcsw *RemoteClusterServiceWatcher) repairEndpoints(ctx context.Context) er
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      gatewayMirrorName,
 			Namespace: rcsw.serviceMirrorNamespace,
-			Labels: map[string]string{
+			Labels: rcsw.withSkipMirror(map[string]string{
 				consts.RemoteClusterNameLabel: rcsw.link.TargetClusterName,
-			},
+			}),
 			Annotations: map[string]string{
 				consts.RemoteGatewayIdentity: rcsw.link.GatewayIdentity,
 			},
@@ -747,9 +833,16 @@ func (rcsw *RemoteClusterServiceWatcher) repairEndpoints(ctx context.Context) er
 		},
 	}
 
-	err = rcsw.createOrUpdateEndpoints(ctx, gatewayMirrorEndpoints)
+	err = rcsw.createOrUpdateEndpoints(ctx, rcsw.endpointsToWrite(gatewayMirrorEndpoints))
 	if err != nil {
 		rcsw.log.Errorf("Failed to create/update gateway mirror endpoints: %s", err)
+	} else if rcsw.enableEndpointSlices {
+		gatewayMirror, err := rcsw.localAPIClient.Svc().Lister().Services(rcsw.serviceMirrorNamespace).Get(gatewayMirrorName)
+		if err != nil {
+			rcsw.log.Errorf("Could not get gateway mirror service: %s", err)
+		} else if err := rcsw.mirrorEndpointSlices(ctx, gatewayMirror, gatewayMirrorEndpoints); err != nil {
+			rcsw.log.Errorf("Failed to create/update gateway mirror EndpointSlices: %s", err)
+		}
 	}
 
 	// Repair mirror service endpoints.
@@ -778,6 +871,7 @@ func (rcsw *RemoteClusterServiceWatcher) repairEndpoints(ctx context.Context) er
 			updatedEndpoints.Annotations = make(map[string]string)
 		}
 		updatedEndpoints.Annotations[consts.RemoteGatewayIdentity] = rcsw.link.GatewayIdentity
+		updatedEndpoints.Labels = rcsw.withSkipMirror(updatedEndpoints.Labels)
 
 		_, err = rcsw.localAPIClient.Client.CoreV1().Services(updatedService.Namespace).Update(ctx, updatedService, metav1.UpdateOptions{})
 		if err != nil {
@@ -785,9 +879,14 @@ func (rcsw *RemoteClusterServiceWatcher) repairEndpoints(ctx context.Context) er
 			continue
 		}
 
-		_, err = rcsw.localAPIClient.Client.CoreV1().Endpoints(updatedService.Namespace).Update(ctx, updatedEndpoints, metav1.UpdateOptions{})
+		_, err = rcsw.localAPIClient.Client.CoreV1().Endpoints(updatedService.Namespace).Update(ctx, rcsw.endpointsToWrite(updatedEndpoints), metav1.UpdateOptions{})
 		if err != nil {
 			rcsw.log.Error(err)
+			continue
+		}
+
+		if err := rcsw.mirrorEndpointSlices(ctx, svc, updatedEndpoints); err != nil {
+			rcsw.log.Error(err)
 		}
 	}
 
@@ -8,6 +8,7 @@ import (
 
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -17,7 +18,10 @@ type mirroringTestCase struct {
 	environment            *testEnvironment
 	expectedLocalServices  []*corev1.Service
 	expectedLocalEndpoints []*corev1.Endpoints
-	expectedEventsInQueue  []interface{}
+	// expectedLocalEndpointSlices are the only EndpointSlices expected in
+	// the namespaces they belong to
+	expectedLocalEndpointSlices []*discovery.EndpointSlice
+	expectedEventsInQueue       []interface{}
 }
 
 func (tc *mirroringTestCase) run(t *testing.T) {
@@ -68,6 +72,24 @@ func (tc *mirroringTestCase) run(t *testing.T) {
 			}
 		}
 
+		for _, expected := range tc.expectedLocalEndpointSlices {
+			slices, err := localAPI.Client.DiscoveryV1beta1().EndpointSlices(expected.Namespace).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(slices.Items) != len(tc.expectedLocalEndpointSlices) {
+				t.Fatalf("Was expecting %d EndpointSlices but found %v", len(tc.expectedLocalEndpointSlices), slices.Items)
+			}
+			actual, err := localAPI.Client.DiscoveryV1beta1().EndpointSlices(expected.Namespace).Get(context.Background(), expected.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Could not find EndpointSlice with name %s", expected.Name)
+			}
+
+			if err := diffEndpointSlices(expected, actual); err != nil {
+				t.Fatal(err)
+			}
+		}
+
 		expectedNumEvents := len(tc.expectedEventsInQueue)
 		actualNumEvents := q.Len()
 
@@ -122,6 +144,73 @@ func TestRemoteServiceCreatedMirroring(t *testing.T) {
 				}),
 			},
 		},
+		{
+			description: "create service and endpoints in the mapped namespace with only the exported ports",
+			environment: createExportedServiceWithNamespaceMappingAndPorts,
+			expectedLocalServices: []*corev1.Service{
+				withRemoteFqName(mirrorService(
+					"service-one-remote",
+					"local-ns1",
+					"111",
+					[]corev1.ServicePort{
+						{
+							Name:     "port1",
+							Protocol: "TCP",
+							Port:     555,
+						},
+						{
+							Name:     "port3",
+							Protocol: "TCP",
+							Port:     777,
+						},
+					}), "service-one.ns1.svc.cluster.local"),
+			},
+			expectedLocalEndpoints: []*corev1.Endpoints{
+				withEndpointsRemoteFqName(endpoints("service-one-remote", "local-ns1", "192.0.2.127", "gateway-identity", []corev1.EndpointPort{
+					{
+						Name:     "port1",
+						Port:     888,
+						Protocol: "TCP",
+					},
+					{
+						Name:     "port3",
+						Port:     888,
+						Protocol: "TCP",
+					},
+				}), "service-one.ns1.svc.cluster.local"),
+			},
+		},
+		{
+			description: "create service, endpoints and EndpointSlices hinted for every local zone",
+			environment: createExportedServiceWithEndpointSlices,
+			expectedLocalServices: []*corev1.Service{
+				mirrorService("service-one-remote", "ns1", "111", []corev1.ServicePort{
+					{
+						Name:     "port1",
+						Protocol: "TCP",
+						Port:     555,
+					},
+				}),
+			},
+			expectedLocalEndpoints: []*corev1.Endpoints{
+				withSkipMirror(endpoints("service-one-remote", "ns1", "192.0.2.127", "gateway-identity", []corev1.EndpointPort{
+					{
+						Name:     "port1",
+						Port:     888,
+						Protocol: "TCP",
+					},
+				})),
+			},
+			expectedLocalEndpointSlices: []*discovery.EndpointSlice{
+				endpointSlice("service-one-remote-ipv4-0", "ns1", "service-one-remote", "192.0.2.127", "gateway-identity", []string{"zone-a", "zone-b"}, []corev1.EndpointPort{
+					{
+						Name:     "port1",
+						Port:     888,
+						Protocol: "TCP",
+					},
+				}),
+			},
+		},
 	} {
 		tc := tt // pin
 		tc.run(t)
@@ -134,6 +223,10 @@ func TestRemoteServiceDeletedMirroring(t *testing.T) {
 			description: "deletes locally mirrored service",
 			environment: deleteMirrorService,
 		},
+		{
+			description: "deletes locally mirrored service in the mapped namespace",
+			environment: deleteMirrorServiceWithNamespaceMapping,
+		},
 	} {
 		tc := tt // pin
 		tc.run(t)
@@ -176,6 +269,37 @@ func TestRemoteServiceUpdatedMirroring(t *testing.T) {
 				}),
 			},
 		},
+		{
+			description: "updates endpoints and EndpointSlices, deleting stale slices",
+			environment: updateServiceWithEndpointSlices,
+			expectedLocalServices: []*corev1.Service{
+				mirrorService("test-service-remote", "test-namespace", "currentServiceResVersion", []corev1.ServicePort{
+					{
+						Name:     "port1",
+						Protocol: "TCP",
+						Port:     111,
+					},
+				}),
+			},
+			expectedLocalEndpoints: []*corev1.Endpoints{
+				withSkipMirror(endpoints("test-service-remote", "test-namespace", "192.0.2.127", "gateway-identity", []corev1.EndpointPort{
+					{
+						Name:     "port1",
+						Port:     888,
+						Protocol: "TCP",
+					},
+				})),
+			},
+			expectedLocalEndpointSlices: []*discovery.EndpointSlice{
+				endpointSlice("test-service-remote-ipv4-0", "test-namespace", "test-service-remote", "192.0.2.127", "gateway-identity", nil, []corev1.EndpointPort{
+					{
+						Name:     "port1",
+						Port:     888,
+						Protocol: "TCP",
+					},
+				}),
+			},
+		},
 	} {
 		tc := tt // pin
 		tc.run(t)
@@ -207,6 +331,17 @@ func TestGcOrphanedServicesMirroring(t *testing.T) {
 				endpoints("test-service-1-remote", "test-namespace", "", "", nil),
 			},
 		},
+		{
+			description: "deletes mirrored resources in mapped namespaces that are no longer present on the remote cluster",
+			environment: gcTriggeredWithNamespaceMapping,
+			expectedLocalServices: []*corev1.Service{
+				mirrorService("test-service-1-remote", "local-namespace", "", nil),
+			},
+
+			expectedLocalEndpoints: []*corev1.Endpoints{
+				endpoints("test-service-1-remote", "local-namespace", "", "", nil),
+			},
+		},
 	} {
 		tc := tt // pin
 		tc.run(t)
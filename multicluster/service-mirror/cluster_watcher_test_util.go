@@ -14,6 +14,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/multicluster"
 	logging "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -36,10 +37,11 @@ var (
 )
 
 type testEnvironment struct {
-	events          []interface{}
-	remoteResources []string
-	localResources  []string
-	link            multicluster.Link
+	events               []interface{}
+	remoteResources      []string
+	localResources       []string
+	link                 multicluster.Link
+	enableEndpointSlices bool
 }
 
 func (te *testEnvironment) runEnvironment(watcherQueue workqueue.RateLimitingInterface) (*k8s.API, error) {
@@ -64,6 +66,8 @@ func (te *testEnvironment) runEnvironment(watcherQueue workqueue.RateLimitingInt
 		log:             logging.WithFields(logging.Fields{"cluster": clusterName}),
 		eventsQueue:     watcherQueue,
 		requeueLimit:    0,
+
+		enableEndpointSlices: te.enableEndpointSlices,
 	}
 
 	for _, ev := range te.events {
@@ -113,6 +117,44 @@ var createExportedService = &testEnvironment{
 	},
 }
 
+var createExportedServiceWithNamespaceMappingAndPorts = &testEnvironment{
+	events: []interface{}{
+		&RemoteServiceCreated{
+			service: withAnnotations(remoteService("service-one", "ns1", "111", map[string]string{
+				consts.DefaultExportedServiceSelector: "true",
+			}, []corev1.ServicePort{
+				{
+					Name:     "port1",
+					Protocol: "TCP",
+					Port:     555,
+				},
+				{
+					Name:     "port2",
+					Protocol: "TCP",
+					Port:     666,
+				},
+				{
+					Name:     "port3",
+					Protocol: "TCP",
+					Port:     777,
+				},
+			}), map[string]string{
+				consts.ExportedPortsAnnotation: "port1, 777",
+			}),
+		},
+	},
+	link: multicluster.Link{
+		TargetClusterName:   clusterName,
+		TargetClusterDomain: clusterDomain,
+		GatewayIdentity:     "gateway-identity",
+		GatewayAddress:      "192.0.2.127",
+		GatewayPort:         888,
+		ProbeSpec:           defaultProbeSpec,
+		Selector:            *defaultSelector,
+		NamespaceMapping:    map[string]string{"ns1": "local-ns1"},
+	},
+}
+
 var deleteMirrorService = &testEnvironment{
 	events: []interface{}{
 		&RemoteServiceDeleted{
@@ -228,6 +270,101 @@ var updateServiceWithChangedPorts = &testEnvironment{
 	},
 }
 
+var createExportedServiceWithEndpointSlices = &testEnvironment{
+	events: []interface{}{
+		&RemoteServiceCreated{
+			service: remoteService("service-one", "ns1", "111", map[string]string{
+				consts.DefaultExportedServiceSelector: "true",
+			}, []corev1.ServicePort{
+				{
+					Name:     "port1",
+					Protocol: "TCP",
+					Port:     555,
+				},
+			}),
+		},
+	},
+	remoteResources: []string{
+		gatewayAsYaml("existing-gateway", "existing-namespace", "222", "192.0.2.127", "mc-gateway", 888, "gateway-identity", defaultProbePort, defaultProbePath, defaultProbePeriod),
+	},
+	localResources: []string{
+		nodeAsYaml("node-1", "zone-b"),
+		nodeAsYaml("node-2", "zone-a"),
+		nodeAsYaml("node-3", "zone-b"),
+	},
+	link: multicluster.Link{
+		TargetClusterName:   clusterName,
+		TargetClusterDomain: clusterDomain,
+		GatewayIdentity:     "gateway-identity",
+		GatewayAddress:      "192.0.2.127",
+		GatewayPort:         888,
+		ProbeSpec:           defaultProbeSpec,
+		Selector:            *defaultSelector,
+	},
+	enableEndpointSlices: true,
+}
+
+var updateServiceWithEndpointSlices = &testEnvironment{
+	events: []interface{}{
+		&RemoteServiceUpdated{
+			remoteUpdate: remoteService("test-service", "test-namespace", "currentServiceResVersion", map[string]string{
+				consts.DefaultExportedServiceSelector: "true",
+			}, []corev1.ServicePort{
+				{
+					Name:     "port1",
+					Protocol: "TCP",
+					Port:     111,
+				},
+			}),
+			localService: mirrorService("test-service-remote", "test-namespace", "pastServiceResVersion", []corev1.ServicePort{
+				{
+					Name:     "port1",
+					Protocol: "TCP",
+					Port:     111,
+				},
+			}),
+			localEndpoints: endpoints("test-service-remote", "test-namespace", "192.0.2.127", "gateway-identity", []corev1.EndpointPort{
+				{
+					Name:     "port1",
+					Port:     888,
+					Protocol: "TCP",
+				},
+			}),
+		},
+	},
+	remoteResources: []string{
+		gatewayAsYaml("gateway", "gateway-ns", "currentGatewayResVersion", "192.0.2.127", "mc-gateway", 888, "", defaultProbePort, defaultProbePath, defaultProbePeriod),
+	},
+	localResources: []string{
+		mirrorServiceAsYaml("test-service-remote", "test-namespace", "past", []corev1.ServicePort{
+			{
+				Name:     "port1",
+				Protocol: "TCP",
+				Port:     111,
+			},
+		}),
+		endpointsAsYaml("test-service-remote", "test-namespace", "192.0.2.127", "gateway-identity", []corev1.EndpointPort{
+			{
+				Name:     "port1",
+				Port:     888,
+				Protocol: "TCP",
+			},
+		}),
+		// a slice left over from a time the gateway had an IPv6 address
+		endpointSliceAsYaml("test-service-remote-ipv6-0", "test-namespace", "test-service-remote", "2001:db8::1", "gateway-identity", nil, nil),
+	},
+	link: multicluster.Link{
+		TargetClusterName:   clusterName,
+		TargetClusterDomain: clusterDomain,
+		GatewayIdentity:     "gateway-identity",
+		GatewayAddress:      "192.0.2.127",
+		GatewayPort:         888,
+		ProbeSpec:           defaultProbeSpec,
+		Selector:            *defaultSelector,
+	},
+	enableEndpointSlices: true,
+}
+
 var clusterUnregistered = &testEnvironment{
 	events: []interface{}{
 		&ClusterUnregistered{},
@@ -243,6 +380,42 @@ var clusterUnregistered = &testEnvironment{
 	},
 }
 
+var deleteMirrorServiceWithNamespaceMapping = &testEnvironment{
+	events: []interface{}{
+		&RemoteServiceDeleted{
+			Name:      "test-service-remote-to-delete",
+			Namespace: "remote-namespace",
+		},
+	},
+	localResources: []string{
+		mirrorServiceAsYaml("test-service-remote-to-delete-remote", "local-namespace", "", nil),
+		endpointsAsYaml("test-service-remote-to-delete-remote", "local-namespace", "", "gateway-identity", nil),
+	},
+	link: multicluster.Link{
+		TargetClusterName: clusterName,
+		NamespaceMapping:  map[string]string{"remote-namespace": "local-namespace"},
+	},
+}
+
+var gcTriggeredWithNamespaceMapping = &testEnvironment{
+	events: []interface{}{
+		&OrphanedServicesGcTriggered{},
+	},
+	localResources: []string{
+		mirrorServiceAsYaml("test-service-1-remote", "local-namespace", "", nil),
+		endpointsAsYaml("test-service-1-remote", "local-namespace", "", "", nil),
+		mirrorServiceAsYaml("test-service-2-remote", "local-namespace", "", nil),
+		endpointsAsYaml("test-service-2-remote", "local-namespace", "", "", nil),
+	},
+	remoteResources: []string{
+		remoteServiceAsYaml("test-service-1", "remote-namespace", "", nil),
+	},
+	link: multicluster.Link{
+		TargetClusterName: clusterName,
+		NamespaceMapping:  map[string]string{"remote-namespace": "local-namespace"},
+	},
+}
+
 var gcTriggered = &testEnvironment{
 	events: []interface{}{
 		&OrphanedServicesGcTriggered{},
@@ -438,6 +611,34 @@ func diffEndpoints(expected, actual *corev1.Endpoints) error {
 	return nil
 }
 
+func diffEndpointSlices(expected, actual *discovery.EndpointSlice) error {
+	if expected.Name != actual.Name {
+		return fmt.Errorf("was expecting EndpointSlice with name %s but was %s", expected.Name, actual.Name)
+	}
+
+	if !reflect.DeepEqual(expected.Annotations, actual.Annotations) {
+		return fmt.Errorf("was expecting EndpointSlice with annotations %v but got %v", expected.Annotations, actual.Annotations)
+	}
+
+	if !reflect.DeepEqual(expected.Labels, actual.Labels) {
+		return fmt.Errorf("was expecting EndpointSlice with labels %v but got %v", expected.Labels, actual.Labels)
+	}
+
+	if expected.AddressType != actual.AddressType {
+		return fmt.Errorf("was expecting EndpointSlice with address type %s but got %s", expected.AddressType, actual.AddressType)
+	}
+
+	if !reflect.DeepEqual(expected.Endpoints, actual.Endpoints) {
+		return fmt.Errorf("was expecting EndpointSlice with endpoints %v but got %v", expected.Endpoints, actual.Endpoints)
+	}
+
+	if !reflect.DeepEqual(expected.Ports, actual.Ports) {
+		return fmt.Errorf("was expecting EndpointSlice with ports %v but got %v", expected.Ports, actual.Ports)
+	}
+
+	return nil
+}
+
 func remoteService(name, namespace, resourceVersion string, labels map[string]string, ports []corev1.ServicePort) *corev1.Service {
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -456,6 +657,11 @@ func remoteService(name, namespace, resourceVersion string, labels map[string]st
 	}
 }
 
+func withAnnotations(svc *corev1.Service, annotations map[string]string) *corev1.Service {
+	svc.Annotations = annotations
+	return svc
+}
+
 func remoteServiceAsYaml(name, namespace, resourceVersion string, ports []corev1.ServicePort) string {
 	svc := remoteService(name, namespace, resourceVersion, nil, ports)
 
@@ -491,6 +697,11 @@ func mirrorService(name, namespace, resourceVersion string, ports []corev1.Servi
 	}
 }
 
+func withRemoteFqName(svc *corev1.Service, fqName string) *corev1.Service {
+	svc.Annotations[consts.RemoteServiceFqName] = fqName
+	return svc
+}
+
 func mirrorServiceAsYaml(name, namespace, resourceVersion string, ports []corev1.ServicePort) string {
 	svc := mirrorService(name, namespace, resourceVersion, ports)
 
@@ -593,6 +804,11 @@ func endpoints(name, namespace, gatewayIP string, gatewayIdentity string, ports
 	return endpoints
 }
 
+func withEndpointsRemoteFqName(ep *corev1.Endpoints, fqName string) *corev1.Endpoints {
+	ep.Annotations[consts.RemoteServiceFqName] = fqName
+	return ep
+}
+
 func endpointsAsYaml(name, namespace, gatewayIP, gatewayIdentity string, ports []corev1.EndpointPort) string {
 	ep := endpoints(name, namespace, gatewayIP, gatewayIdentity, ports)
 
@@ -602,3 +818,98 @@ func endpointsAsYaml(name, namespace, gatewayIP, gatewayIdentity string, ports [
 	}
 	return string(bytes)
 }
+
+func withSkipMirror(ep *corev1.Endpoints) *corev1.Endpoints {
+	ep.Labels[discovery.LabelSkipMirror] = "true"
+	return ep
+}
+
+func endpointSlice(name, namespace, serviceName, gatewayIP, gatewayIdentity string, zones []string, ports []corev1.EndpointPort) *discovery.EndpointSlice {
+	ready := true
+	var hints *discovery.EndpointHints
+	if len(zones) > 0 {
+		hints = &discovery.EndpointHints{}
+		for _, zone := range zones {
+			hints.ForZones = append(hints.ForZones, discovery.ForZone{Name: zone})
+		}
+	}
+
+	addressType := discovery.AddressTypeIPv4
+	if strings.Contains(gatewayIP, ":") {
+		addressType = discovery.AddressTypeIPv6
+	}
+
+	var slicePorts []discovery.EndpointPort
+	for i := range ports {
+		port := ports[i]
+		slicePorts = append(slicePorts, discovery.EndpointPort{
+			Name:     &port.Name,
+			Port:     &port.Port,
+			Protocol: &port.Protocol,
+		})
+	}
+
+	es := &discovery.EndpointSlice{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "EndpointSlice",
+			APIVersion: "discovery.k8s.io/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				consts.RemoteClusterNameLabel: clusterName,
+				consts.MirroredResourceLabel:  "true",
+				discovery.LabelServiceName:    serviceName,
+				discovery.LabelManagedBy:      endpointSliceManagedBy,
+			},
+			Annotations: map[string]string{
+				consts.RemoteServiceFqName: fmt.Sprintf("%s.%s.svc.cluster.local", strings.Replace(serviceName, "-remote", "", 1), namespace),
+			},
+		},
+		AddressType: addressType,
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses:  []string{gatewayIP},
+				Conditions: discovery.EndpointConditions{Ready: &ready},
+				Hints:      hints,
+			},
+		},
+		Ports: slicePorts,
+	}
+
+	if gatewayIdentity != "" {
+		es.Annotations[consts.RemoteGatewayIdentity] = gatewayIdentity
+	}
+
+	return es
+}
+
+func endpointSliceAsYaml(name, namespace, serviceName, gatewayIP, gatewayIdentity string, zones []string, ports []corev1.EndpointPort) string {
+	es := endpointSlice(name, namespace, serviceName, gatewayIP, gatewayIdentity, zones, ports)
+
+	bytes, err := yaml.Marshal(es)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return string(bytes)
+}
+
+func nodeAsYaml(name, zone string) string {
+	node := &corev1.Node{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Node",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{zoneLabel: zone},
+		},
+	}
+
+	bytes, err := yaml.Marshal(node)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return string(bytes)
+}
@@ -0,0 +1,98 @@
+package servicemirror
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	eventReasonRemoteAuthFailed   = "RemoteAuthFailed"
+	eventReasonCredentialsExpired = "CredentialsExpired"
+)
+
+// authFailureRoundTripper reports every 401 response returned by the remote
+// API server, which is how an expired or revoked token manifests.
+type authFailureRoundTripper struct {
+	rt        http.RoundTripper
+	onFailure func(req *http.Request)
+}
+
+func (a *authFailureRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := a.rt.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		a.onFailure(req)
+	}
+	return resp, err
+}
+
+// withAuthFailureDetection returns a copy of cfg whose transport calls
+// onFailure whenever the remote API server rejects the credentials.
+func withAuthFailureDetection(cfg *rest.Config, onFailure func(req *http.Request)) *rest.Config {
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &authFailureRoundTripper{rt: rt, onFailure: onFailure}
+	})
+	return cfg
+}
+
+// tokenExpiry extracts the expiration time from a JWT bearer token. Tokens
+// that can't be decoded or don't carry an `exp` claim, such as legacy
+// service account tokens, never expire.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+func (rcsw *RemoteClusterServiceWatcher) onRemoteAuthFailure(req *http.Request) {
+	remoteAuthFailureCounter.With(prometheus.Labels{
+		gatewayClusterName: rcsw.link.TargetClusterName,
+	}).Inc()
+	rcsw.log.Errorf("Remote API server rejected credentials for %s %s", req.Method, req.URL.Path)
+	rcsw.recordEvent(corev1.EventTypeWarning, eventReasonRemoteAuthFailed,
+		"The API server of cluster %s rejected the credentials in secret %s", rcsw.link.TargetClusterName, rcsw.link.ClusterCredentialsSecret)
+}
+
+// checkCredentialsExpiry publishes the expiry of the remote cluster token and
+// warns once it has passed.
+func (rcsw *RemoteClusterServiceWatcher) checkCredentialsExpiry() {
+	expiry, ok := tokenExpiry(rcsw.bearerToken)
+	if !ok {
+		return
+	}
+	credentialsExpiryGauge.With(prometheus.Labels{
+		gatewayClusterName: rcsw.link.TargetClusterName,
+	}).Set(float64(expiry.Unix()))
+
+	if time.Now().After(expiry) {
+		rcsw.log.Errorf("Credentials in secret %s expired at %s", rcsw.link.ClusterCredentialsSecret, expiry)
+		rcsw.recordEvent(corev1.EventTypeWarning, eventReasonCredentialsExpired,
+			"The credentials in secret %s expired at %s", rcsw.link.ClusterCredentialsSecret, expiry.UTC().Format(time.RFC3339))
+	}
+}
+
+func (rcsw *RemoteClusterServiceWatcher) recordEvent(eventType, reason, messageFmt string, args ...interface{}) {
+	if rcsw.recorder == nil {
+		return
+	}
+	rcsw.recorder.Eventf(rcsw.link.ObjectReference(), eventType, reason, messageFmt, args...)
+}
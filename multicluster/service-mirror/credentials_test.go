@@ -0,0 +1,51 @@
+package servicemirror
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTokenExpiry(t *testing.T) {
+	jwt := func(payload string) string {
+		return fmt.Sprintf("header.%s.signature", base64.RawURLEncoding.EncodeToString([]byte(payload)))
+	}
+
+	for _, tc := range []struct {
+		description string
+		token       string
+		expiry      time.Time
+		expires     bool
+	}{
+		{
+			description: "token with exp claim",
+			token:       jwt(`{"iss":"kubernetes/serviceaccount","exp":1600000000}`),
+			expiry:      time.Unix(1600000000, 0),
+			expires:     true,
+		},
+		{
+			description: "legacy service account token without exp claim",
+			token:       jwt(`{"iss":"kubernetes/serviceaccount"}`),
+		},
+		{
+			description: "opaque token",
+			token:       "not-a-jwt",
+		},
+		{
+			description: "malformed payload",
+			token:       "header.!!!.signature",
+		},
+	} {
+		tc := tc // pin
+		t.Run(tc.description, func(t *testing.T) {
+			expiry, expires := tokenExpiry(tc.token)
+			if expires != tc.expires {
+				t.Fatalf("Expected expires to be %t but got %t", tc.expires, expires)
+			}
+			if !expiry.Equal(tc.expiry) {
+				t.Fatalf("Expected expiry %s but got %s", tc.expiry, expiry)
+			}
+		})
+	}
+}
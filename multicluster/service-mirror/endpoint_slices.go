@@ -0,0 +1,296 @@
+package servicemirror
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	consts "github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// endpointSliceManagedBy is the value of the managed-by label of the
+	// EndpointSlices written by the service mirror, which keeps them apart
+	// from the ones managed by Kubernetes controllers
+	endpointSliceManagedBy = "linkerd-service-mirror"
+
+	// maxEndpointsPerSlice matches the default of the Kubernetes
+	// EndpointSlice controller
+	maxEndpointsPerSlice = 100
+
+	// maxZonesPerHint is the maximum number of zones an endpoint hint can
+	// contain
+	maxZonesPerHint = 8
+
+	// maxEndpointsAddresses matches the number of addresses above which the
+	// Kubernetes Endpoints controller truncates Endpoints
+	maxEndpointsAddresses = 1000
+
+	zoneLabel = "topology.kubernetes.io/zone"
+)
+
+// withSkipMirror returns the labels of mirror Endpoints, updated so that the
+// Kubernetes EndpointSliceMirroring controller skips them whenever the service
+// mirror writes the EndpointSlices itself.
+func (rcsw *RemoteClusterServiceWatcher) withSkipMirror(l map[string]string) map[string]string {
+	if l == nil {
+		l = make(map[string]string)
+	}
+	if rcsw.enableEndpointSlices {
+		l[discovery.LabelSkipMirror] = "true"
+	} else {
+		delete(l, discovery.LabelSkipMirror)
+	}
+	return l
+}
+
+// endpointsToWrite returns the mirror Endpoints to write to the local cluster.
+// When EndpointSlices are enabled, they hold all the addresses, and the
+// Endpoints are truncated to maxEndpointsAddresses addresses as Kubernetes
+// does, so that their size doesn't grow past the limit of an object.
+func (rcsw *RemoteClusterServiceWatcher) endpointsToWrite(ep *corev1.Endpoints) *corev1.Endpoints {
+	truncated := ep.DeepCopy()
+	delete(truncated.Annotations, corev1.EndpointsOverCapacity)
+	if !rcsw.enableEndpointSlices {
+		return truncated
+	}
+
+	remaining := maxEndpointsAddresses
+	for i := range truncated.Subsets {
+		subset := &truncated.Subsets[i]
+		if len(subset.Addresses) > remaining {
+			subset.Addresses = subset.Addresses[:remaining]
+			if truncated.Annotations == nil {
+				truncated.Annotations = make(map[string]string)
+			}
+			truncated.Annotations[corev1.EndpointsOverCapacity] = "truncated"
+		}
+		remaining -= len(subset.Addresses)
+	}
+	return truncated
+}
+
+// mirrorEndpointSlices makes the EndpointSlices of the given mirror service
+// reflect its Endpoints. It is a no-op unless EndpointSlices are enabled.
+func (rcsw *RemoteClusterServiceWatcher) mirrorEndpointSlices(ctx context.Context, svc *corev1.Service, ep *corev1.Endpoints) error {
+	if !rcsw.enableEndpointSlices {
+		return nil
+	}
+
+	zones, err := rcsw.localZones()
+	if err != nil {
+		rcsw.log.Warnf("Failed to list local zones, skipping topology hints: %s", err)
+	}
+	desired := endpointSlicesFromEndpoints(svc, ep, zones)
+
+	selector := labels.SelectorFromSet(labels.Set{
+		discovery.LabelServiceName: svc.Name,
+		discovery.LabelManagedBy:   endpointSliceManagedBy,
+	})
+	existing, err := rcsw.localAPIClient.ES().Lister().EndpointSlices(svc.Namespace).List(selector)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]*discovery.EndpointSlice)
+	for _, es := range existing {
+		current[es.Name] = es
+	}
+
+	client := rcsw.localAPIClient.Client.DiscoveryV1beta1().EndpointSlices(svc.Namespace)
+	var errors []error
+	for _, es := range desired {
+		if old, ok := current[es.Name]; ok {
+			delete(current, es.Name)
+			es.ResourceVersion = old.ResourceVersion
+			if _, err := client.Update(ctx, es, metav1.UpdateOptions{}); err != nil {
+				errors = append(errors, fmt.Errorf("could not update EndpointSlice %s/%s: %s", es.Namespace, es.Name, err))
+			}
+			continue
+		}
+		if _, err := client.Create(ctx, es, metav1.CreateOptions{}); err != nil {
+			if !kerrors.IsAlreadyExists(err) {
+				errors = append(errors, fmt.Errorf("could not create EndpointSlice %s/%s: %s", es.Namespace, es.Name, err))
+			}
+		}
+	}
+
+	// slices left over from a larger set of addresses
+	for _, es := range current {
+		if err := client.Delete(ctx, es.Name, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			errors = append(errors, fmt.Errorf("could not delete EndpointSlice %s/%s: %s", es.Namespace, es.Name, err))
+		}
+	}
+
+	if len(errors) > 0 {
+		return RetryableError{errors}
+	}
+	return nil
+}
+
+// cleanupEndpointSlices undoes what the service mirror did while
+// EndpointSlices were enabled for the target cluster: it deletes the
+// EndpointSlices it wrote, and removes the skip-mirror label from the mirror
+// Endpoints so that Kubernetes mirrors them again. It is a no-op unless
+// EndpointSlices are disabled.
+func (rcsw *RemoteClusterServiceWatcher) cleanupEndpointSlices(ctx context.Context) error {
+	if rcsw.enableEndpointSlices {
+		return nil
+	}
+
+	var errors []error
+	slicesSelector := labels.SelectorFromSet(labels.Set{
+		consts.RemoteClusterNameLabel: rcsw.link.TargetClusterName,
+		discovery.LabelManagedBy:      endpointSliceManagedBy,
+	})
+	slices, err := rcsw.localAPIClient.Client.DiscoveryV1beta1().EndpointSlices(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: slicesSelector.String()})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			errors = append(errors, fmt.Errorf("could not list mirror EndpointSlices: %s", err))
+		}
+	} else {
+		for _, es := range slices.Items {
+			rcsw.log.Infof("Deleting EndpointSlice %s/%s as EndpointSlices are disabled", es.Namespace, es.Name)
+			err := rcsw.localAPIClient.Client.DiscoveryV1beta1().EndpointSlices(es.Namespace).Delete(ctx, es.Name, metav1.DeleteOptions{})
+			if err != nil && !kerrors.IsNotFound(err) {
+				errors = append(errors, fmt.Errorf("could not delete EndpointSlice %s/%s: %s", es.Namespace, es.Name, err))
+			}
+		}
+	}
+
+	endpointsSelector := labels.SelectorFromSet(labels.Set{
+		consts.RemoteClusterNameLabel: rcsw.link.TargetClusterName,
+		discovery.LabelSkipMirror:     "true",
+	})
+	endpoints, err := rcsw.localAPIClient.Client.CoreV1().Endpoints(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: endpointsSelector.String()})
+	if err != nil {
+		errors = append(errors, fmt.Errorf("could not list mirror Endpoints: %s", err))
+	} else {
+		for i := range endpoints.Items {
+			ep := &endpoints.Items[i]
+			ep.Labels = rcsw.withSkipMirror(ep.Labels)
+			if _, err := rcsw.localAPIClient.Client.CoreV1().Endpoints(ep.Namespace).Update(ctx, ep, metav1.UpdateOptions{}); err != nil {
+				errors = append(errors, fmt.Errorf("could not update Endpoints %s/%s: %s", ep.Namespace, ep.Name, err))
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RetryableError{errors}
+	}
+	return nil
+}
+
+// localZones returns the zones of the nodes of the local cluster.
+func (rcsw *RemoteClusterServiceWatcher) localZones() ([]string, error) {
+	nodes, err := rcsw.localAPIClient.Node().Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{})
+	zones := []string{}
+	for _, node := range nodes {
+		zone, ok := node.Labels[zoneLabel]
+		if _, dup := seen[zone]; !ok || dup {
+			continue
+		}
+		seen[zone] = struct{}{}
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// endpointSlicesFromEndpoints builds the EndpointSlices equivalent to the
+// Endpoints of a mirror service. Addresses are split per address family and
+// in chunks of maxEndpointsPerSlice, and the ports of all the subsets are
+// deduplicated. The gateway of the target cluster is
+// equally far from every local zone, so every endpoint is hinted for all of
+// them; this keeps mirror services usable when topology aware hints are
+// enabled.
+func endpointSlicesFromEndpoints(svc *corev1.Service, ep *corev1.Endpoints, zones []string) []*discovery.EndpointSlice {
+	var hints *discovery.EndpointHints
+	if len(zones) > 0 && len(zones) <= maxZonesPerHint {
+		hints = &discovery.EndpointHints{}
+		for _, zone := range zones {
+			hints.ForZones = append(hints.ForZones, discovery.ForZone{Name: zone})
+		}
+	}
+
+	ready := true
+	addressesByType := map[discovery.AddressType][]discovery.Endpoint{}
+	var ports []discovery.EndpointPort
+	seenPorts := make(map[corev1.EndpointPort]struct{})
+	for _, subset := range ep.Subsets {
+		for _, addr := range subset.Addresses {
+			addressType := discovery.AddressTypeIPv4
+			if ip := net.ParseIP(addr.IP); ip != nil && ip.To4() == nil {
+				addressType = discovery.AddressTypeIPv6
+			}
+			addressesByType[addressType] = append(addressesByType[addressType], discovery.Endpoint{
+				Addresses:  []string{addr.IP},
+				Conditions: discovery.EndpointConditions{Ready: &ready},
+				Hints:      hints,
+			})
+		}
+		for i := range subset.Ports {
+			port := subset.Ports[i]
+			key := corev1.EndpointPort{Name: port.Name, Port: port.Port, Protocol: port.Protocol}
+			if _, ok := seenPorts[key]; ok {
+				continue
+			}
+			seenPorts[key] = struct{}{}
+			ports = append(ports, discovery.EndpointPort{
+				Name:     &port.Name,
+				Port:     &port.Port,
+				Protocol: &port.Protocol,
+			})
+		}
+	}
+
+	slices := []*discovery.EndpointSlice{}
+	for _, addressType := range []discovery.AddressType{discovery.AddressTypeIPv4, discovery.AddressTypeIPv6} {
+		endpoints := addressesByType[addressType]
+		for i := 0; i*maxEndpointsPerSlice < len(endpoints); i++ {
+			end := (i + 1) * maxEndpointsPerSlice
+			if end > len(endpoints) {
+				end = len(endpoints)
+			}
+			slices = append(slices, &discovery.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            fmt.Sprintf("%s-%s-%d", svc.Name, strings.ToLower(string(addressType)), i),
+					Namespace:       svc.Namespace,
+					Labels:          endpointSliceLabels(svc, ep),
+					Annotations:     copyMap(ep.Annotations),
+					OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(svc, corev1.SchemeGroupVersion.WithKind("Service"))},
+				},
+				AddressType: addressType,
+				Endpoints:   endpoints[i*maxEndpointsPerSlice : end],
+				Ports:       ports,
+			})
+		}
+	}
+	return slices
+}
+
+func endpointSliceLabels(svc *corev1.Service, ep *corev1.Endpoints) map[string]string {
+	l := copyMap(ep.Labels)
+	delete(l, discovery.LabelSkipMirror)
+	l[discovery.LabelServiceName] = svc.Name
+	l[discovery.LabelManagedBy] = endpointSliceManagedBy
+	return l
+}
+
+func copyMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,183 @@
+package servicemirror
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEndpointSlicesFromEndpoints(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "svc-remote", Namespace: "ns"}}
+
+	addresses := []corev1.EndpointAddress{{IP: "2001:db8::1"}}
+	for i := 0; i < 150; i++ {
+		addresses = append(addresses, corev1.EndpointAddress{IP: fmt.Sprintf("10.0.%d.%d", i/256, i%256)})
+	}
+	ep := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc-remote",
+			Namespace: "ns",
+			Labels:    map[string]string{discovery.LabelSkipMirror: "true"},
+		},
+		Subsets: []corev1.EndpointSubset{{Addresses: addresses}},
+	}
+
+	t.Run("splits addresses per family and in chunks", func(t *testing.T) {
+		slices := endpointSlicesFromEndpoints(svc, ep, []string{"zone-a"})
+
+		expected := []struct {
+			name        string
+			addressType discovery.AddressType
+			endpoints   int
+		}{
+			{"svc-remote-ipv4-0", discovery.AddressTypeIPv4, 100},
+			{"svc-remote-ipv4-1", discovery.AddressTypeIPv4, 50},
+			{"svc-remote-ipv6-0", discovery.AddressTypeIPv6, 1},
+		}
+		if len(slices) != len(expected) {
+			t.Fatalf("Expected %d slices but got %d", len(expected), len(slices))
+		}
+		for i, exp := range expected {
+			es := slices[i]
+			if es.Name != exp.name || es.AddressType != exp.addressType || len(es.Endpoints) != exp.endpoints {
+				t.Fatalf("Expected slice %s (%s) with %d endpoints but got %s (%s) with %d endpoints",
+					exp.name, exp.addressType, exp.endpoints, es.Name, es.AddressType, len(es.Endpoints))
+			}
+			if _, ok := es.Labels[discovery.LabelSkipMirror]; ok {
+				t.Fatalf("Expected slice %s not to have the %s label", es.Name, discovery.LabelSkipMirror)
+			}
+			if es.Endpoints[0].Hints == nil || es.Endpoints[0].Hints.ForZones[0].Name != "zone-a" {
+				t.Fatalf("Expected endpoints of slice %s to be hinted for zone-a", es.Name)
+			}
+		}
+	})
+
+	t.Run("deduplicates the ports of the subsets", func(t *testing.T) {
+		ports := []corev1.EndpointPort{{Name: "http", Port: 8080, Protocol: "TCP"}, {Name: "grpc", Port: 9090, Protocol: "TCP"}}
+		ep := &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc-remote", Namespace: "ns"},
+			Subsets: []corev1.EndpointSubset{
+				{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}, Ports: ports},
+				{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.2"}}, Ports: ports},
+			},
+		}
+		slices := endpointSlicesFromEndpoints(svc, ep, nil)
+		if len(slices) != 1 {
+			t.Fatalf("Expected 1 slice but got %d", len(slices))
+		}
+		if len(slices[0].Ports) != len(ports) {
+			t.Fatalf("Expected %d ports but got %d", len(ports), len(slices[0].Ports))
+		}
+		for i, port := range ports {
+			actual := slices[0].Ports[i]
+			if *actual.Name != port.Name || *actual.Port != port.Port || *actual.Protocol != port.Protocol {
+				t.Fatalf("Expected port %v but got %s/%d/%s", port, *actual.Name, *actual.Port, *actual.Protocol)
+			}
+		}
+	})
+
+	t.Run("omits hints when there are too many zones", func(t *testing.T) {
+		zones := []string{}
+		for i := 0; i <= maxZonesPerHint; i++ {
+			zones = append(zones, fmt.Sprintf("zone-%d", i))
+		}
+		for _, es := range endpointSlicesFromEndpoints(svc, ep, zones) {
+			if es.Endpoints[0].Hints != nil {
+				t.Fatalf("Expected no hints in slice %s", es.Name)
+			}
+		}
+	})
+}
+
+func TestEndpointsToWrite(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "svc-remote", Namespace: "ns"}}
+	addresses := []corev1.EndpointAddress{}
+	for i := 0; i < maxEndpointsAddresses+50; i++ {
+		addresses = append(addresses, corev1.EndpointAddress{IP: fmt.Sprintf("10.0.%d.%d", i/256, i%256)})
+	}
+	ep := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc-remote", Namespace: "ns"},
+		Subsets:    []corev1.EndpointSubset{{Addresses: addresses}},
+	}
+
+	t.Run("truncates the Endpoints when EndpointSlices are enabled", func(t *testing.T) {
+		watcher := RemoteClusterServiceWatcher{enableEndpointSlices: true}
+		truncated := watcher.endpointsToWrite(ep)
+		if len(truncated.Subsets[0].Addresses) != maxEndpointsAddresses {
+			t.Fatalf("Expected %d addresses but got %d", maxEndpointsAddresses, len(truncated.Subsets[0].Addresses))
+		}
+		if truncated.Annotations[corev1.EndpointsOverCapacity] != "truncated" {
+			t.Fatalf("Expected the %s annotation to be set", corev1.EndpointsOverCapacity)
+		}
+		if len(ep.Subsets[0].Addresses) != maxEndpointsAddresses+50 {
+			t.Fatal("Expected the original Endpoints not to be modified")
+		}
+
+		endpoints := 0
+		for _, es := range endpointSlicesFromEndpoints(svc, ep, nil) {
+			endpoints += len(es.Endpoints)
+		}
+		if endpoints != maxEndpointsAddresses+50 {
+			t.Fatalf("Expected the EndpointSlices to hold %d endpoints but got %d", maxEndpointsAddresses+50, endpoints)
+		}
+	})
+
+	t.Run("keeps all the addresses when EndpointSlices are disabled", func(t *testing.T) {
+		watcher := RemoteClusterServiceWatcher{}
+		actual := watcher.endpointsToWrite(ep)
+		if len(actual.Subsets[0].Addresses) != maxEndpointsAddresses+50 {
+			t.Fatalf("Expected %d addresses but got %d", maxEndpointsAddresses+50, len(actual.Subsets[0].Addresses))
+		}
+		if _, ok := actual.Annotations[corev1.EndpointsOverCapacity]; ok {
+			t.Fatalf("Expected the %s annotation not to be set", corev1.EndpointsOverCapacity)
+		}
+	})
+}
+
+func TestCleanupEndpointSlices(t *testing.T) {
+	ports := []corev1.EndpointPort{{Name: "port1", Port: 888, Protocol: "TCP"}}
+	ep := withSkipMirror(endpoints("service-one-remote", "ns1", "192.0.2.127", "gateway-identity", ports))
+	epYaml, err := yaml.Marshal(ep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localAPI, err := k8s.NewFakeAPI(
+		string(epYaml),
+		endpointSliceAsYaml("service-one-remote-ipv4-0", "ns1", "service-one-remote", "192.0.2.127", "gateway-identity", nil, ports),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher := RemoteClusterServiceWatcher{
+		link:           &multicluster.Link{TargetClusterName: clusterName},
+		localAPIClient: localAPI,
+		log:            logging.WithFields(logging.Fields{"cluster": clusterName}),
+	}
+	if err := watcher.cleanupEndpointSlices(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	slices, err := localAPI.Client.DiscoveryV1beta1().EndpointSlices("ns1").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slices.Items) != 0 {
+		t.Fatalf("Expected the EndpointSlices to be deleted but found %v", slices.Items)
+	}
+	actual, err := localAPI.Client.CoreV1().Endpoints("ns1").Get(context.Background(), "service-one-remote", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := actual.Labels[discovery.LabelSkipMirror]; ok {
+		t.Fatalf("Expected Endpoints not to have the %s label", discovery.LabelSkipMirror)
+	}
+}
@@ -31,7 +31,11 @@ type ProbeMetrics struct {
 	unregister func()
 }
 
-var endpointRepairCounter *prometheus.CounterVec
+var (
+	endpointRepairCounter    *prometheus.CounterVec
+	remoteAuthFailureCounter *prometheus.CounterVec
+	credentialsExpiryGauge   *prometheus.GaugeVec
+)
 
 func init() {
 	endpointRepairCounter = promauto.NewCounterVec(
@@ -41,6 +45,22 @@ func init() {
 		},
 		[]string{gatewayClusterName},
 	)
+
+	remoteAuthFailureCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "service_mirror_remote_auth_failures",
+			Help: "Increments when the API server of a target cluster rejects the credentials of the service mirror controller",
+		},
+		[]string{gatewayClusterName},
+	)
+
+	credentialsExpiryGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "service_mirror_credentials_expiry_timestamp_seconds",
+			Help: "The time at which the credentials used to access a target cluster expire, in seconds since the epoch",
+		},
+		[]string{gatewayClusterName},
+	)
 }
 
 // NewProbeMetricVecs creates a new ProbeMetricVecs.
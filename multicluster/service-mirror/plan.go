@@ -0,0 +1,128 @@
+package servicemirror
+
+import (
+	"fmt"
+	"sort"
+
+	consts "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type (
+	// MirrorAction is the kind of change the service mirror controller would
+	// apply to a mirror service.
+	MirrorAction string
+
+	// MirrorChange describes a change the service mirror controller would
+	// apply to the source cluster. It is used to preview the effect of
+	// linking and unlinking clusters without applying anything.
+	MirrorChange struct {
+		Action    MirrorAction
+		Namespace string
+		Name      string
+		// RemoteService is the namespace/name of the service in the target
+		// cluster, if it still exists.
+		RemoteService string
+	}
+)
+
+const (
+	// MirrorCreate means that a new mirror service would be created.
+	MirrorCreate MirrorAction = "create"
+	// MirrorUpdate means that an existing mirror service is out of date and
+	// would be updated.
+	MirrorUpdate MirrorAction = "update"
+	// MirrorDelete means that an existing mirror service would be deleted.
+	MirrorDelete MirrorAction = "delete"
+)
+
+// PlanMirroring computes the changes the service mirror controller of the
+// given link would apply to the source cluster, given the services present in
+// the target cluster and the mirror services already present in the source
+// cluster. Mirror services that are up to date are left out.
+func PlanMirroring(link *multicluster.Link, remoteServices, localMirrors []corev1.Service) ([]MirrorChange, error) {
+	mirrors := make(map[string]corev1.Service)
+	for _, svc := range localMirrors {
+		mirrors[svc.Namespace+"/"+svc.Name] = svc
+	}
+
+	changes := []MirrorChange{}
+	seen := make(map[string]struct{})
+	for i := range remoteServices {
+		remote := &remoteServices[i]
+		exported, err := isExportedService(link, remote)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service selector: %s", err)
+		}
+		if !exported {
+			continue
+		}
+
+		change := MirrorChange{
+			Namespace:     link.LocalNamespace(remote.Namespace),
+			Name:          mirroredResourceName(link, remote.Name),
+			RemoteService: remote.Namespace + "/" + remote.Name,
+		}
+		key := change.Namespace + "/" + change.Name
+		seen[key] = struct{}{}
+
+		// mirrors the decision taken by createOrUpdateService
+		local, ok := mirrors[key]
+		if !ok {
+			change.Action = MirrorCreate
+		} else if version, ok := local.Annotations[consts.RemoteResourceVersionAnnotation]; ok && version != remote.ResourceVersion {
+			change.Action = MirrorUpdate
+		} else {
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	// any mirror that doesn't correspond to an exported service anymore is
+	// either orphaned or no longer exported, and gets deleted
+	for key, local := range mirrors {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		changes = append(changes, MirrorChange{
+			Action:    MirrorDelete,
+			Namespace: local.Namespace,
+			Name:      local.Name,
+		})
+	}
+
+	sortChanges(changes)
+	return changes, nil
+}
+
+// PlanCleanup computes the changes applied to the source cluster when the
+// given link is removed, which are the same ones cleanupMirroredResources
+// performs: every mirror service created for the link gets deleted.
+func PlanCleanup(link *multicluster.Link, localMirrors []corev1.Service) []MirrorChange {
+	selector := MirroredResourcesSelector(link)
+	changes := []MirrorChange{}
+	for _, svc := range localMirrors {
+		if !selector.Matches(labels.Set(svc.Labels)) {
+			continue
+		}
+		changes = append(changes, MirrorChange{
+			Action:        MirrorDelete,
+			Namespace:     svc.Namespace,
+			Name:          svc.Name,
+			RemoteService: link.RemoteNamespace(svc.Namespace) + "/" + originalResourceName(link, svc.Name),
+		})
+	}
+	sortChanges(changes)
+	return changes
+}
+
+func sortChanges(changes []MirrorChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Namespace != changes[j].Namespace {
+			return changes[i].Namespace < changes[j].Namespace
+		}
+		return changes[i].Name < changes[j].Name
+	})
+}
@@ -0,0 +1,70 @@
+package servicemirror
+
+import (
+	"reflect"
+	"testing"
+
+	consts "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPlanMirroring(t *testing.T) {
+	link := &multicluster.Link{
+		TargetClusterName: clusterName,
+		Selector:          *defaultSelector,
+		NamespaceMapping:  map[string]string{"mapped": "local-mapped"},
+	}
+	exported := map[string]string{consts.DefaultExportedServiceSelector: "true"}
+
+	remoteServices := []corev1.Service{
+		*remoteService("new", "ns", "1", exported, nil),
+		*remoteService("changed", "ns", "2", exported, nil),
+		*remoteService("unchanged", "ns", "3", exported, nil),
+		*remoteService("not-exported-anymore", "ns", "4", nil, nil),
+		*remoteService("new", "mapped", "5", exported, nil),
+	}
+	localMirrors := []corev1.Service{
+		*mirrorService("changed-remote", "ns", "1", nil),
+		*mirrorService("unchanged-remote", "ns", "3", nil),
+		*mirrorService("not-exported-anymore-remote", "ns", "4", nil),
+		*mirrorService("gone-remote", "ns", "5", nil),
+	}
+
+	changes, err := PlanMirroring(link, remoteServices, localMirrors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []MirrorChange{
+		{Action: MirrorCreate, Namespace: "local-mapped", Name: "new-remote", RemoteService: "mapped/new"},
+		{Action: MirrorUpdate, Namespace: "ns", Name: "changed-remote", RemoteService: "ns/changed"},
+		{Action: MirrorDelete, Namespace: "ns", Name: "gone-remote"},
+		{Action: MirrorCreate, Namespace: "ns", Name: "new-remote", RemoteService: "ns/new"},
+		{Action: MirrorDelete, Namespace: "ns", Name: "not-exported-anymore-remote"},
+	}
+	if !reflect.DeepEqual(expected, changes) {
+		t.Fatalf("Expected changes %+v but got %+v", expected, changes)
+	}
+}
+
+func TestPlanCleanup(t *testing.T) {
+	link := &multicluster.Link{
+		TargetClusterName: clusterName,
+		NamespaceMapping:  map[string]string{"remote-ns": "local-ns"},
+	}
+	other := mirrorService("svc-other", "ns", "1", nil)
+	other.Labels[consts.RemoteClusterNameLabel] = "other"
+
+	changes := PlanCleanup(link, []corev1.Service{
+		*mirrorService("svc-remote", "local-ns", "1", nil),
+		*other,
+	})
+
+	expected := []MirrorChange{
+		{Action: MirrorDelete, Namespace: "local-ns", Name: "svc-remote", RemoteService: "remote-ns/svc"},
+	}
+	if !reflect.DeepEqual(expected, changes) {
+		t.Fatalf("Expected changes %+v but got %+v", expected, changes)
+	}
+}
@@ -29,6 +29,7 @@ var (
 		"charts/partials/templates/_metadata.tpl",
 		"charts/partials/templates/_helpers.tpl",
 		"charts/partials/templates/_debug.tpl",
+		"charts/partials/templates/_await.tpl",
 		"charts/partials/templates/_capabilities.tpl",
 		"charts/partials/templates/_trace.tpl",
 		"charts/partials/templates/_nodeselector.tpl",
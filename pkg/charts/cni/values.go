@@ -20,6 +20,8 @@ type Values struct {
 	Namespace           string `json:"namespace"`
 	InboundProxyPort    uint   `json:"inboundProxyPort"`
 	OutboundProxyPort   uint   `json:"outboundProxyPort"`
+	ProxyControlPort    uint   `json:"proxyControlPort"`
+	ProxyAdminPort      uint   `json:"proxyAdminPort"`
 	IgnoreInboundPorts  string `json:"ignoreInboundPorts"`
 	IgnoreOutboundPorts string `json:"ignoreOutboundPorts"`
 	CliVersion          string `json:"cliVersion"`
@@ -31,8 +33,17 @@ type Values struct {
 	DestCNINetDir       string `json:"destCNINetDir"`
 	DestCNIBinDir       string `json:"destCNIBinDir"`
 	UseWaitFlag         bool   `json:"useWaitFlag"`
+	EnableIPv6          bool   `json:"enableIPv6"`
 	PriorityClassName   string `json:"priorityClassName"`
 	InstallNamespace    bool   `json:"installNamespace"`
+	APITimeout          string `json:"apiTimeout"`
+	FailurePolicy       string `json:"failurePolicy"`
+	EnablePodCache      bool   `json:"enablePodCache"`
+	PodCacheDir         string `json:"podCacheDir"`
+	PodCacheTimeout     string `json:"podCacheTimeout"`
+
+	EnableRepairController    bool `json:"enableRepairController"`
+	RepairControllerEvictPods bool `json:"repairControllerEvictPods"`
 }
 
 // NewValues returns a new instance of the Values type.
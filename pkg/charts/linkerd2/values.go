@@ -34,6 +34,7 @@ type (
 		OmitWebhookSideEffects       bool                `json:"omitWebhookSideEffects"`
 		DisableHeartBeat             bool                `json:"disableHeartBeat"`
 		HeartbeatSchedule            string              `json:"heartbeatSchedule"`
+		HeartbeatURL                 string              `json:"heartbeatURL"`
 		InstallNamespace             bool                `json:"installNamespace"`
 		Configs                      ConfigJSONs         `json:"configs"`
 		Namespace                    string              `json:"namespace"`
@@ -57,6 +58,10 @@ type (
 		ImagePullSecrets             []map[string]string `json:"imagePullSecrets"`
 		LinkerdVersion               string              `json:"linkerdVersion"`
 
+		ControlPlaneTracingProtocol           string  `json:"controlPlaneTracingProtocol"`
+		ControlPlaneTracingSampleRatio        float64 `json:"controlPlaneTracingSampleRatio"`
+		ControlPlaneTracingResourceAttributes string  `json:"controlPlaneTracingResourceAttributes"`
+
 		PodAnnotations map[string]string `json:"podAnnotations"`
 		PodLabels      map[string]string `json:"podLabels"`
 
@@ -109,6 +114,7 @@ type (
 		InboundConnectTimeout         string           `json:"inboundConnectTimeout"`
 		OpaquePorts                   string           `json:"opaquePorts"`
 		Await                         bool             `json:"await"`
+		JobShutdown                   bool             `json:"jobShutdown"`
 	}
 
 	// ProxyInit contains the fields to set the proxy-init container
@@ -188,6 +194,21 @@ type (
 	ProxyInjector struct {
 		*TLS
 		NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector"`
+		InjectionPolicy   []InjectionRule       `json:"injectionPolicy"`
+	}
+
+	// InjectionRule is a rule of the injection policy enforced by the proxy
+	// injector. A pod matches it if it matches all of its criteria, and the
+	// first rule a pod matches decides of its injection.
+	InjectionRule struct {
+		Name string `json:"name"`
+		// Action is one of deny, allow or enforce
+		Action string `json:"action"`
+		// Namespaces and Images are lists of glob patterns
+		Namespaces        []string              `json:"namespaces,omitempty"`
+		NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+		PodSelector       *metav1.LabelSelector `json:"podSelector,omitempty"`
+		Images            []string              `json:"images,omitempty"`
 	}
 
 	// ProfileValidator has all the profile validator's Helm variables
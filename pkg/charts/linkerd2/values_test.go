@@ -37,6 +37,7 @@ func TestNewValues(t *testing.T) {
 		OmitWebhookSideEffects:       false,
 		DisableHeartBeat:             false,
 		HeartbeatSchedule:            "",
+		HeartbeatURL:                 "",
 		InstallNamespace:             true,
 		Namespace:                    "linkerd",
 		ClusterDomain:                "cluster.local",
@@ -84,6 +85,7 @@ func TestNewValues(t *testing.T) {
 			InboundConnectTimeout:  "100ms",
 			OpaquePorts:            "25,443,587,3306,5432,11211",
 			Await:                  true,
+			JobShutdown:            true,
 		},
 		ProxyInit: &ProxyInit{
 			Image: &Image{
@@ -123,8 +125,11 @@ func TestNewValues(t *testing.T) {
 			},
 		},
 
-		ProxyInjector:    &ProxyInjector{TLS: &TLS{}, NamespaceSelector: namespaceSelector},
+		ProxyInjector:    &ProxyInjector{TLS: &TLS{}, NamespaceSelector: namespaceSelector, InjectionPolicy: []InjectionRule{}},
 		ProfileValidator: &ProfileValidator{TLS: &TLS{}, NamespaceSelector: namespaceSelector},
+
+		ControlPlaneTracingProtocol:    "grpc",
+		ControlPlaneTracingSampleRatio: 1,
 	}
 
 	// pin the versions to ensure consistent test result.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/linkerd/linkerd2/pkg/trace"
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
@@ -36,12 +37,16 @@ func ConfigureAndParse(cmd *flag.FlagSet, args []string) {
 	maybePrintVersionAndExit(*printVersion)
 }
 
-// AddTraceFlags adds the trace-collector flag
-// to the flagSet and returns their pointers for usage
-func AddTraceFlags(cmd *flag.FlagSet) *string {
-	traceCollector := cmd.String("trace-collector", "", "Enables OC Tracing with the specified endpoint as collector")
+// AddTraceFlags adds the trace flags to the flagSet and returns the tracing
+// configuration they're parsed into
+func AddTraceFlags(cmd *flag.FlagSet) *trace.Config {
+	config := &trace.Config{}
+	cmd.StringVar(&config.Collector, "trace-collector", "", "Enables OpenTelemetry tracing with the specified OTLP collector (host:port)")
+	cmd.StringVar(&config.Protocol, "trace-protocol", trace.ProtocolGRPC, "OTLP protocol used to export spans, one of: grpc, http")
+	cmd.Float64Var(&config.SampleRatio, "trace-sample-ratio", 1, "Fraction of the traces started by this component that are sampled, between 0 and 1")
+	cmd.StringVar(&config.ResourceAttributes, "trace-resource-attributes", "", "Comma-separated key=value pairs added to the resource of the spans")
 
-	return traceCollector
+	return config
 }
 
 func setLogLevel(logLevel string) {
@@ -153,6 +153,8 @@ const (
 	linkerdCNIDisabledSkipReason = "skipping check because CNI is not enabled"
 	linkerdCNIResourceName       = "linkerd-cni"
 	linkerdCNIConfigMapName      = "linkerd-cni-config"
+	linkerdCNIRepairContainer    = "repair-controller"
+	linkerdCNIRepairSkipReason   = "skipping check because the CNI repair controller is not enabled"
 
 	podCIDRUnavailableSkipReason = "skipping check because the nodes aren't exposing podCIDR"
 
@@ -997,6 +999,27 @@ func (hc *HealthChecker) allCategories() []*Category {
 						return nil
 					},
 				},
+				{
+					description: "cni plugin redirects the traffic of all IP families",
+					hintAnchor:  "cni-plugin-ip-families",
+					check: func(ctx context.Context) error {
+						if !hc.CNIEnabled {
+							return &SkipError{Reason: linkerdCNIDisabledSkipReason}
+						}
+						return hc.checkCNIIPFamilies(ctx)
+					},
+				},
+				{
+					description: "cni plugin set up the redirection of all meshed pods",
+					hintAnchor:  "cni-plugin-redirected-pods",
+					warning:     true,
+					check: func(ctx context.Context) error {
+						if !hc.CNIEnabled {
+							return &SkipError{Reason: linkerdCNIDisabledSkipReason}
+						}
+						return hc.checkCNIRedirectedPods(ctx)
+					},
+				},
 			},
 			false,
 		),
@@ -1376,6 +1399,19 @@ func (hc *HealthChecker) allCategories() []*Category {
 						return checkMisconfiguredPodsLabels(pods)
 					},
 				},
+				{
+					description: "data plane Job pods shut down their proxy",
+					hintAnchor:  "l5d-data-plane-job-shutdown",
+					warning:     true,
+					check: func(ctx context.Context) error {
+						pods, err := hc.GetDataPlanePods(ctx)
+						if err != nil {
+							return err
+						}
+
+						return checkJobShutdown(pods)
+					},
+				},
 				{
 					description: "data plane service labels are configured correctly",
 					hintAnchor:  "l5d-data-plane-services-labels",
@@ -1581,6 +1617,95 @@ func (hc *HealthChecker) CheckAPIService(ctx context.Context, serviceName string
 	return fmt.Errorf("%s service not available", apiStatus.Name)
 }
 
+// checkCNIIPFamilies verifies that the CNI plugin redirects IPv6 traffic as
+// well as IPv4 traffic to the proxy when the pods of the cluster get IPv6
+// addresses, as happens in dual-stack clusters.
+func (hc *HealthChecker) checkCNIIPFamilies(ctx context.Context) error {
+	nodes, err := hc.kubeAPI.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	var ipv6Nodes []string
+	for _, node := range nodes.Items {
+		for _, cidr := range append([]string{node.Spec.PodCIDR}, node.Spec.PodCIDRs...) {
+			if ip, _, err := net.ParseCIDR(cidr); err == nil && ip.To4() == nil {
+				ipv6Nodes = append(ipv6Nodes, node.Name)
+				break
+			}
+		}
+	}
+	if len(ipv6Nodes) == 0 {
+		return nil
+	}
+
+	cm, err := hc.kubeAPI.CoreV1().ConfigMaps(hc.CNINamespace).Get(ctx, linkerdCNIConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	var networkConfig struct {
+		Linkerd struct {
+			IPv6 bool `json:"ipv6"`
+		} `json:"linkerd"`
+	}
+	if err := yaml.Unmarshal([]byte(cm.Data["cni_network_config"]), &networkConfig); err != nil {
+		return fmt.Errorf("invalid CNI network config: %s", err)
+	}
+	if !networkConfig.Linkerd.IPv6 {
+		return fmt.Errorf("IPv6 traffic bypasses the proxy on nodes with IPv6 pod CIDRs (%s), the CNI plugin needs to be installed with --enable-ipv6", strings.Join(ipv6Nodes, ", "))
+	}
+	return nil
+}
+
+// checkCNIRedirectedPods verifies that the CNI plugin set up the redirection
+// of the traffic of all the meshed pods relying on it, including the ones
+// started on a node before the plugin was ready. The plugin only records it
+// when the repair controller is enabled.
+func (hc *HealthChecker) checkCNIRedirectedPods(ctx context.Context) error {
+	ds, err := hc.kubeAPI.AppsV1().DaemonSets(hc.CNINamespace).Get(ctx, linkerdCNIResourceName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	repairEnabled := false
+	for _, container := range ds.Spec.Template.Spec.Containers {
+		if container.Name == linkerdCNIRepairContainer {
+			repairEnabled = true
+		}
+	}
+	if !repairEnabled {
+		return &SkipError{Reason: linkerdCNIRepairSkipReason}
+	}
+
+	pods, err := hc.kubeAPI.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, pod := range pods.Items {
+		if k8s.IsMissingCNIRedirect(pod) {
+			missing = append(missing, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the traffic of some pods bypasses the proxy, they need to be recreated:\n\t%s", strings.Join(missing, "\n\t"))
+	}
+	return nil
+}
+
+// checkJobShutdown verifies that the containers of the meshed pods of Jobs shut
+// down their proxy when they exit, without which the pods never complete.
+func checkJobShutdown(pods []corev1.Pod) error {
+	var missing []string
+	for _, pod := range pods {
+		if k8s.IsMissingJobShutdown(pod) {
+			missing = append(missing, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("some Job pods won't complete as their containers don't shut down the proxy when they exit; set their command so that they can be wrapped by linkerd-await:\n\t%s", strings.Join(missing, "\n\t"))
+	}
+	return nil
+}
+
 func (hc *HealthChecker) checkMinReplicasAvailable(ctx context.Context) error {
 	faulty := []string{}
 
@@ -2280,7 +2405,7 @@ func (hc *HealthChecker) checkMisconfiguredOpaquePortAnnotations(ctx context.Con
 	// This is used instead of `hc.kubeAPI` to limit multiple k8s API requests
 	// and use the caching logic in the shared informers
 	// TODO: move the shared informer code out of `controller/`, and into `pkg` to simplify the dependency tree.
-	kubeAPI := controllerK8s.NewAPI(hc.kubeAPI, nil, nil, controllerK8s.Endpoint, controllerK8s.Pod, controllerK8s.Svc)
+	kubeAPI := controllerK8s.NewAPI(hc.kubeAPI, nil, nil, nil, controllerK8s.Endpoint, controllerK8s.Pod, controllerK8s.Svc)
 	kubeAPI.Sync(ctx.Done())
 
 	services, err := kubeAPI.Svc().Lister().Services(hc.DataPlaneNamespace).List(labels.Everything())
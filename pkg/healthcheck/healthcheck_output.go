@@ -27,6 +27,10 @@ const (
 	WideOutput = "wide"
 	// ShortOutput is used to specify the short output format
 	ShortOutput = "short"
+	// JUnitOutput is used to specify the JUnit XML output format
+	JUnitOutput = "junit"
+	// SARIFOutput is used to specify the SARIF output format
+	SARIFOutput = "sarif"
 
 	// DefaultHintBaseURL is the default base URL on the linkerd.io website
 	// that all check hints for the latest linkerd version point to. Each
@@ -85,67 +89,7 @@ func RunExtensionsChecks(wout io.Writer, werr io.Writer, extensions []string, fl
 
 	success := true
 	for _, extension := range extensions {
-		var path string
-		args := append([]string{"check"}, flags...)
-		var err error
-		results := CheckResults{
-			Results: []CheckResult{},
-		}
-		extensionCmd := fmt.Sprintf("linkerd-%s", extension)
-
-		switch extension {
-		case "jaeger":
-			path = os.Args[0]
-			args = append([]string{"jaeger"}, args...)
-		case "viz":
-			path = os.Args[0]
-			args = append([]string{"viz"}, args...)
-		case "multicluster":
-			path = os.Args[0]
-			args = append([]string{"multicluster"}, args...)
-		default:
-			path, err = exec.LookPath(extensionCmd)
-			results.Results = []CheckResult{
-				{
-					Category:    CategoryID(extensionCmd),
-					Description: fmt.Sprintf("Linkerd extension command %s exists", extensionCmd),
-					Err:         err,
-					HintURL:     HintBaseURL(version.Version) + "extensions",
-					Warning:     true,
-				},
-			}
-		}
-
-		if err == nil {
-			if isatty.IsTerminal(os.Stdout.Fd()) {
-				spin.Suffix = fmt.Sprintf(" Running %s extension check", extension)
-				spin.Color("bold") // this calls spin.Restart()
-			}
-			plugin := exec.Command(path, args...)
-			var stdout, stderr bytes.Buffer
-			plugin.Stdout = &stdout
-			plugin.Stderr = &stderr
-			plugin.Run()
-			extensionResults, err := parseJSONCheckOutput(stdout.Bytes())
-			spin.Stop()
-			if err != nil {
-				command := fmt.Sprintf("%s %s", path, strings.Join(args, " "))
-				if len(stderr.String()) > 0 {
-					err = errors.New(stderr.String())
-				} else {
-					err = fmt.Errorf("invalid extension check output from \"%s\" (JSON object expected):\n%s\n[%s]", command, stdout.String(), err)
-				}
-				results.Results = append(results.Results, CheckResult{
-					Category:    CategoryID(extensionCmd),
-					Description: fmt.Sprintf("Running: %s", command),
-					Err:         err,
-					HintURL:     HintBaseURL(version.Version) + "extensions",
-				})
-				success = false
-			} else {
-				results.Results = append(results.Results, extensionResults.Results...)
-			}
-		}
+		results := runExtensionChecks(extension, flags, spin)
 		// add a new line to space out each check output
 		fmt.Fprintln(wout)
 		extensionSuccess := RunChecks(wout, werr, results, fmt.Sprintf("extension-%s", output))
@@ -157,15 +101,123 @@ func RunExtensionsChecks(wout io.Writer, werr io.Writer, extensions []string, fl
 	return success
 }
 
+// ExtensionsCheckResults runs the checks of each extension passed into the
+// `extensions` parameter, and returns their results without printing them
+func ExtensionsCheckResults(extensions []string, flags []string) CheckResults {
+	results := CheckResults{
+		Results: []CheckResult{},
+	}
+	for _, extension := range extensions {
+		results.Results = append(results.Results, runExtensionChecks(extension, flags, nil).Results...)
+	}
+	return results
+}
+
+// runExtensionChecks runs the check command of an extension, and returns its
+// results. The spinner, if any, is shown while the command runs.
+func runExtensionChecks(extension string, flags []string, spin *spinner.Spinner) CheckResults {
+	results := CheckResults{
+		Results: []CheckResult{},
+	}
+	extensionCmd := fmt.Sprintf("linkerd-%s", extension)
+
+	path, args, err := ExtensionCheckCommand(extension, flags)
+	if !builtinExtensions[extension] {
+		results.Results = []CheckResult{
+			{
+				Category:    CategoryID(extensionCmd),
+				Description: fmt.Sprintf("Linkerd extension command %s exists", extensionCmd),
+				Err:         err,
+				HintURL:     HintBaseURL(version.Version) + "extensions",
+				Warning:     true,
+			},
+		}
+	}
+	if err != nil {
+		return results
+	}
+
+	if spin != nil && isatty.IsTerminal(os.Stdout.Fd()) {
+		spin.Suffix = fmt.Sprintf(" Running %s extension check", extension)
+		spin.Color("bold") // this calls spin.Restart()
+	}
+	plugin := exec.Command(path, args...)
+	var stdout, stderr bytes.Buffer
+	plugin.Stdout = &stdout
+	plugin.Stderr = &stderr
+	plugin.Run()
+	extensionResults, err := parseJSONCheckOutput(stdout.Bytes())
+	if spin != nil {
+		spin.Stop()
+	}
+	if err != nil {
+		command := fmt.Sprintf("%s %s", path, strings.Join(args, " "))
+		if len(stderr.String()) > 0 {
+			err = errors.New(stderr.String())
+		} else {
+			err = fmt.Errorf("invalid extension check output from \"%s\" (JSON object expected):\n%s\n[%s]", command, stdout.String(), err)
+		}
+		results.Results = append(results.Results, CheckResult{
+			Category:    CategoryID(extensionCmd),
+			Description: fmt.Sprintf("Running: %s", command),
+			Err:         err,
+			HintURL:     HintBaseURL(version.Version) + "extensions",
+		})
+	} else {
+		results.Results = append(results.Results, extensionResults.Results...)
+	}
+	return results
+}
+
+// builtinExtensions are the extensions whose commands are part of the linkerd
+// CLI
+var builtinExtensions = map[string]bool{
+	"jaeger":       true,
+	"multicluster": true,
+	"viz":          true,
+}
+
+// ExtensionCheckCommand returns the path and the arguments of the command
+// running the checks of an extension. The checks of the builtin extensions
+// are run by the linkerd CLI itself, and the others by the
+// linkerd-<extension> executable found in the PATH.
+func ExtensionCheckCommand(extension string, flags []string) (string, []string, error) {
+	args := append([]string{"check"}, flags...)
+	if builtinExtensions[extension] {
+		return os.Args[0], append([]string{extension}, args...), nil
+	}
+	path, err := exec.LookPath(fmt.Sprintf("linkerd-%s", extension))
+	return path, args, err
+}
+
 // RunChecks runs the checks that are part of hc
 func RunChecks(wout io.Writer, werr io.Writer, hc Runner, output string) bool {
-	if output == JSONOutput {
+	switch output {
+	case JSONOutput:
 		return runChecksJSON(wout, werr, hc)
+	case JUnitOutput:
+		return runChecksJUnit(wout, werr, hc)
+	case SARIFOutput:
+		return runChecksSARIF(wout, werr, hc)
 	}
 
 	return runChecksTable(wout, hc, output)
 }
 
+// CollectCheckResults runs the checks that are part of hc, and returns their
+// final results, without the ones that were retried
+func CollectCheckResults(hc Runner) CheckResults {
+	results := CheckResults{
+		Results: []CheckResult{},
+	}
+	hc.RunChecks(func(result *CheckResult) {
+		if !result.Retry {
+			results.Results = append(results.Results, *result)
+		}
+	})
+	return results
+}
+
 func runChecksTable(wout io.Writer, hc Runner, output string) bool {
 	var lastCategory CategoryID
 	spin := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
@@ -280,10 +332,12 @@ const (
 	checkErr     checkResult = "error"
 )
 
-func runChecksJSON(wout io.Writer, werr io.Writer, hc Runner) bool {
+// collectCategories runs the checks that are part of hc, and groups their
+// final results by category
+func collectCategories(hc Runner) ([]*checkCategory, bool) {
 	var categories []*checkCategory
 
-	collectJSONOutput := func(result *CheckResult) {
+	collectOutput := func(result *CheckResult) {
 		categoryName := string(result.Category)
 		if categories == nil || categories[len(categories)-1].Name != categoryName {
 			categories = append(categories, &checkCategory{
@@ -319,7 +373,12 @@ func runChecksJSON(wout io.Writer, werr io.Writer, hc Runner) bool {
 		}
 	}
 
-	result := hc.RunChecks(collectJSONOutput)
+	success := hc.RunChecks(collectOutput)
+	return categories, success
+}
+
+func runChecksJSON(wout io.Writer, werr io.Writer, hc Runner) bool {
+	categories, result := collectCategories(hc)
 
 	outputJSON := checkOutput{
 		Success:    result,
@@ -0,0 +1,223 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/version"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+var reNonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// junitTestSuites is the root of the JUnit XML report of the checks, for
+// output via `linkerd check -o junit`. Each category is a test suite, whose
+// test cases are its checks.
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Cases    []*junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a check. Warnings don't fail the checks, so they are
+// reported in the output of their test case rather than as failures.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// sarifLog is the SARIF report of the checks, for output via
+// `linkerd check -o sarif`. Each check is a rule, with a result of kind
+// "pass", or of level "error" or "warning" when it failed.
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool      `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string              `json:"id"`
+	ShortDescription sarifMessage        `json:"shortDescription"`
+	HelpURI          string              `json:"helpUri,omitempty"`
+	Properties       sarifRuleProperties `json:"properties"`
+}
+
+type sarifRuleProperties struct {
+	Category string `json:"category"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId"`
+	RuleIndex int              `json:"ruleIndex"`
+	Kind      string           `json:"kind"`
+	Level     string           `json:"level"`
+	Message   sarifMessage     `json:"message"`
+	Locations []*sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []*sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+func runChecksJUnit(wout io.Writer, werr io.Writer, hc Runner) bool {
+	categories, result := collectCategories(hc)
+
+	report := junitTestSuites{Suites: []*junitTestSuite{}}
+	for _, category := range categories {
+		suite := &junitTestSuite{Name: category.Name, Cases: []*junitTestCase{}}
+		for _, check := range category.Checks {
+			testCase := &junitTestCase{Name: check.Description, ClassName: category.Name}
+			switch check.Result {
+			case checkErr:
+				testCase.Failure = &junitFailure{
+					Message: check.Error,
+					Type:    string(checkErr),
+					Text:    checkDetails(check),
+				}
+				suite.Failures++
+			case checkWarn:
+				testCase.SystemOut = fmt.Sprintf("%s: %s", checkWarn, checkDetails(check))
+			}
+			suite.Cases = append(suite.Cases, testCase)
+			suite.Tests++
+		}
+		report.Suites = append(report.Suites, suite)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+	}
+
+	resultXML, err := xml.MarshalIndent(report, "", "  ")
+	if err == nil {
+		fmt.Fprintf(wout, "%s%s\n", xml.Header, string(resultXML))
+	} else {
+		fmt.Fprintf(werr, "JUnit serialization of the check result failed with %s", err)
+	}
+	return result
+}
+
+func runChecksSARIF(wout io.Writer, werr io.Writer, hc Runner) bool {
+	categories, success := collectCategories(hc)
+
+	driver := sarifDriver{
+		Name:           "linkerd-check",
+		Version:        version.Version,
+		InformationURI: "https://linkerd.io",
+		Rules:          []*sarifRule{},
+	}
+	results := []*sarifResult{}
+	ruleIndexes := map[string]int{}
+	for _, category := range categories {
+		for _, check := range category.Checks {
+			id := sarifRuleID(category.Name, check.Description)
+			index, ok := ruleIndexes[id]
+			if !ok {
+				index = len(driver.Rules)
+				ruleIndexes[id] = index
+				driver.Rules = append(driver.Rules, &sarifRule{
+					ID:               id,
+					ShortDescription: sarifMessage{Text: check.Description},
+					Properties:       sarifRuleProperties{Category: category.Name},
+				})
+			}
+			if check.Hint != "" {
+				driver.Rules[index].HelpURI = check.Hint
+			}
+
+			result := &sarifResult{
+				RuleID:    id,
+				RuleIndex: index,
+				Kind:      "pass",
+				Level:     "none",
+				Message:   sarifMessage{Text: check.Description},
+				Locations: []*sarifLocation{{
+					LogicalLocations: []*sarifLogicalLocation{{Name: category.Name, Kind: "module"}},
+				}},
+			}
+			if check.Result != checkSuccess {
+				result.Kind = "fail"
+				result.Level = string(check.Result)
+				result.Message.Text = fmt.Sprintf("%s: %s", check.Description, checkDetails(check))
+			}
+			results = append(results, result)
+		}
+	}
+
+	report := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []*sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	resultJSON, err := json.MarshalIndent(report, "", "  ")
+	if err == nil {
+		fmt.Fprintf(wout, "%s\n", string(resultJSON))
+	} else {
+		fmt.Fprintf(werr, "SARIF serialization of the check result failed with %s", err)
+	}
+	return success
+}
+
+// checkDetails returns the error of a failed check, followed by its hint URL
+func checkDetails(check *check) string {
+	if check.Hint == "" {
+		return check.Error
+	}
+	return fmt.Sprintf("%s\nsee %s for hints", check.Error, check.Hint)
+}
+
+// sarifRuleID returns the ID of the SARIF rule of a check, made of its
+// category and description
+func sarifRuleID(category, description string) string {
+	slug := func(s string) string {
+		return strings.Trim(reNonAlphanumeric.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	}
+	return fmt.Sprintf("%s/%s", slug(category), slug(description))
+}
@@ -3304,6 +3304,7 @@ func TestCniChecks(t *testing.T) {
 				"linkerd-cni-plugin cni plugin ServiceAccount exists",
 				"linkerd-cni-plugin cni plugin DaemonSet exists",
 				"linkerd-cni-plugin cni plugin pod is running on all nodes",
+				"linkerd-cni-plugin cni plugin redirects the traffic of all IP families",
 			},
 		},
 	}
@@ -3336,6 +3337,189 @@ func TestCniChecks(t *testing.T) {
 
 }
 
+func TestCheckCNIIPFamilies(t *testing.T) {
+	node := func(name string, podCIDRs ...string) string {
+		return fmt.Sprintf(`
+apiVersion: v1
+kind: Node
+metadata:
+  name: %s
+spec:
+  podCIDR: %s
+  podCIDRs: [%s]
+`, name, podCIDRs[0], strings.Join(podCIDRs, ", "))
+	}
+	configMap := func(ipv6 bool) string {
+		return fmt.Sprintf(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-cni-config
+  namespace: test-ns
+data:
+  cni_network_config: |-
+    {
+      "name": "linkerd-cni",
+      "type": "linkerd-cni",
+      "linkerd": {
+        "incoming-proxy-port": 4143,
+        "ipv6": %t
+      }
+    }
+`, ipv6)
+	}
+
+	testCases := []struct {
+		description string
+		resources   []string
+		expected    string
+	}{
+		{
+			"passes for IPv4 clusters",
+			[]string{node("node-1", "10.0.0.0/24"), configMap(false)},
+			"",
+		},
+		{
+			"passes for dual-stack clusters when IPv6 is enabled",
+			[]string{node("node-1", "10.0.0.0/24", "fd00::/64"), configMap(true)},
+			"",
+		},
+		{
+			"fails for dual-stack clusters when IPv6 is disabled",
+			[]string{node("node-1", "10.0.0.0/24", "fd00::/64"), node("node-2", "10.0.1.0/24"), configMap(false)},
+			"IPv6 traffic bypasses the proxy on nodes with IPv6 pod CIDRs (node-1), the CNI plugin needs to be installed with --enable-ipv6",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.description, func(t *testing.T) {
+			hc := NewHealthChecker(
+				[]CategoryID{LinkerdCNIPluginChecks},
+				&Options{
+					CNINamespace: "test-ns",
+				},
+			)
+			var err error
+			hc.kubeAPI, err = k8s.NewFakeAPI(tc.resources...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			err = hc.checkCNIIPFamilies(context.Background())
+			if tc.expected == "" && err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if tc.expected != "" && (err == nil || err.Error() != tc.expected) {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestCheckCNIRedirectedPods(t *testing.T) {
+	pod := func(name, annotations, startTime string) string {
+		return fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %s
+  namespace: emojivoto
+  annotations: {%s}
+spec:
+  nodeName: node-1
+  containers:
+  - name: linkerd-proxy
+status:
+  phase: Running
+  startTime: %s
+  containerStatuses:
+  - name: linkerd-proxy
+    state:
+      running:
+        startedAt: 1995-02-10T00:42:42Z
+`, name, annotations, startTime)
+	}
+	daemonSet := func(containers string) string {
+		return fmt.Sprintf(`
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: linkerd-cni
+  namespace: linkerd-cni
+spec:
+  selector:
+    matchLabels:
+      k8s-app: linkerd-cni
+  template:
+    spec:
+      containers: [%s]
+`, containers)
+	}
+	cniPod := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: linkerd-cni-abcde
+  namespace: linkerd-cni
+  labels:
+    k8s-app: linkerd-cni
+spec:
+  nodeName: node-1
+status:
+  phase: Running
+  startTime: 1995-02-10T00:00:00Z
+`
+	withRepair := daemonSet("{name: install-cni}, {name: repair-controller}")
+
+	testCases := []struct {
+		description string
+		resources   []string
+		expected    string
+	}{
+		{
+			"passes when all pods are redirected",
+			[]string{withRepair, cniPod, pod("web", "linkerd.io/cni-redirected: ipv4", "1995-02-10T00:42:00Z"), pod("emoji", "linkerd.io/cni-redirected: ipv4", "1995-02-10T00:42:00Z")},
+			"",
+		},
+		{
+			"fails when some pods aren't redirected",
+			[]string{withRepair, cniPod, pod("web", "linkerd.io/cni-redirected: ipv4", "1995-02-10T00:42:00Z"), pod("emoji", "", "1995-02-10T00:42:00Z")},
+			"the traffic of some pods bypasses the proxy, they need to be recreated:\n\temojivoto/emoji",
+		},
+		{
+			"fails when pods started before the plugin aren't redirected",
+			[]string{withRepair, cniPod, pod("emoji", "", "1995-02-09T00:42:00Z")},
+			"the traffic of some pods bypasses the proxy, they need to be recreated:\n\temojivoto/emoji",
+		},
+		{
+			"is skipped when the repair controller is disabled",
+			[]string{daemonSet("{name: install-cni}"), cniPod, pod("emoji", "", "1995-02-10T00:42:00Z")},
+			linkerdCNIRepairSkipReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.description, func(t *testing.T) {
+			hc := NewHealthChecker([]CategoryID{LinkerdCNIPluginChecks}, &Options{CNINamespace: "linkerd-cni"})
+			var err error
+			hc.kubeAPI, err = k8s.NewFakeAPI(tc.resources...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			err = hc.checkCNIRedirectedPods(context.Background())
+			if tc.expected == "" && err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if tc.expected != "" && (err == nil || err.Error() != tc.expected) {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
 func TestMinReplicaCheck(t *testing.T) {
 	hc := NewHealthChecker(
 		[]CategoryID{LinkerdHAChecks},
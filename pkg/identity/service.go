@@ -18,7 +18,11 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	pb "github.com/linkerd/linkerd2-proxy-api/go/identity"
 	"github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/linkerd/linkerd2/pkg/trace"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -36,6 +40,10 @@ const (
 	eventTypeUpdated        = "IssuerUpdated"
 	eventTypeFailed         = "IssuerValidationFailed"
 	eventTypeIssuedLeafCert = "IssuedLeafCertificate"
+
+	// tracerName is the name of the OpenTelemetry tracer of the identity
+	// service
+	tracerName = "github.com/linkerd/linkerd2/pkg/identity"
 )
 
 type (
@@ -164,7 +172,11 @@ func (svc *Service) ensureIssuerStillValid() error {
 }
 
 // Certify validates identity and signs certificates.
-func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.CertifyResponse, error) {
+func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (_ *pb.CertifyResponse, err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "identity.Certify",
+		oteltrace.WithAttributes(attribute.String("identity.requested", req.GetIdentity())))
+	defer func() { trace.EndSpan(span, err) }()
+
 	svc.issuerMutex.RLock()
 	defer svc.issuerMutex.RUnlock()
 
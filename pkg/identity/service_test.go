@@ -7,6 +7,9 @@ import (
 
 	pb "github.com/linkerd/linkerd2-proxy-api/go/identity"
 	"github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/linkerd/linkerd2/pkg/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type fakeValidator struct {
@@ -97,3 +100,29 @@ func TestInvalidRequestArguments(t *testing.T) {
 	}
 
 }
+
+func TestCertifySpan(t *testing.T) {
+	exporter := trace.InitializeInMemoryTracing()
+	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "")
+	req := &pb.CertifyRequest{Identity: "some-identity"}
+
+	if _, err := svc.Certify(context.TODO(), req); err == nil {
+		t.Fatal("Expected error but got nothing")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "identity.Certify" {
+		t.Errorf("Expected span identity.Certify, got %s", span.Name)
+	}
+	expected := attribute.String("identity.requested", "some-identity")
+	if len(span.Attributes) != 1 || span.Attributes[0] != expected {
+		t.Errorf("Expected attributes [%v], got %v", expected, span.Attributes)
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("Expected an error status, got %v", span.Status)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
 	"github.com/linkerd/linkerd2/pkg/charts/static"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/proxyinit"
 	"github.com/linkerd/linkerd2/pkg/util"
 	log "github.com/sirupsen/logrus"
 	"helm.sh/helm/v3/pkg/chart/loader"
@@ -62,6 +63,7 @@ var (
 		k8s.ProxyIgnoreInboundPortsAnnotation,
 		k8s.ProxyOpaquePortsAnnotation,
 		k8s.ProxyIgnoreOutboundPortsAnnotation,
+		k8s.ProxyEnableIPv6Annotation,
 		k8s.ProxyOutboundConnectTimeout,
 		k8s.ProxyInboundConnectTimeout,
 		k8s.ProxyAwait,
@@ -70,6 +72,7 @@ var (
 	// (config.alpha prefix) that can be applied to a pod or namespace.
 	ProxyAlphaConfigAnnotations = []string{
 		k8s.ProxyWaitBeforeExitSecondsAnnotation,
+		k8s.ProxyJobShutdownAnnotation,
 	}
 )
 
@@ -108,6 +111,23 @@ type ResourceConfig struct {
 	ownerRetriever OwnerRetrieverFunc
 	origin         Origin
 
+	// injectedContainers holds the names of the containers added by the
+	// last patch returned by GetPodPatch
+	injectedContainers []string
+
+	// namespace is matched against the injection policy rules
+	namespace struct {
+		name   string
+		labels map[string]string
+	}
+
+	// These ProxyConfigs are applied under the annotations, see
+	// proxyConfigLayers()
+	proxyConfigs struct {
+		namespace string
+		configs   []ProxyConfig
+	}
+
 	workload struct {
 		obj      runtime.Object
 		metaType metav1.TypeMeta
@@ -140,6 +160,38 @@ type podPatch struct {
 	AddRootVolumes        bool                      `json:"addRootVolumes"`
 	Labels                map[string]string         `json:"labels"`
 	DebugContainer        *l5dcharts.DebugContainer `json:"debugContainer"`
+	JobShutdown           *jobShutdown              `json:"jobShutdown"`
+}
+
+// jobShutdown holds the containers of the pod of a Job that get wrapped by
+// linkerd-await, so that the proxy is shut down once they exit
+type jobShutdown struct {
+	Containers []awaitWrapper `json:"containers"`
+}
+
+type awaitWrapper struct {
+	Index               int      `json:"index"`
+	Command             []string `json:"command"`
+	AddRootVolumeMounts bool     `json:"addRootVolumeMounts"`
+}
+
+// containerNames returns the names of the containers the patch adds to the
+// pod, init containers included
+func (p *podPatch) containerNames() []string {
+	names := []string{}
+	if p.ProxyInit != nil && !p.CNIEnabled {
+		names = append(names, k8s.InitContainerName)
+	}
+	if p.JobShutdown != nil {
+		names = append(names, k8s.AwaitContainerName)
+	}
+	if p.DebugContainer != nil {
+		names = append(names, k8s.DebugSidecarName)
+	}
+	if p.Proxy != nil {
+		names = append(names, k8s.ProxyContainerName)
+	}
+	return names
 }
 
 type annotationPatch struct {
@@ -260,6 +312,15 @@ func (conf *ResourceConfig) GetOverriddenValues() (*linkerd2.Values, error) {
 	return copyValues, nil
 }
 
+// GetValuesWithAnnotations returns a copy of values overridden by the given
+// proxy configuration annotations, as if they were set on a pod
+func GetValuesWithAnnotations(values *l5dcharts.Values, annotations map[string]string) (*l5dcharts.Values, error) {
+	conf := NewResourceConfig(values, OriginCLI)
+	conf.pod.meta.Annotations = annotations
+	conf.pod.spec = &corev1.PodSpec{}
+	return conf.GetOverriddenValues()
+}
+
 // GetPodPatch returns the JSON patch containing the proxy and init containers specs, if any.
 // If injectProxy is false, only the config.linkerd.io annotations are set.
 func (conf *ResourceConfig) GetPodPatch(injectProxy bool) ([]byte, error) {
@@ -299,6 +360,7 @@ func (conf *ResourceConfig) GetPodPatch(injectProxy bool) ([]byte, error) {
 		if injectProxy {
 			conf.injectObjectMeta(patch)
 			conf.injectPodSpec(patch)
+			conf.injectedContainers = patch.containerNames()
 		} else {
 			patch.Proxy = nil
 			patch.ProxyInit = nil
@@ -656,10 +718,57 @@ func (conf *ResourceConfig) injectPodSpec(values *podPatch) {
 		}
 	}
 
+	if values.Proxy.JobShutdown && conf.isJob() {
+		conf.injectJobShutdown(values)
+	}
+
 	conf.injectProxyInit(values)
 	values.AddRootVolumes = len(conf.pod.spec.Volumes) == 0
 }
 
+// isJob returns true if the pod belongs to a Job, whose completion requires
+// the proxy to exit once the other containers do
+func (conf *ResourceConfig) isJob() bool {
+	switch strings.ToLower(conf.workload.metaType.Kind) {
+	case k8s.Job, k8s.CronJob:
+		return true
+	}
+	if ref := conf.workload.ownerRef; ref != nil {
+		return ref.Kind == k8s.Job || ref.Kind == k8s.CronJob
+	}
+	return false
+}
+
+// injectJobShutdown wraps the containers of the pod with linkerd-await, which
+// shuts the proxy down when they exit. linkerd-await is copied into the pod by
+// an init container, as the images of the containers don't ship it.
+// Containers that don't set their command rely on the entrypoint of their
+// image, which is unknown here, so they're left as is.
+func (conf *ResourceConfig) injectJobShutdown(values *podPatch) {
+	wrappers := []awaitWrapper{}
+	for i, container := range conf.pod.spec.Containers {
+		if len(container.Command) == 0 {
+			log.Warnf("container %s doesn't set its command, so it can't shut down the proxy when it exits", container.Name)
+			continue
+		}
+		if container.Command[0] == k8s.AwaitBinaryPath {
+			continue
+		}
+		wrappers = append(wrappers, awaitWrapper{
+			Index:               i,
+			Command:             append(awaitCommand(values.Proxy.Ports.Admin), container.Command...),
+			AddRootVolumeMounts: len(container.VolumeMounts) == 0,
+		})
+	}
+	if len(wrappers) > 0 {
+		values.JobShutdown = &jobShutdown{Containers: wrappers}
+	}
+}
+
+func awaitCommand(adminPort int32) []string {
+	return []string{k8s.AwaitBinaryPath, "--port", fmt.Sprintf("%d", adminPort), "--shutdown", "--"}
+}
+
 func (conf *ResourceConfig) injectProxyInit(values *podPatch) {
 
 	// Fill common fields from Proxy into ProxyInit
@@ -733,6 +842,23 @@ func (conf *ResourceConfig) injectPodAnnotations(values *podPatch) {
 	values.AddRootMetadata = reflect.DeepEqual(conf.pod.meta, emptyMeta)
 	values.AddRootAnnotations = len(conf.pod.meta.Annotations) == 0
 
+	// The values set by the ProxyConfigs are written as annotations too, for
+	// the components that only read the pod annotations, like linkerd-cni
+	proxyConfigAnnotations := make(map[string]string)
+	for _, origin := range conf.proxyConfigLayers() {
+		proxyConfigAnnotations[origin.Annotation] = origin.Value
+	}
+	for _, k := range sortedKeys(proxyConfigAnnotations) {
+		if _, ok := conf.pod.meta.Annotations[k]; ok {
+			continue
+		}
+		if _, ok := conf.pod.annotations[k]; ok {
+			continue
+		}
+		values.Annotations[k] = proxyConfigAnnotations[k]
+		conf.pod.meta.Annotations[k] = proxyConfigAnnotations[k]
+	}
+
 	for _, k := range sortedKeys(conf.pod.annotations) {
 		values.Annotations[k] = conf.pod.annotations[k]
 
@@ -743,7 +869,12 @@ func (conf *ResourceConfig) injectPodAnnotations(values *podPatch) {
 }
 
 func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
+	// The ProxyConfigs come first, so that the annotations take precedence
 	annotations := make(map[string]string)
+	for _, origin := range conf.proxyConfigLayers() {
+		annotations[origin.Annotation] = origin.Value
+	}
+
 	for k, v := range conf.pod.meta.Annotations {
 		annotations[k] = v
 	}
@@ -756,6 +887,13 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 		for k, v := range conf.pod.annotations {
 			annotations[k] = v
 		}
+	} else {
+		// The flags take precedence over the ProxyConfigs as well
+		for k := range conf.pod.annotations {
+			if _, ok := conf.pod.meta.Annotations[k]; !ok {
+				delete(annotations, k)
+			}
+		}
 	}
 
 	if override, ok := annotations[k8s.ProxyInjectAnnotation]; ok {
@@ -780,33 +918,25 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 		values.ProxyInit.Image.Version = override
 	}
 
-	if override, ok := annotations[k8s.ProxyControlPortAnnotation]; ok {
-		controlPort, err := strconv.ParseInt(override, 10, 32)
-		if err == nil {
-			values.Proxy.Ports.Control = int32(controlPort)
-		}
-	}
-
-	if override, ok := annotations[k8s.ProxyInboundPortAnnotation]; ok {
-		inboundPort, err := strconv.ParseInt(override, 10, 32)
-		if err == nil {
-			values.Proxy.Ports.Inbound = int32(inboundPort)
-		}
-	}
-
-	if override, ok := annotations[k8s.ProxyAdminPortAnnotation]; ok {
-		adminPort, err := strconv.ParseInt(override, 10, 32)
-		if err == nil {
-			values.Proxy.Ports.Admin = int32(adminPort)
-		}
-	}
-
-	if override, ok := annotations[k8s.ProxyOutboundPortAnnotation]; ok {
-		outboundPort, err := strconv.ParseInt(override, 10, 32)
-		if err == nil {
-			values.Proxy.Ports.Outbound = int32(outboundPort)
-		}
-	}
+	// The ports, UID and skipped ports are shared with the CNI plugin, which
+	// parses them the same way
+	redirect := proxyinit.Config{
+		InboundPort:         values.Proxy.Ports.Inbound,
+		OutboundPort:        values.Proxy.Ports.Outbound,
+		ControlPort:         values.Proxy.Ports.Control,
+		AdminPort:           values.Proxy.Ports.Admin,
+		ProxyUID:            values.Proxy.UID,
+		IgnoreInboundPorts:  values.ProxyInit.IgnoreInboundPorts,
+		IgnoreOutboundPorts: values.ProxyInit.IgnoreOutboundPorts,
+	}
+	redirect.ApplyAnnotations(annotations)
+	values.Proxy.Ports.Inbound = redirect.InboundPort
+	values.Proxy.Ports.Outbound = redirect.OutboundPort
+	values.Proxy.Ports.Control = redirect.ControlPort
+	values.Proxy.Ports.Admin = redirect.AdminPort
+	values.Proxy.UID = redirect.ProxyUID
+	values.ProxyInit.IgnoreInboundPorts = redirect.IgnoreInboundPorts
+	values.ProxyInit.IgnoreOutboundPorts = redirect.IgnoreOutboundPorts
 
 	if override, ok := annotations[k8s.ProxyLogLevelAnnotation]; ok {
 		values.Proxy.LogLevel = override
@@ -904,13 +1034,6 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 		}
 	}
 
-	if override, ok := annotations[k8s.ProxyUIDAnnotation]; ok {
-		v, err := strconv.ParseInt(override, 10, 64)
-		if err == nil {
-			values.Proxy.UID = v
-		}
-	}
-
 	if override, ok := annotations[k8s.ProxyEnableExternalProfilesAnnotation]; ok {
 		value, err := strconv.ParseBool(override)
 		if err == nil {
@@ -926,14 +1049,6 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 		values.ProxyInit.Image.PullPolicy = override
 	}
 
-	if override, ok := annotations[k8s.ProxyIgnoreInboundPortsAnnotation]; ok {
-		values.ProxyInit.IgnoreInboundPorts = override
-	}
-
-	if override, ok := annotations[k8s.ProxyIgnoreOutboundPortsAnnotation]; ok {
-		values.ProxyInit.IgnoreOutboundPorts = override
-	}
-
 	if override, ok := annotations[k8s.ProxyOpaquePortsAnnotation]; ok {
 		opaquePortsStrs := util.ParseContainerOpaquePorts(override, conf.pod.spec.Containers)
 		values.Proxy.OpaquePorts = strings.Join(opaquePortsStrs, ",")
@@ -958,6 +1073,14 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 			log.Warnf("unrecognized value used for the %s annotation, valid values are: [%s, %s]", k8s.ProxyAwait, k8s.Enabled, k8s.Disabled)
 		}
 	}
+
+	if override, ok := annotations[k8s.ProxyJobShutdownAnnotation]; ok {
+		if override == k8s.Enabled || override == k8s.Disabled {
+			values.Proxy.JobShutdown = override == k8s.Enabled
+		} else {
+			log.Warnf("unrecognized value used for the %s annotation, valid values are: [%s, %s]", k8s.ProxyJobShutdownAnnotation, k8s.Enabled, k8s.Disabled)
+		}
+	}
 }
 
 // GetOverriddenConfiguration returns a map of the overridden proxy annotations
@@ -970,6 +1093,12 @@ func (conf *ResourceConfig) GetOverriddenConfiguration() map[string]string {
 	return proxyOverrideConfig
 }
 
+// InjectedContainers returns the names of the containers added to the pod by
+// the last patch returned by GetPodPatch
+func (conf *ResourceConfig) InjectedContainers() []string {
+	return conf.injectedContainers
+}
+
 // IsControlPlaneComponent returns true if the component is part of linkerd control plane
 func (conf *ResourceConfig) IsControlPlaneComponent() bool {
 	_, b := conf.pod.meta.Labels[k8s.ControllerComponentLabel]
@@ -0,0 +1,156 @@
+package inject
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// These constants are the actions of the injection policy rules
+const (
+	// PolicyDeny prevents the injection of the pods matching the rule
+	PolicyDeny = "deny"
+	// PolicyAllow injects the pods matching the rule even if no annotation
+	// enables their injection
+	PolicyAllow = "allow"
+	// PolicyEnforce injects the pods matching the rule even if an annotation
+	// disables their injection
+	PolicyEnforce = "enforce"
+)
+
+// WithNamespace enriches ResourceConfig with the name and labels of the
+// namespace of the workload, which the injection policy rules select
+func (conf *ResourceConfig) WithNamespace(ns *corev1.Namespace) *ResourceConfig {
+	conf.namespace.name = ns.Name
+	conf.namespace.labels = ns.Labels
+	return conf
+}
+
+// ValidateInjectionRule returns an error if the action, the patterns or the
+// selectors of the injection policy rule are invalid
+func ValidateInjectionRule(rule *l5dcharts.InjectionRule) error {
+	switch rule.Action {
+	case PolicyDeny, PolicyAllow, PolicyEnforce:
+	default:
+		return fmt.Errorf("unknown action %q, valid actions are: [%s, %s, %s]", rule.Action, PolicyDeny, PolicyAllow, PolicyEnforce)
+	}
+	for _, pattern := range append(rule.Namespaces, rule.Images...) {
+		if _, err := globToRegexp(pattern); err != nil {
+			return err
+		}
+	}
+	for _, selector := range []*metav1.LabelSelector{rule.NamespaceSelector, rule.PodSelector} {
+		if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InjectionRuleID returns the name of an injection policy rule, or its
+// position in the policy, e.g. "#1" for the first one, when it's unnamed
+func InjectionRuleID(rule *l5dcharts.InjectionRule, index int) string {
+	if rule.Name != "" {
+		return rule.Name
+	}
+	return fmt.Sprintf("#%d", index+1)
+}
+
+// matchInjectionPolicy returns the first injection policy rule matching the
+// pod, if any, along with its ID. An invalid rule is returned along with its
+// error, since it can't be told whether it would have matched the pod.
+func (conf *ResourceConfig) matchInjectionPolicy() (*l5dcharts.InjectionRule, string, error) {
+	if conf.values == nil || conf.values.ProxyInjector == nil {
+		return nil, "", nil
+	}
+	for i := range conf.values.ProxyInjector.InjectionPolicy {
+		rule := &conf.values.ProxyInjector.InjectionPolicy[i]
+		matches, err := conf.matchesRule(rule)
+		if err != nil {
+			return rule, InjectionRuleID(rule, i), err
+		}
+		if matches {
+			return rule, InjectionRuleID(rule, i), nil
+		}
+	}
+	return nil, "", nil
+}
+
+func (conf *ResourceConfig) matchesRule(rule *l5dcharts.InjectionRule) (bool, error) {
+	if err := ValidateInjectionRule(rule); err != nil {
+		return false, err
+	}
+
+	if len(rule.Namespaces) > 0 {
+		matches, err := matchesAny(rule.Namespaces, []string{conf.namespace.name})
+		if err != nil || !matches {
+			return false, err
+		}
+	}
+
+	if len(rule.Images) > 0 {
+		images := []string{}
+		for _, container := range append(conf.pod.spec.InitContainers, conf.pod.spec.Containers...) {
+			images = append(images, container.Image)
+		}
+		matches, err := matchesAny(rule.Images, images)
+		if err != nil || !matches {
+			return false, err
+		}
+	}
+
+	for _, s := range []struct {
+		selector *metav1.LabelSelector
+		labels   map[string]string
+	}{
+		{rule.NamespaceSelector, conf.namespace.labels},
+		{rule.PodSelector, conf.pod.meta.Labels},
+	} {
+		if s.selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(s.selector)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(labels.Set(s.labels)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// matchesAny returns true if any of the values matches any of the glob
+// patterns, in which '*' matches any sequence of characters, including '/',
+// and '?' any single character
+func matchesAny(patterns []string, values []string) (bool, error) {
+	for _, pattern := range patterns {
+		re, err := globToRegexp(pattern)
+		if err != nil {
+			return false, err
+		}
+		for _, value := range values {
+			if re.MatchString(value) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %s", pattern, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,274 @@
+package inject
+
+import (
+	"testing"
+
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestInjectionPolicy(t *testing.T) {
+	policy := []l5dcharts.InjectionRule{
+		{
+			Name:       "no-kube-system",
+			Action:     PolicyDeny,
+			Namespaces: []string{"kube-system"},
+		},
+		{
+			Name:   "no-legacy-images",
+			Action: PolicyDeny,
+			Images: []string{"registry.example.com/legacy/*"},
+		},
+		{
+			Name:   "no-opt-out-in-prod",
+			Action: PolicyEnforce,
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+		},
+		{
+			Name:       "teams",
+			Action:     PolicyAllow,
+			Namespaces: []string{"team-*"},
+			PodSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "linkerd-policy", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		namespace     corev1.Namespace
+		podAnnotation string
+		podLabels     map[string]string
+		image         string
+		injectable    bool
+		rule          string
+	}{
+		{
+			name:          "denies injection into kube-system",
+			namespace:     namespace("kube-system", k8s.ProxyInjectEnabled, nil),
+			podAnnotation: k8s.ProxyInjectEnabled,
+			rule:          "no-kube-system",
+		},
+		{
+			name:      "denies injection of matching images",
+			namespace: namespace("emojivoto", k8s.ProxyInjectEnabled, nil),
+			image:     "registry.example.com/legacy/app:v1",
+			rule:      "no-legacy-images",
+		},
+		{
+			name:          "enforces injection despite the annotation",
+			namespace:     namespace("payments", "", map[string]string{"env": "prod"}),
+			podAnnotation: k8s.ProxyInjectDisabled,
+			injectable:    true,
+			rule:          "no-opt-out-in-prod",
+		},
+		{
+			name:       "allows injection without annotation",
+			namespace:  namespace("team-a", "", nil),
+			injectable: true,
+			rule:       "teams",
+		},
+		{
+			name:          "allowed injection can be disabled by the pod",
+			namespace:     namespace("team-a", "", nil),
+			podAnnotation: k8s.ProxyInjectDisabled,
+			rule:          "teams",
+		},
+		{
+			name:      "allowed injection can be disabled by the namespace",
+			namespace: namespace("team-a", k8s.ProxyInjectDisabled, nil),
+			rule:      "teams",
+		},
+		{
+			name:      "pod selector doesn't match",
+			namespace: namespace("team-a", "", nil),
+			podLabels: map[string]string{"linkerd-policy": "none"},
+		},
+		{
+			name:          "annotations decide without matching rule",
+			namespace:     namespace("emojivoto", "", nil),
+			podAnnotation: k8s.ProxyInjectEnabled,
+			injectable:    true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := l5dcharts.NewValues()
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			values.ProxyInjector.InjectionPolicy = policy
+
+			image := tc.image
+			if image == "" {
+				image = "buoyantio/emojivoto-web:v11"
+			}
+			pod := corev1.Pod{
+				TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "web",
+					Labels:      tc.podLabels,
+					Annotations: map[string]string{},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "web",
+						Image: image,
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "token", MountPath: k8s.MountPathServiceAccount},
+						},
+					}},
+				},
+			}
+			if tc.podAnnotation != "" {
+				pod.Annotations[k8s.ProxyInjectAnnotation] = tc.podAnnotation
+			}
+			data, err := yaml.Marshal(&pod)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			conf := NewResourceConfig(values, OriginWebhook).
+				WithKind("Pod").
+				WithNsAnnotations(tc.namespace.Annotations).
+				WithNamespace(&tc.namespace)
+			report, err := conf.ParseMetaAndYAML(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if injectable, reasons := report.Injectable(); injectable != tc.injectable {
+				t.Errorf("Expected injectable to be %t, got %t (%v)", tc.injectable, injectable, reasons)
+			}
+			if report.PolicyRule != tc.rule {
+				t.Errorf("Expected rule %q, got %q", tc.rule, report.PolicyRule)
+			}
+		})
+	}
+}
+
+func TestInjectionPolicyDecision(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		policy   []l5dcharts.InjectionRule
+		decision string
+	}{
+		{
+			name: "invalid rule",
+			policy: []l5dcharts.InjectionRule{
+				{Name: "invalid", Action: "inject", Namespaces: []string{"kube-system"}},
+			},
+			decision: `injection policy rule "invalid" is invalid, which denies the injection: unknown action "inject", valid actions are: [deny, allow, enforce]`,
+		},
+		{
+			name: "invalid unnamed rule",
+			policy: []l5dcharts.InjectionRule{
+				{Name: "no-kube-system", Action: PolicyDeny, Namespaces: []string{"kube-system"}},
+				{Action: "inject", Namespaces: []string{"kube-system"}},
+			},
+			decision: `injection policy rule "#2" is invalid, which denies the injection: unknown action "inject", valid actions are: [deny, allow, enforce]`,
+		},
+		{
+			name: "unnamed rule",
+			policy: []l5dcharts.InjectionRule{
+				{Action: PolicyDeny, Namespaces: []string{"emojivoto"}},
+			},
+			decision: `injection policy rule "#1" denies the injection`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := l5dcharts.NewValues()
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			values.ProxyInjector.InjectionPolicy = tc.policy
+			pod := corev1.Pod{
+				TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "web",
+					Annotations: map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "buoyantio/emojivoto-web:v11"}}},
+			}
+			data, err := yaml.Marshal(&pod)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ns := namespace("emojivoto", "", nil)
+
+			conf := NewResourceConfig(values, OriginWebhook).
+				WithKind("Pod").
+				WithNsAnnotations(ns.Annotations).
+				WithNamespace(&ns)
+			report, err := conf.ParseMetaAndYAML(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if injectable, _ := report.Injectable(); injectable {
+				t.Error("Expected the injection policy to deny the injection")
+			}
+			if decision := report.PolicyDecision(); decision != tc.decision {
+				t.Errorf("Expected decision %q, got %q", tc.decision, decision)
+			}
+		})
+	}
+}
+
+func TestValidateInjectionRule(t *testing.T) {
+	for _, tc := range []struct {
+		rule l5dcharts.InjectionRule
+		err  string
+	}{
+		{
+			rule: l5dcharts.InjectionRule{Name: "valid", Action: PolicyDeny, Namespaces: []string{"kube-*"}},
+		},
+		{
+			rule: l5dcharts.InjectionRule{Name: "unknown-action", Action: "inject"},
+			err:  `unknown action "inject", valid actions are: [deny, allow, enforce]`,
+		},
+		{
+			rule: l5dcharts.InjectionRule{
+				Name:   "invalid-selector",
+				Action: PolicyAllow,
+				PodSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Bogus"}},
+				},
+			},
+			err: `"Bogus" is not a valid pod selector operator`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.rule.Name, func(t *testing.T) {
+			err := ValidateInjectionRule(&tc.rule)
+			if tc.err == "" && err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if tc.err != "" && (err == nil || err.Error() != tc.err) {
+				t.Fatalf("Expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func namespace(name, injectAnnotation string, labels map[string]string) corev1.Namespace {
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: map[string]string{},
+		},
+	}
+	if injectAnnotation != "" {
+		ns.Annotations[k8s.ProxyInjectAnnotation] = injectAnnotation
+	}
+	return ns
+}
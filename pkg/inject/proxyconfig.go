@@ -0,0 +1,220 @@
+package inject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// These constants are the layers a proxy configuration annotation can be set
+// at, from the lowest to the highest precedence. The annotations set at none of
+// them are left to the values the control plane was installed with.
+const (
+	// LayerCluster is the layer of the default ProxyConfig of the control
+	// plane namespace
+	LayerCluster = "cluster"
+	// LayerNamespace is the layer of the ProxyConfigs of a namespace that
+	// don't have a selector
+	LayerNamespace = "namespace"
+	// LayerWorkload is the layer of the ProxyConfigs whose selector matches
+	// the labels of a pod
+	LayerWorkload = "workload"
+	// LayerAnnotation is the layer of the annotations of a pod, including the
+	// ones it inherits from its namespace
+	LayerAnnotation = "annotation"
+)
+
+// ProxyConfig is an internal representation of the
+// proxyconfigs.config.linkerd.io custom resource. It holds proxy configuration
+// annotations that apply to the pods of its namespace, or only to the ones
+// matching its selector, unless they're overridden by the pods' annotations.
+// The ProxyConfig named "default" in the control plane namespace applies to
+// the pods of all namespaces.
+type ProxyConfig struct {
+	Name      string
+	Namespace string
+	// Selector is nil when the ProxyConfig applies to the whole namespace
+	Selector    labels.Selector
+	Annotations map[string]string
+}
+
+// ConfigOrigin tells which layer set a proxy configuration annotation when
+// injecting a workload.
+type ConfigOrigin struct {
+	Annotation string
+	Value      string
+	Layer      string
+	// Source is the object that set the annotation at that layer
+	Source string
+}
+
+// NewProxyConfig parses an unstructured proxyconfigs.config.linkerd.io
+// resource and converts it to a structured internal representation.
+func NewProxyConfig(u unstructured.Unstructured) (ProxyConfig, error) {
+	spec, ok := u.Object["spec"].(map[string]interface{})
+	if !ok {
+		return ProxyConfig{}, errors.New("Field 'spec' is missing or not an object")
+	}
+
+	config := ProxyConfig{
+		Name:        u.GetName(),
+		Namespace:   u.GetNamespace(),
+		Annotations: make(map[string]string),
+	}
+
+	if selectorObj, ok := spec["selector"]; ok {
+		bytes, err := json.Marshal(selectorObj)
+		if err != nil {
+			return ProxyConfig{}, err
+		}
+		selector := metav1.LabelSelector{}
+		if err := json.Unmarshal(bytes, &selector); err != nil {
+			return ProxyConfig{}, err
+		}
+		config.Selector, err = metav1.LabelSelectorAsSelector(&selector)
+		if err != nil {
+			return ProxyConfig{}, err
+		}
+	}
+
+	annotations, ok := spec["annotations"].(map[string]interface{})
+	if !ok {
+		return ProxyConfig{}, errors.New("Field 'annotations' is missing or not an object")
+	}
+	for key, value := range annotations {
+		if !IsProxyConfigAnnotation(key) {
+			return ProxyConfig{}, fmt.Errorf("%s is not a proxy configuration annotation", key)
+		}
+		str, ok := value.(string)
+		if !ok {
+			return ProxyConfig{}, fmt.Errorf("the value of %s is not a string", key)
+		}
+		config.Annotations[key] = str
+	}
+
+	return config, nil
+}
+
+func (pc ProxyConfig) String() string {
+	return fmt.Sprintf("ProxyConfig %s/%s", pc.Namespace, pc.Name)
+}
+
+// IsProxyConfigAnnotation returns true if the key is one of the annotations
+// configuring the proxy
+func IsProxyConfigAnnotation(key string) bool {
+	for _, annotation := range append(ProxyAnnotations, ProxyAlphaConfigAnnotations...) {
+		if key == annotation {
+			return true
+		}
+	}
+	return false
+}
+
+// WithProxyConfigs enriches ResourceConfig with the ProxyConfigs that may apply
+// to the workload, which lives in the given namespace. If namespace is empty,
+// the namespace of the workload's manifest is used.
+func (conf *ResourceConfig) WithProxyConfigs(namespace string, configs []ProxyConfig) *ResourceConfig {
+	conf.proxyConfigs.namespace = namespace
+	conf.proxyConfigs.configs = configs
+	return conf
+}
+
+// proxyConfigLayers returns the ProxyConfigs applying to the pod, from the
+// lowest to the highest precedence. ProxyConfigs of the same layer are sorted
+// by name, so that the last one wins when they set the same annotation.
+func (conf *ResourceConfig) proxyConfigLayers() []ConfigOrigin {
+	namespace := conf.proxyConfigs.namespace
+	if namespace == "" {
+		namespace = conf.workload.Meta.Namespace
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	var controlPlaneNamespace string
+	if conf.values != nil {
+		controlPlaneNamespace = conf.values.Namespace
+	}
+
+	var cluster, ns, workload []ProxyConfig
+	for _, pc := range conf.proxyConfigs.configs {
+		switch {
+		case pc.Namespace == controlPlaneNamespace && pc.Name == k8s.ProxyConfigDefaultName:
+			cluster = append(cluster, pc)
+		case pc.Namespace != namespace:
+			continue
+		case pc.Selector == nil:
+			ns = append(ns, pc)
+		case pc.Selector.Matches(labels.Set(conf.pod.meta.Labels)):
+			workload = append(workload, pc)
+		}
+	}
+
+	origins := []ConfigOrigin{}
+	for _, layer := range []struct {
+		name    string
+		configs []ProxyConfig
+	}{
+		{LayerCluster, cluster},
+		{LayerNamespace, ns},
+		{LayerWorkload, workload},
+	} {
+		sort.Slice(layer.configs, func(i, j int) bool { return layer.configs[i].Name < layer.configs[j].Name })
+		for _, pc := range layer.configs {
+			for _, key := range sortedKeys(pc.Annotations) {
+				origins = append(origins, ConfigOrigin{
+					Annotation: key,
+					Value:      pc.Annotations[key],
+					Layer:      layer.name,
+					Source:     pc.String(),
+				})
+			}
+		}
+	}
+	return origins
+}
+
+// ExplainConfig returns, for each proxy configuration annotation that isn't
+// left to its default value, the value the workload gets injected with and
+// the layer that set it.
+func (conf *ResourceConfig) ExplainConfig() []ConfigOrigin {
+	origins := make(map[string]ConfigOrigin)
+	for _, origin := range conf.proxyConfigLayers() {
+		origins[origin.Annotation] = origin
+	}
+
+	for _, key := range append(ProxyAnnotations, ProxyAlphaConfigAnnotations...) {
+		if value, ok := conf.nsAnnotations[key]; ok {
+			origins[key] = ConfigOrigin{key, value, LayerAnnotation, "namespace annotation"}
+		}
+		if value, ok := conf.pod.annotations[key]; ok && !conf.inheritedFromNamespace(key, value) {
+			source := "pod annotation"
+			if conf.origin == OriginCLI {
+				source = "linkerd inject flag"
+			}
+			origins[key] = ConfigOrigin{key, value, LayerAnnotation, source}
+		}
+		if value, ok := conf.pod.meta.Annotations[key]; ok {
+			origins[key] = ConfigOrigin{key, value, LayerAnnotation, "pod annotation"}
+		}
+	}
+
+	explained := []ConfigOrigin{}
+	for _, origin := range origins {
+		explained = append(explained, origin)
+	}
+	sort.Slice(explained, func(i, j int) bool { return explained[i].Annotation < explained[j].Annotation })
+	return explained
+}
+
+// inheritedFromNamespace returns true if the annotation to be added to the pod
+// was copied from its namespace by AppendNamespaceAnnotations
+func (conf *ResourceConfig) inheritedFromNamespace(key, value string) bool {
+	nsValue, ok := conf.nsAnnotations[key]
+	return ok && nsValue == value && conf.origin != OriginCLI
+}
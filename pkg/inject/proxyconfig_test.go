@@ -0,0 +1,201 @@
+package inject
+
+import (
+	"reflect"
+	"testing"
+
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+func TestNewProxyConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		spec     map[string]interface{}
+		selector bool
+		err      bool
+	}{
+		{
+			name: "namespace-wide",
+			spec: map[string]interface{}{
+				"annotations": map[string]interface{}{k8s.ProxyLogLevelAnnotation: "debug"},
+			},
+		},
+		{
+			name: "with a selector",
+			spec: map[string]interface{}{
+				"selector":    map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}},
+				"annotations": map[string]interface{}{k8s.ProxyCPURequestAnnotation: "100m"},
+			},
+			selector: true,
+		},
+		{
+			name: "missing annotations",
+			spec: map[string]interface{}{},
+			err:  true,
+		},
+		{
+			name: "not a proxy configuration annotation",
+			spec: map[string]interface{}{
+				"annotations": map[string]interface{}{k8s.ProxyInjectAnnotation: "enabled"},
+			},
+			err: true,
+		},
+		{
+			name: "invalid selector",
+			spec: map[string]interface{}{
+				"selector": map[string]interface{}{"matchExpressions": []interface{}{
+					map[string]interface{}{"key": "app", "operator": "Bogus"},
+				}},
+				"annotations": map[string]interface{}{k8s.ProxyLogLevelAnnotation: "debug"},
+			},
+			err: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			u := unstructured.Unstructured{Object: map[string]interface{}{"spec": tc.spec}}
+			u.SetName("config")
+			u.SetNamespace("ns")
+
+			config, err := NewProxyConfig(u)
+			if tc.err {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if config.Name != "config" || config.Namespace != "ns" {
+				t.Errorf("Unexpected name: %s", config)
+			}
+			if (config.Selector != nil) != tc.selector {
+				t.Errorf("Expected a selector: %t, got %v", tc.selector, config.Selector)
+			}
+			if len(config.Annotations) != 1 {
+				t.Errorf("Unexpected annotations: %v", config.Annotations)
+			}
+		})
+	}
+}
+
+func TestProxyConfigPrecedence(t *testing.T) {
+	values, err := l5dcharts.NewValues()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	values.Namespace = "linkerd"
+	values.IdentityTrustAnchorsPEM = "trust anchors"
+
+	configs := []ProxyConfig{
+		{
+			Name:      k8s.ProxyConfigDefaultName,
+			Namespace: "linkerd",
+			Annotations: map[string]string{
+				k8s.ProxyLogLevelAnnotation:      "warn",
+				k8s.ProxyCPURequestAnnotation:    "10m",
+				k8s.ProxyMemoryRequestAnnotation: "10Mi",
+				k8s.ProxyCPULimitAnnotation:      "1",
+			},
+		},
+		{
+			Name:      "team",
+			Namespace: "emojivoto",
+			Annotations: map[string]string{
+				k8s.ProxyCPURequestAnnotation:    "20m",
+				k8s.ProxyMemoryRequestAnnotation: "20Mi",
+			},
+		},
+		{
+			Name:        "web",
+			Namespace:   "emojivoto",
+			Selector:    labels.SelectorFromSet(labels.Set{"app": "web"}),
+			Annotations: map[string]string{k8s.ProxyCPURequestAnnotation: "30m"},
+		},
+		{
+			Name:        "voting",
+			Namespace:   "emojivoto",
+			Selector:    labels.SelectorFromSet(labels.Set{"app": "voting"}),
+			Annotations: map[string]string{k8s.ProxyCPURequestAnnotation: "40m"},
+		},
+		{
+			Name:        "other",
+			Namespace:   "other",
+			Annotations: map[string]string{k8s.ProxyCPURequestAnnotation: "50m"},
+		},
+	}
+
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "emojivoto"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "web"},
+					Annotations: map[string]string{k8s.ProxyCPULimitAnnotation: "2"},
+				},
+			},
+		},
+	}
+	data, err := yaml.Marshal(&deployment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf := NewResourceConfig(values, OriginWebhook).
+		WithKind("Deployment").
+		WithNsAnnotations(map[string]string{k8s.ProxyLogLevelAnnotation: "info"}).
+		WithProxyConfigs("emojivoto", configs)
+	if err := conf.parse(data); err != nil {
+		t.Fatal(err)
+	}
+	conf.AppendNamespaceAnnotations()
+
+	overridden, err := conf.GetOverriddenValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overridden.Proxy.LogLevel != "info" {
+		t.Errorf("Expected the namespace annotation to win, got log level %s", overridden.Proxy.LogLevel)
+	}
+	if overridden.Proxy.Resources.CPU.Request != "30m" {
+		t.Errorf("Expected the workload ProxyConfig to win, got CPU request %s", overridden.Proxy.Resources.CPU.Request)
+	}
+	if overridden.Proxy.Resources.Memory.Request != "20Mi" {
+		t.Errorf("Expected the namespace ProxyConfig to win, got memory request %s", overridden.Proxy.Resources.Memory.Request)
+	}
+	if overridden.Proxy.Resources.CPU.Limit != "2" {
+		t.Errorf("Expected the pod annotation to win, got CPU limit %s", overridden.Proxy.Resources.CPU.Limit)
+	}
+
+	expected := []ConfigOrigin{
+		{k8s.ProxyCPULimitAnnotation, "2", LayerAnnotation, "pod annotation"},
+		{k8s.ProxyCPURequestAnnotation, "30m", LayerWorkload, "ProxyConfig emojivoto/web"},
+		{k8s.ProxyLogLevelAnnotation, "info", LayerAnnotation, "namespace annotation"},
+		{k8s.ProxyMemoryRequestAnnotation, "20Mi", LayerNamespace, "ProxyConfig emojivoto/team"},
+	}
+	if actual := conf.ExplainConfig(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected origins\n%+v\nbut got\n%+v", expected, actual)
+	}
+
+	if _, err := conf.GetPodPatch(true); err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range map[string]string{
+		k8s.ProxyCPULimitAnnotation:      "2",
+		k8s.ProxyCPURequestAnnotation:    "30m",
+		k8s.ProxyLogLevelAnnotation:      "info",
+		k8s.ProxyMemoryRequestAnnotation: "20Mi",
+	} {
+		if actual := conf.pod.meta.Annotations[key]; actual != value {
+			t.Errorf("Expected the pod to be annotated with %s=%s, got %q", key, value, actual)
+		}
+	}
+}
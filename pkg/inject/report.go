@@ -22,6 +22,8 @@ const (
 	invalidInjectAnnotationNamespace     = "invalid_inject_annotation_at_ns"
 	disabledAutomountServiceAccountToken = "disabled_automount_service_account_token_account"
 	udpPortsEnabled                      = "udp_ports_enabled"
+	injectDeniedByPolicy                 = "injection_denied_by_policy"
+	invalidInjectionPolicy               = "invalid_injection_policy"
 )
 
 var (
@@ -36,6 +38,8 @@ var (
 		invalidInjectAnnotationNamespace:     fmt.Sprintf("invalid value for annotation \"%s\" at namespace", k8s.ProxyInjectAnnotation),
 		disabledAutomountServiceAccountToken: "automountServiceAccountToken set to \"false\"",
 		udpPortsEnabled:                      "UDP port(s) configured on pod spec",
+		injectDeniedByPolicy:                 "an injection policy rule denies it",
+		invalidInjectionPolicy:               "the injection policy is invalid",
 	}
 )
 
@@ -55,6 +59,24 @@ type Report struct {
 	Annotated                    bool
 	AutomountServiceAccountToken bool
 
+	// PolicyRule is the ID of the injection policy rule that decided of the
+	// injection of the workload, if any, and PolicyAction its action
+	PolicyRule   string
+	PolicyAction string
+	// PolicyError is set when the injection policy is invalid, which denies
+	// the injection
+	PolicyError string
+
+	// InjectedContainers are the names of the containers added to the pod
+	// and OverriddenConfig the proxy configuration annotations it's
+	// injected with
+	InjectedContainers []string
+	OverriddenConfig   map[string]string
+
+	// ConfigOrigins tells which layer set each proxy configuration value,
+	// when the injection was asked to be explained
+	ConfigOrigins []ConfigOrigin
+
 	// Uninjected consists of two boolean flags to indicate if a proxy and
 	// proxy-init containers have been uninjected in this report
 	Uninjected struct {
@@ -87,6 +109,9 @@ func newReport(conf *ResourceConfig) *Report {
 
 	if conf.HasPodTemplate() {
 		report.InjectDisabled, report.InjectDisabledReason, report.InjectAnnotationAt = report.disabledByAnnotation(conf)
+		if conf.origin == OriginWebhook {
+			report.applyInjectionPolicy(conf)
+		}
 		report.HostNetwork = conf.pod.spec.HostNetwork
 		report.Sidecar = healthcheck.HasExistingSidecars(conf.pod.spec)
 		report.UDP = checkUDPPorts(conf.pod.spec)
@@ -142,6 +167,60 @@ func (r *Report) Injectable() (bool, []string) {
 	return true, nil
 }
 
+// applyInjectionPolicy overrides the decision of the inject annotations with
+// the one of the first injection policy rule matching the pod, if any
+func (r *Report) applyInjectionPolicy(conf *ResourceConfig) {
+	rule, id, err := conf.matchInjectionPolicy()
+	if err != nil {
+		// fail closed rather than letting through the pods the rule may
+		// have been meant to deny
+		r.PolicyRule = id
+		r.PolicyAction = PolicyDeny
+		r.PolicyError = err.Error()
+		r.InjectDisabled = true
+		r.InjectDisabledReason = invalidInjectionPolicy
+		return
+	}
+	if rule == nil {
+		return
+	}
+	r.PolicyRule = id
+	r.PolicyAction = rule.Action
+
+	switch rule.Action {
+	case PolicyDeny:
+		r.InjectDisabled = true
+		r.InjectDisabledReason = injectDeniedByPolicy
+	case PolicyAllow:
+		// the pod and its namespace may still disable the injection
+		if r.InjectDisabledReason == injectEnableAnnotationAbsent &&
+			conf.nsAnnotations[k8s.ProxyInjectAnnotation] != k8s.ProxyInjectDisabled &&
+			conf.pod.meta.Annotations[k8s.ProxyInjectAnnotation] != k8s.ProxyInjectDisabled {
+			r.InjectDisabled = false
+			r.InjectDisabledReason = ""
+		}
+	case PolicyEnforce:
+		r.InjectDisabled = false
+		r.InjectDisabledReason = ""
+	}
+}
+
+// PolicyDecision describes the decision of the injection policy, if any
+func (r *Report) PolicyDecision() string {
+	if r.PolicyRule == "" {
+		return ""
+	}
+	if r.PolicyError != "" {
+		return fmt.Sprintf("injection policy rule %q is invalid, which denies the injection: %s", r.PolicyRule, r.PolicyError)
+	}
+	verbs := map[string]string{
+		PolicyDeny:    "denies",
+		PolicyAllow:   "allows",
+		PolicyEnforce: "enforces",
+	}
+	return fmt.Sprintf("injection policy rule %q %s the injection", r.PolicyRule, verbs[r.PolicyAction])
+}
+
 // IsAnnotatable returns true if the resource for a report can be annotated.
 func (r *Report) IsAnnotatable() bool {
 	return r.Annotatable
@@ -184,7 +263,10 @@ func (r *Report) disabledByAnnotation(conf *ResourceConfig) (bool, string, strin
 	nsAnnotation := conf.nsAnnotations[k8s.ProxyInjectAnnotation]
 
 	if conf.origin == OriginCLI {
-		return podAnnotation == k8s.ProxyInjectDisabled, "", ""
+		if podAnnotation == k8s.ProxyInjectDisabled {
+			return true, injectDisableAnnotationPresent, ""
+		}
+		return false, "", ""
 	}
 
 	if !isInjectAnnotationValid(nsAnnotation) {
@@ -36,6 +36,9 @@ func (conf *ResourceConfig) uninjectPodSpec(report *Report) {
 	t := conf.pod.spec
 	initContainers := []v1.Container{}
 	for _, container := range t.InitContainers {
+		if container.Name == k8s.AwaitContainerName {
+			continue
+		}
 		if container.Name != k8s.InitContainerName {
 			initContainers = append(initContainers, container)
 		} else {
@@ -47,7 +50,7 @@ func (conf *ResourceConfig) uninjectPodSpec(report *Report) {
 	containers := []v1.Container{}
 	for _, container := range t.Containers {
 		if container.Name != k8s.ProxyContainerName {
-			containers = append(containers, container)
+			containers = append(containers, unwrapAwait(container))
 		} else {
 			report.Uninjected.Proxy = true
 		}
@@ -56,13 +59,39 @@ func (conf *ResourceConfig) uninjectPodSpec(report *Report) {
 
 	volumes := []v1.Volume{}
 	for _, volume := range t.Volumes {
-		if volume.Name != k8s.IdentityEndEntityVolumeName && volume.Name != k8s.InitXtablesLockVolumeMountName {
+		if volume.Name != k8s.IdentityEndEntityVolumeName && volume.Name != k8s.InitXtablesLockVolumeMountName && volume.Name != k8s.AwaitVolumeName {
 			volumes = append(volumes, volume)
 		}
 	}
 	t.Volumes = volumes
 }
 
+// unwrapAwait restores the command of a container wrapped by linkerd-await
+// when injecting the pod of a Job
+func unwrapAwait(container v1.Container) v1.Container {
+	if len(container.Command) == 0 || container.Command[0] != k8s.AwaitBinaryPath {
+		return container
+	}
+	for i, arg := range container.Command {
+		if arg == "--" {
+			container.Command = container.Command[i+1:]
+			break
+		}
+	}
+
+	mounts := []v1.VolumeMount{}
+	for _, mount := range container.VolumeMounts {
+		if mount.Name != k8s.AwaitVolumeName {
+			mounts = append(mounts, mount)
+		}
+	}
+	if len(mounts) == 0 {
+		mounts = nil
+	}
+	container.VolumeMounts = mounts
+	return container
+}
+
 func uninjectObjectMeta(t *metav1.ObjectMeta, report *Report) {
 	// We only uninject control plane components in the context
 	// of doing an inject --manual. This is done as a way to update
@@ -34,6 +34,12 @@ func FetchIssuerData(ctx context.Context, api kubernetes.Interface, trustAnchors
 		return nil, err
 	}
 
+	return IssuerDataFromSecret(secret, trustAnchors)
+}
+
+// IssuerDataFromSecret reads the issuer data from a linkerd-identity-issuer
+// secret (used for linkerd.io/tls schemed secrets)
+func IssuerDataFromSecret(secret *corev1.Secret, trustAnchors string) (*IssuerCertData, error) {
 	crt, ok := secret.Data[k8s.IdentityIssuerCrtName]
 	if !ok {
 		return nil, fmt.Errorf(keyMissingError, k8s.IdentityIssuerCrtName, "issuer certificate", k8s.IdentityIssuerSecretName, false)
@@ -59,6 +65,12 @@ func FetchExternalIssuerData(ctx context.Context, api kubernetes.Interface, cont
 		return nil, err
 	}
 
+	return ExternalIssuerDataFromSecret(secret)
+}
+
+// ExternalIssuerDataFromSecret reads the issuer data from a
+// linkerd-identity-issuer secret (used for kubernetes.io/tls schemed secrets)
+func ExternalIssuerDataFromSecret(secret *corev1.Secret) (*IssuerCertData, error) {
 	anchors, ok := secret.Data[k8s.IdentityIssuerTrustAnchorsNameExternal]
 	if !ok {
 		return nil, fmt.Errorf(keyMissingError, k8s.IdentityIssuerTrustAnchorsNameExternal, "trust anchors", k8s.IdentityIssuerSecretName, true)
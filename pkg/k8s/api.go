@@ -275,6 +275,64 @@ func GetProxyReady(pod corev1.Pod) bool {
 	return false
 }
 
+// IsMissingCNIRedirect returns true if the pod is injected and relies on the
+// CNI plugin to redirect its traffic to the proxy, but the plugin didn't record
+// having done so. This happens to the pods whose network was set up while the
+// plugin wasn't ready or configured on their node, e.g. because they were
+// scheduled on a new node before the plugin, or because another CNI plugin
+// overwrote its configuration, whose traffic bypasses the proxy.
+func IsMissingCNIRedirect(pod corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Spec.HostNetwork || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	if _, ok := pod.Annotations[CNIRedirectedAnnotation]; ok {
+		return false
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == InitContainerName {
+			return false
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == ProxyContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMissingJobShutdown returns true if the meshed pod belongs to a Job but
+// some of its containers aren't wrapped by linkerd-await, so that the proxy
+// keeps running once they exit and the pod never completes. It's false for the
+// pods whose job shutdown was explicitly disabled.
+func IsMissingJobShutdown(pod corev1.Pod) bool {
+	if pod.Annotations[ProxyJobShutdownAnnotation] == Disabled {
+		return false
+	}
+	isJob := false
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "Job" {
+			isJob = true
+		}
+	}
+	if !isJob {
+		return false
+	}
+
+	meshed := false
+	missing := false
+	for _, container := range pod.Spec.Containers {
+		switch {
+		case container.Name == ProxyContainerName:
+			meshed = true
+		case container.Name == DebugSidecarName:
+		case len(container.Command) == 0 || container.Command[0] != AwaitBinaryPath:
+			missing = true
+		}
+	}
+	return meshed && missing
+}
+
 // GetProxyVersion returns the container proxy's version, if any
 func GetProxyVersion(pod corev1.Pod) string {
 	for _, container := range pod.Spec.Containers {
@@ -1,6 +1,7 @@
 package k8s
 
 import (
+	"fmt"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -303,3 +304,245 @@ status:
 		}
 	}
 }
+
+func TestIsMissingCNIRedirect(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		pod      string
+		expected bool
+	}{
+		{
+			desc:     "Proxy is running without redirection",
+			expected: true,
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  containers:
+  - name: linkerd-proxy
+status:
+  phase: Running
+  startTime: 1995-02-10T00:42:00Z
+  containerStatuses:
+  - name: linkerd-proxy
+    state:
+      running:
+        startedAt: 1995-02-10T00:42:42Z
+`,
+		},
+		{
+			desc:     "Proxy is running with redirection",
+			expected: false,
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+  annotations:
+    linkerd.io/cni-redirected: ipv4
+spec:
+  containers:
+  - name: linkerd-proxy
+status:
+  phase: Running
+  startTime: 1995-02-10T00:42:00Z
+  containerStatuses:
+  - name: linkerd-proxy
+    state:
+      running:
+        startedAt: 1995-02-10T00:42:42Z
+`,
+		},
+		{
+			desc:     "Pod started before the plugin",
+			expected: true,
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  containers:
+  - name: linkerd-proxy
+status:
+  phase: Running
+  startTime: 1995-02-09T00:42:00Z
+  containerStatuses:
+  - name: linkerd-proxy
+    state:
+      running:
+        startedAt: 1995-02-10T00:42:42Z
+`,
+		},
+		{
+			desc:     "Redirection is set up by the init container",
+			expected: false,
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  initContainers:
+  - name: linkerd-init
+  containers:
+  - name: linkerd-proxy
+status:
+  phase: Running
+  startTime: 1995-02-10T00:42:00Z
+  containerStatuses:
+  - name: linkerd-proxy
+    state:
+      running:
+        startedAt: 1995-02-10T00:42:42Z
+`,
+		},
+		{
+			desc:     "Pod isn't meshed",
+			expected: false,
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  containers:
+  - name: emoji
+status:
+  phase: Running
+  startTime: 1995-02-10T00:42:00Z
+  containerStatuses:
+  - name: emoji
+    state:
+      running:
+        startedAt: 1995-02-10T00:42:42Z
+`,
+		},
+		{
+			desc:     "Pod uses the host network",
+			expected: false,
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  hostNetwork: true
+  containers:
+  - name: linkerd-proxy
+status:
+  phase: Running
+  startTime: 1995-02-10T00:42:00Z
+  containerStatuses:
+  - name: linkerd-proxy
+    state:
+      running:
+        startedAt: 1995-02-10T00:42:42Z
+`,
+		},
+	}
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.desc, func(t *testing.T) {
+			obj, err := ToRuntimeObject(s.pod)
+			if err != nil {
+				t.Fatalf("could not decode yml: %s", err)
+			}
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				t.Fatalf("could not convert returned object to pod")
+			}
+			if got := IsMissingCNIRedirect(*pod); got != s.expected {
+				t.Fatalf("Expected %t, got %t", s.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsMissingJobShutdown(t *testing.T) {
+	pod := func(annotations, containers string) string {
+		return fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: vote-bot-once-x2k8p
+  namespace: emojivoto
+  annotations: {%s}
+  ownerReferences:
+  - apiVersion: batch/v1
+    kind: Job
+    name: vote-bot-once
+    uid: 7ef04a5c-6b4e-4d4c-9d0c-0f0b1b7d3a51
+spec:
+  containers:
+  - name: linkerd-proxy%s`, annotations, containers)
+	}
+
+	scenarios := []struct {
+		desc     string
+		pod      string
+		expected bool
+	}{
+		{
+			desc:     "Containers are wrapped by linkerd-await",
+			expected: false,
+			pod: pod("", `
+  - name: vote-bot
+    command: [/var/run/linkerd/await/linkerd-await, --shutdown, --, emojivoto-vote-bot]`),
+		},
+		{
+			desc:     "A container isn't wrapped",
+			expected: true,
+			pod: pod("", `
+  - name: vote-bot
+    command: [/var/run/linkerd/await/linkerd-await, --shutdown, --, emojivoto-vote-bot]
+  - name: sidecar
+    args: [date]`),
+		},
+		{
+			desc:     "Job shutdown is disabled",
+			expected: false,
+			pod: pod("config.alpha.linkerd.io/proxy-job-shutdown: disabled", `
+  - name: sidecar
+    args: [date]`),
+		},
+		{
+			desc:     "Pod doesn't belong to a Job",
+			expected: false,
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  containers:
+  - name: linkerd-proxy
+  - name: emoji`,
+		},
+	}
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.desc, func(t *testing.T) {
+			obj, err := ToRuntimeObject(s.pod)
+			if err != nil {
+				t.Fatalf("could not decode yml: %s", err)
+			}
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				t.Fatalf("could not convert returned object to pod")
+			}
+			if got := IsMissingJobShutdown(*pod); got != s.expected {
+				t.Fatalf("Expected %t, got %t", s.expected, got)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package k8s
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -33,10 +34,34 @@ const (
 	LinkAPIGroupVersion = "multicluster.linkerd.io/v1alpha1"
 	LinkKind            = "Link"
 
+	ProxyConfigAPIGroup        = "config.linkerd.io"
+	ProxyConfigAPIVersion      = "v1alpha1"
+	ProxyConfigAPIGroupVersion = "config.linkerd.io/v1alpha1"
+	ProxyConfigKind            = "ProxyConfig"
+	// ProxyConfigDefaultName is the name of the ProxyConfig of the control
+	// plane namespace holding the cluster-wide defaults
+	ProxyConfigDefaultName = "default"
+
 	// special case k8s job label, to not conflict with Prometheus' job label
 	l5dJob = "k8s_job"
 )
 
+// ProxyConfigGVR is the Group Version and Resource of the ProxyConfig custom
+// resource.
+var ProxyConfigGVR = schema.GroupVersionResource{
+	Group:    ProxyConfigAPIGroup,
+	Version:  ProxyConfigAPIVersion,
+	Resource: "proxyconfigs",
+}
+
+// ServiceProfileGVR is the Group Version and Resource of the ServiceProfile
+// custom resource.
+var ServiceProfileGVR = schema.GroupVersionResource{
+	Group:    "linkerd.io",
+	Version:  "v1alpha2",
+	Resource: "serviceprofiles",
+}
+
 type resourceName struct {
 	short  string
 	full   string
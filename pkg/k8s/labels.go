@@ -120,6 +120,11 @@ const (
 	// disable injection for a pod or namespace.
 	ProxyInjectDisabled = Disabled
 
+	// CNIRedirectedAnnotation is set by the CNI plugin on the pods whose
+	// traffic it redirected to the proxy, to the comma-separated list of the
+	// redirected IP families.
+	CNIRedirectedAnnotation = Prefix + "/cni-redirected"
+
 	// IdentityModeAnnotation controls how a pod participates
 	// in service identity.
 	IdentityModeAnnotation = Prefix + "/identity-mode"
@@ -172,6 +177,10 @@ const (
 	// ignoreOutboundPorts config.
 	ProxyIgnoreOutboundPortsAnnotation = ProxyConfigAnnotationsPrefix + "/skip-outbound-ports"
 
+	// ProxyEnableIPv6Annotation can be used to override the ipv6 config of the
+	// CNI plugin, which redirects IPv6 traffic to the proxy as well.
+	ProxyEnableIPv6Annotation = ProxyConfigAnnotationsPrefix + "/enable-ipv6"
+
 	// ProxyInboundPortAnnotation can be used to override the inboundPort config.
 	ProxyInboundPortAnnotation = ProxyConfigAnnotationsPrefix + "/inbound-port"
 
@@ -246,6 +255,11 @@ const (
 	// to be ready.
 	ProxyAwait = ProxyConfigAnnotationsPrefix + "/proxy-await"
 
+	// ProxyJobShutdownAnnotation can be used to disable the shutdown of the
+	// proxy of the pods of Jobs and CronJobs once their containers exit, which
+	// is otherwise enabled by default.
+	ProxyJobShutdownAnnotation = ProxyConfigAnnotationsPrefixAlpha + "/proxy-job-shutdown"
+
 	// IdentityModeDefault is assigned to IdentityModeAnnotation to
 	// use the control plane's default identity scheme.
 	IdentityModeDefault = "default"
@@ -280,6 +294,14 @@ const (
 	// ProxyContainerName is the name assigned to the injected proxy container.
 	ProxyContainerName = "linkerd-proxy"
 
+	// AwaitContainerName is the name of the init container that copies
+	// linkerd-await into the pods of Jobs, whose containers are wrapped by it
+	// so that they shut down the proxy when they exit.
+	AwaitContainerName = "linkerd-await"
+
+	// AwaitVolumeName is the name of the volume linkerd-await is copied to.
+	AwaitVolumeName = "linkerd-await"
+
 	// IdentityEndEntityVolumeName is the name assigned the temporary end-entity
 	// volume mounted into each proxy to store identity credentials.
 	IdentityEndEntityVolumeName = "linkerd-identity-end-entity"
@@ -362,6 +384,12 @@ const (
 	// MountPathTLSCrtPEM is the path at which the TLS cert PEM file is mounted.
 	MountPathTLSCrtPEM = MountPathTLSBase + "/tls.crt"
 
+	// MountPathAwait is the path at which the linkerd-await volume is mounted.
+	MountPathAwait = MountPathBase + "/await"
+
+	// AwaitBinaryPath is the path of linkerd-await in the containers it wraps.
+	AwaitBinaryPath = MountPathAwait + "/linkerd-await"
+
 	// MountPathXtablesLock is the path at which the proxy init container mounts xtables
 	// This is necessary for xtables-legacy support
 	MountPathXtablesLock = "/run"
@@ -388,6 +416,11 @@ const (
 	// services.
 	DefaultExportedServiceSelector = SvcMirrorPrefix + "/exported"
 
+	// ExportedPortsAnnotation can be set on an exported service to restrict
+	// the ports that get mirrored. Its value is a comma-separated list of
+	// port names or numbers.
+	ExportedPortsAnnotation = SvcMirrorPrefix + "/exported-ports"
+
 	// MirroredResourceLabel indicates that this resource is the result
 	// of a mirroring operation (can be a namespace or a service)
 	MirroredResourceLabel = SvcMirrorPrefix + "/mirrored-service"
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -44,6 +45,7 @@ type (
 		GatewayIdentity               string
 		ProbeSpec                     ProbeSpec
 		Selector                      metav1.LabelSelector
+		NamespaceMapping              map[string]string
 	}
 )
 
@@ -136,6 +138,11 @@ func NewLink(u unstructured.Unstructured) (Link, error) {
 		}
 	}
 
+	namespaceMapping, err := newNamespaceMapping(specObj)
+	if err != nil {
+		return Link{}, err
+	}
+
 	return Link{
 		Name:                          u.GetName(),
 		Namespace:                     u.GetNamespace(),
@@ -148,6 +155,7 @@ func NewLink(u unstructured.Unstructured) (Link, error) {
 		GatewayIdentity:               gatewayIdentity,
 		ProbeSpec:                     probeSpec,
 		Selector:                      selector,
+		NamespaceMapping:              namespaceMapping,
 	}, nil
 }
 
@@ -180,6 +188,14 @@ func (l Link) ToUnstructured() (unstructured.Unstructured, error) {
 	}
 	spec["selector"] = selector
 
+	if len(l.NamespaceMapping) > 0 {
+		mapping := make(map[string]interface{})
+		for remote, local := range l.NamespaceMapping {
+			mapping[remote] = local
+		}
+		spec["namespaceMapping"] = mapping
+	}
+
 	return unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": k8s.LinkAPIGroupVersion,
@@ -193,6 +209,39 @@ func (l Link) ToUnstructured() (unstructured.Unstructured, error) {
 	}, nil
 }
 
+// ObjectReference returns a reference to the Link resource, suitable for
+// attaching Kubernetes events to it.
+func (l Link) ObjectReference() *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: k8s.LinkAPIGroupVersion,
+		Kind:       k8s.LinkKind,
+		Name:       l.Name,
+		Namespace:  l.Namespace,
+	}
+}
+
+// LocalNamespace returns the namespace in the source cluster that services
+// from the given target cluster namespace are mirrored into. Namespaces that
+// are not part of the mapping are mirrored into a namespace of the same name.
+func (l Link) LocalNamespace(remoteNamespace string) string {
+	if local, ok := l.NamespaceMapping[remoteNamespace]; ok {
+		return local
+	}
+	return remoteNamespace
+}
+
+// RemoteNamespace is the inverse of LocalNamespace, returning the target
+// cluster namespace that mirrors in the given source cluster namespace
+// originate from.
+func (l Link) RemoteNamespace(localNamespace string) string {
+	for remote, local := range l.NamespaceMapping {
+		if local == localNamespace {
+			return remote
+		}
+	}
+	return localNamespace
+}
+
 // ExtractProbeSpec parses the ProbSpec from a gateway service's annotations.
 func ExtractProbeSpec(gateway *corev1.Service) (ProbeSpec, error) {
 	path := gateway.Annotations[consts.GatewayProbePath]
@@ -291,6 +340,51 @@ func newProbeSpec(obj map[string]interface{}) (ProbeSpec, error) {
 	}, nil
 }
 
+func newNamespaceMapping(spec map[string]interface{}) (map[string]string, error) {
+	obj, ok := spec["namespaceMapping"]
+	if !ok {
+		return nil, nil
+	}
+	mappingObj, ok := obj.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("Field 'namespaceMapping' is not an object")
+	}
+
+	mapping := make(map[string]string)
+	for remote := range mappingObj {
+		local, err := stringField(mappingObj, remote)
+		if err != nil {
+			return nil, err
+		}
+		mapping[remote] = local
+	}
+	if err := ValidateNamespaceMapping(mapping); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// ValidateNamespaceMapping checks that a namespace mapping is reversible, so
+// that mirrors can be traced back to the namespace they originate from: two
+// target cluster namespaces can't be mirrored into the same local namespace.
+func ValidateNamespaceMapping(mapping map[string]string) error {
+	remotes := make([]string, 0, len(mapping))
+	for remote := range mapping {
+		remotes = append(remotes, remote)
+	}
+	sort.Strings(remotes)
+
+	sources := make(map[string]string)
+	for _, remote := range remotes {
+		local := mapping[remote]
+		if other, ok := sources[local]; ok {
+			return fmt.Errorf("namespaces %s and %s are both mapped to %s", other, remote, local)
+		}
+		sources[local] = remote
+	}
+	return nil
+}
+
 func stringField(obj map[string]interface{}, key string) (string, error) {
 	value, ok := obj[key]
 	if !ok {
@@ -0,0 +1,44 @@
+package multicluster
+
+import (
+	"testing"
+)
+
+func TestValidateNamespaceMapping(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		mapping  map[string]string
+		expected string
+	}{
+		{
+			desc:    "accepts an empty mapping",
+			mapping: map[string]string{},
+		},
+		{
+			desc:    "accepts the mapping of the --namespace-mapping example",
+			mapping: map[string]string{"prod": "prod-east", "staging": "staging-east"},
+		},
+		{
+			desc:    "accepts swapped namespaces",
+			mapping: map[string]string{"prod": "staging", "staging": "prod"},
+		},
+		{
+			desc:     "rejects namespaces mapped to the same namespace",
+			mapping:  map[string]string{"prod": "east", "staging": "east"},
+			expected: "namespaces prod and staging are both mapped to east",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateNamespaceMapping(tc.mapping)
+			if tc.expected == "" && err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if tc.expected != "" && (err == nil || err.Error() != tc.expected) {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -1,16 +1,23 @@
 package prometheus
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/julienschmidt/httprouter"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"go.opencensus.io/plugin/ocgrpc"
-	"go.opencensus.io/plugin/ochttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
 )
 
+// unknownRoute is the route of the requests matching no route, which name
+// their spans instead of their path
+const unknownRoute = "unknown"
+
 var (
 	// RequestLatencyBucketsSeconds represents latency buckets to record (seconds)
 	RequestLatencyBucketsSeconds = append(append(append(append(
@@ -89,12 +96,12 @@ func init() {
 	)
 }
 
-// NewGrpcServer returns a grpc server pre-configured with prometheus interceptors and oc-grpc handler
+// NewGrpcServer returns a grpc server pre-configured with prometheus and
+// OpenTelemetry interceptors
 func NewGrpcServer() *grpc.Server {
 	server := grpc.NewServer(
-		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
-		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
+		grpc.ChainUnaryInterceptor(grpc_prometheus.UnaryServerInterceptor, otelgrpc.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(grpc_prometheus.StreamServerInterceptor, otelgrpc.StreamServerInterceptor()),
 	)
 
 	grpc_prometheus.EnableHandlingTimeHistogram()
@@ -102,13 +109,71 @@ func NewGrpcServer() *grpc.Server {
 	return server
 }
 
-// WithTelemetry instruments the HTTP server with prometheus and oc-http handler
-func WithTelemetry(handler http.Handler) http.Handler {
-	return &ochttp.Handler{
-		Handler: promhttp.InstrumentHandlerDuration(serverLatency,
+// WithTelemetry instruments the HTTP server with prometheus and the
+// OpenTelemetry handler, which names the server spans after the method and
+// the route of the request, so that the span names don't hold the values of
+// the request parameters
+func WithTelemetry(handler http.Handler, route func(*http.Request) string) http.Handler {
+	return otelhttp.NewHandler(
+		promhttp.InstrumentHandlerDuration(serverLatency,
 			promhttp.InstrumentHandlerResponseSize(serverResponseSize,
 				promhttp.InstrumentHandlerCounter(serverCounter, handler))),
+		"http.server",
+		otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+			return fmt.Sprintf("%s %s", req.Method, route(req))
+		}),
+	)
+}
+
+// RouterRoute returns the route of the router matching the request, with the
+// names of its parameters in place of their values, or unknownRoute if no
+// route matches
+func RouterRoute(router *httprouter.Router) func(*http.Request) string {
+	return func(req *http.Request) string {
+		handle, params, _ := router.Lookup(req.Method, req.URL.Path)
+		if handle == nil {
+			return unknownRoute
+		}
+		return routeOf(req.URL.Path, params)
+	}
+}
+
+// PathRoute returns the path of the request as its route if it's one of the
+// paths, or unknownRoute otherwise
+func PathRoute(paths ...string) func(*http.Request) string {
+	known := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		known[path] = true
+	}
+	return func(req *http.Request) string {
+		if known[req.URL.Path] {
+			return req.URL.Path
+		}
+		return unknownRoute
+	}
+}
+
+// routeOf replaces the values of the parameters in the path by their names.
+// The parameters are matched from the end of the path, as the routes start
+// with static segments.
+func routeOf(path string, params httprouter.Params) string {
+	suffix := ""
+	if n := len(params); n > 0 && strings.HasPrefix(params[n-1].Value, "/") {
+		// a catch-all parameter matches the rest of the path
+		path = strings.TrimSuffix(path, params[n-1].Value)
+		suffix = "/*" + params[n-1].Key
+		params = params[:n-1]
+	}
+
+	segments := strings.Split(path, "/")
+	i := len(params) - 1
+	for j := len(segments) - 1; j >= 0 && i >= 0; j-- {
+		if segments[j] == params[i].Value {
+			segments[j] = ":" + params[i].Key
+			i--
+		}
 	}
+	return strings.Join(segments, "/") + suffix
 }
 
 // ClientWithTelemetry instruments the HTTP client with prometheus
@@ -0,0 +1,54 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestRouterRoute(t *testing.T) {
+	handle := func(http.ResponseWriter, *http.Request, httprouter.Params) {}
+	router := &httprouter.Router{}
+	router.GET("/namespaces/:namespace", handle)
+	router.GET("/namespaces/:namespace/pods/:pod", handle)
+	router.GET("/dist/*filepath", handle)
+	router.POST("/apis/tap.linkerd.io/v1alpha1/watch/namespaces/:namespace/:resource/:name/tap", handle)
+	route := RouterRoute(router)
+
+	testCases := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{"GET", "/namespaces/emojivoto", "/namespaces/:namespace"},
+		{"GET", "/namespaces/namespaces", "/namespaces/:namespace"},
+		{"GET", "/namespaces/emojivoto/pods/web", "/namespaces/:namespace/pods/:pod"},
+		{"GET", "/dist/css/styles.css", "/dist/*filepath"},
+		{"POST", "/apis/tap.linkerd.io/v1alpha1/watch/namespaces/emojivoto/deployments/web/tap",
+			"/apis/tap.linkerd.io/v1alpha1/watch/namespaces/:namespace/:resource/:name/tap"},
+		{"GET", "/unknown/path", unknownRoute},
+		{"POST", "/namespaces/emojivoto", unknownRoute},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if actual := route(req); actual != tc.expected {
+			t.Errorf("Expected route %s for %s %s, got %s", tc.expected, tc.method, tc.path, actual)
+		}
+	}
+}
+
+func TestPathRoute(t *testing.T) {
+	route := PathRoute("/api/v1/StatSummary")
+	for path, expected := range map[string]string{
+		"/api/v1/StatSummary": "/api/v1/StatSummary",
+		"/api/v1/unknown":     unknownRoute,
+	} {
+		req := httptest.NewRequest("POST", path, nil)
+		if actual := route(req); actual != expected {
+			t.Errorf("Expected route %s for %s, got %s", expected, path, actual)
+		}
+	}
+}
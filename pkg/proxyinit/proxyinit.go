@@ -0,0 +1,87 @@
+package proxyinit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+// Config holds the settings of the redirection of the traffic of a pod to its
+// proxy, which is set up either by the proxy-init container or by the CNI
+// plugin. Both derive it from the same annotations through ApplyAnnotations,
+// so that a pod is set up the same way whichever is used.
+type Config struct {
+	InboundPort  int32
+	OutboundPort int32
+	ControlPort  int32
+	AdminPort    int32
+	ProxyUID     int64
+
+	// IgnoreInboundPorts and IgnoreOutboundPorts are comma-separated lists
+	// of ports and port ranges
+	IgnoreInboundPorts  string
+	IgnoreOutboundPorts string
+}
+
+// ApplyAnnotations overrides the config with the values of the given
+// annotations. Annotations with invalid values are ignored.
+func (c *Config) ApplyAnnotations(annotations map[string]string) {
+	for annotation, port := range map[string]*int32{
+		k8s.ProxyInboundPortAnnotation:  &c.InboundPort,
+		k8s.ProxyOutboundPortAnnotation: &c.OutboundPort,
+		k8s.ProxyControlPortAnnotation:  &c.ControlPort,
+		k8s.ProxyAdminPortAnnotation:    &c.AdminPort,
+	} {
+		if override, ok := annotations[annotation]; ok {
+			value, err := strconv.ParseInt(override, 10, 32)
+			if err == nil {
+				*port = int32(value)
+			}
+		}
+	}
+
+	if override, ok := annotations[k8s.ProxyUIDAnnotation]; ok {
+		value, err := strconv.ParseInt(override, 10, 64)
+		if err == nil {
+			c.ProxyUID = value
+		}
+	}
+
+	if override, ok := annotations[k8s.ProxyIgnoreInboundPortsAnnotation]; ok {
+		c.IgnoreInboundPorts = override
+	}
+
+	if override, ok := annotations[k8s.ProxyIgnoreOutboundPortsAnnotation]; ok {
+		c.IgnoreOutboundPorts = override
+	}
+}
+
+// InboundPortsToIgnore returns the inbound ports whose traffic skips the
+// proxy: its control and admin ports, followed by IgnoreInboundPorts.
+func (c *Config) InboundPortsToIgnore() []string {
+	ports := []string{}
+	for _, port := range []int32{c.ControlPort, c.AdminPort} {
+		if port != 0 {
+			ports = append(ports, fmt.Sprintf("%d", port))
+		}
+	}
+	return append(ports, splitPorts(c.IgnoreInboundPorts)...)
+}
+
+// OutboundPortsToIgnore returns the outbound ports whose traffic skips the
+// proxy.
+func (c *Config) OutboundPortsToIgnore() []string {
+	return splitPorts(c.IgnoreOutboundPorts)
+}
+
+func splitPorts(ports string) []string {
+	split := []string{}
+	for _, port := range strings.Split(ports, ",") {
+		if port = strings.TrimSpace(port); port != "" {
+			split = append(split, port)
+		}
+	}
+	return split
+}
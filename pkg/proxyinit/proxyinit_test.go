@@ -0,0 +1,86 @@
+package proxyinit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestApplyAnnotations(t *testing.T) {
+	defaults := Config{
+		InboundPort:        4143,
+		OutboundPort:       4140,
+		ControlPort:        4190,
+		AdminPort:          4191,
+		ProxyUID:           2102,
+		IgnoreInboundPorts: "25",
+	}
+
+	testCases := []struct {
+		desc        string
+		annotations map[string]string
+		expected    Config
+	}{
+		{
+			desc:     "keeps the defaults without annotations",
+			expected: defaults,
+		},
+		{
+			desc: "overrides the defaults with the annotations",
+			annotations: map[string]string{
+				k8s.ProxyInboundPortAnnotation:         "5143",
+				k8s.ProxyOutboundPortAnnotation:        "5140",
+				k8s.ProxyControlPortAnnotation:         "5190",
+				k8s.ProxyAdminPortAnnotation:           "5191",
+				k8s.ProxyUIDAnnotation:                 "3000",
+				k8s.ProxyIgnoreInboundPortsAnnotation:  "8080,9000-9100",
+				k8s.ProxyIgnoreOutboundPortsAnnotation: "3306",
+			},
+			expected: Config{
+				InboundPort:         5143,
+				OutboundPort:        5140,
+				ControlPort:         5190,
+				AdminPort:           5191,
+				ProxyUID:            3000,
+				IgnoreInboundPorts:  "8080,9000-9100",
+				IgnoreOutboundPorts: "3306",
+			},
+		},
+		{
+			desc: "ignores invalid values",
+			annotations: map[string]string{
+				k8s.ProxyInboundPortAnnotation: "http",
+				k8s.ProxyUIDAnnotation:         "-",
+			},
+			expected: defaults,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			config := defaults
+			config.ApplyAnnotations(tc.annotations)
+			if !reflect.DeepEqual(config, tc.expected) {
+				t.Fatalf("Expected %+v, got %+v", tc.expected, config)
+			}
+		})
+	}
+}
+
+func TestPortsToIgnore(t *testing.T) {
+	config := Config{
+		ControlPort:         4190,
+		AdminPort:           4191,
+		IgnoreInboundPorts:  "8080, 9000-9100",
+		IgnoreOutboundPorts: "",
+	}
+	expected := []string{"4190", "4191", "8080", "9000-9100"}
+	if got := config.InboundPortsToIgnore(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	if got := config.OutboundPortsToIgnore(); len(got) != 0 {
+		t.Fatalf("Expected no outbound port, got %v", got)
+	}
+}
@@ -1,22 +1,146 @@
 package trace
 
 import (
-	"contrib.go.opencensus.io/exporter/ocagent"
-	"go.opencensus.io/trace"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ProtocolGRPC exports spans with OTLP over gRPC
+	ProtocolGRPC = "grpc"
+	// ProtocolHTTP exports spans with OTLP over HTTP
+	ProtocolHTTP = "http"
 )
 
-// InitializeTracing initiates trace, exporter and the sampler
-func InitializeTracing(serviceName string, address string) error {
-	oce, err := ocagent.NewExporter(
-		ocagent.WithInsecure(),
-		ocagent.WithAddress(address),
-		ocagent.WithServiceName(serviceName))
+// Config holds the tracing configuration of a control plane component
+type Config struct {
+	// Collector is the host:port of the OTLP collector; tracing is disabled
+	// if it's empty
+	Collector string
+	// Protocol is the OTLP transport, either ProtocolGRPC or ProtocolHTTP
+	Protocol string
+	// SampleRatio is the fraction of the traces started by the component
+	// that are sampled; the sampling decision of a remote parent is always
+	// honored
+	SampleRatio float64
+	// ResourceAttributes is a comma-separated list of key=value pairs added
+	// to the resource of every span
+	ResourceAttributes string
+}
+
+// Enabled returns true if a collector is configured
+func (c *Config) Enabled() bool {
+	return c.Collector != ""
+}
+
+// InitializeTracing initiates the OTLP exporter, the sampler and the
+// propagators of the global tracer provider. Both the W3C trace context and the
+// B3 headers are propagated.
+func InitializeTracing(serviceName string, config *Config) error {
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return fmt.Errorf("invalid trace sample ratio %v: must be between 0 and 1", config.SampleRatio)
+	}
+
+	attrs, err := parseResourceAttributes(config.ResourceAttributes)
 	if err != nil {
 		return err
 	}
-	trace.RegisterExporter(oce)
-	trace.ApplyConfig(trace.Config{
-		DefaultSampler: trace.AlwaysSample(),
-	})
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		append(attrs, semconv.ServiceNameKey.String(serviceName))...)
+
+	var client otlptrace.Client
+	switch config.Protocol {
+	case ProtocolGRPC:
+		client = otlptracegrpc.NewClient(
+			otlptracegrpc.WithInsecure(),
+			otlptracegrpc.WithEndpoint(config.Collector))
+	case ProtocolHTTP:
+		client = otlptracehttp.NewClient(
+			otlptracehttp.WithInsecure(),
+			otlptracehttp.WithEndpoint(config.Collector))
+	default:
+		return fmt.Errorf("invalid trace protocol %q: must be one of %s, %s", config.Protocol, ProtocolGRPC, ProtocolHTTP)
+	}
+	exporter, err := otlptrace.New(context.Background(), client)
+	if err != nil {
+		return err
+	}
+
+	register(sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	))
 	return nil
 }
+
+// InitializeInMemoryTracing registers a global tracer provider that samples
+// every trace and synchronously exports the spans to the returned exporter,
+// so that tests can verify them
+func InitializeInMemoryTracing() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	register(sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	))
+	return exporter
+}
+
+// EndSpan ends the span, recording err as its status if it's not nil
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// EndSpanOnce returns a function ending the span with EndSpan the first time
+// it's called only, so that a span ended once its setup is done isn't ended
+// again by the deferred call recording the error of the whole call
+func EndSpanOnce(span trace.Span) func(error) {
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { EndSpan(span, err) })
+	}
+}
+
+func register(provider *sdktrace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+		b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader|b3.B3SingleHeader)),
+	))
+}
+
+func parseResourceAttributes(s string) ([]attribute.KeyValue, error) {
+	attrs := []attribute.KeyValue{}
+	if s == "" {
+		return attrs, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid resource attribute %q: must be key=value", pair)
+		}
+		attrs = append(attrs, attribute.String(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])))
+	}
+	return attrs, nil
+}
@@ -0,0 +1,129 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestParseResourceAttributes(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []attribute.KeyValue
+		err      bool
+	}{
+		{"", []attribute.KeyValue{}, false},
+		{
+			"deployment.environment=prod, k8s.cluster.name = east",
+			[]attribute.KeyValue{
+				attribute.String("deployment.environment", "prod"),
+				attribute.String("k8s.cluster.name", "east"),
+			},
+			false,
+		},
+		{"team=", []attribute.KeyValue{attribute.String("team", "")}, false},
+		{"team", nil, true},
+		{"=east", nil, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := parseResourceAttributes(tc.input)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Expected an error for %q", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestInitializeTracing(t *testing.T) {
+	testCases := []struct {
+		config *Config
+		err    bool
+	}{
+		{&Config{Collector: "collector:4317", Protocol: ProtocolGRPC, SampleRatio: 0.5}, false},
+		{&Config{Collector: "collector:4318", Protocol: ProtocolHTTP, SampleRatio: 1, ResourceAttributes: "a=b"}, false},
+		{&Config{Collector: "collector:55678", Protocol: "opencensus", SampleRatio: 1}, true},
+		{&Config{Collector: "collector:4317", Protocol: ProtocolGRPC, SampleRatio: 1.5}, true},
+		{&Config{Collector: "collector:4317", Protocol: ProtocolGRPC, SampleRatio: 1, ResourceAttributes: "a"}, true},
+	}
+
+	for _, tc := range testCases {
+		err := InitializeTracing("linkerd-test", tc.config)
+		if tc.err && err == nil {
+			t.Errorf("Expected an error for %+v", tc.config)
+		}
+		if !tc.err && err != nil {
+			t.Errorf("Unexpected error for %+v: %s", tc.config, err)
+		}
+	}
+}
+
+func TestEndSpan(t *testing.T) {
+	exporter := InitializeInMemoryTracing()
+
+	_, span := otel.Tracer("test").Start(context.Background(), "ok")
+	EndSpan(span, nil)
+	_, span = otel.Tracer("test").Start(context.Background(), "failed")
+	EndSpan(span, errors.New("boom"))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Name != "ok" || spans[0].Status.Code != codes.Unset {
+		t.Errorf("Unexpected span: %s %v", spans[0].Name, spans[0].Status)
+	}
+	if spans[1].Name != "failed" || spans[1].Status.Code != codes.Error || spans[1].Status.Description != "boom" {
+		t.Errorf("Unexpected span: %s %v", spans[1].Name, spans[1].Status)
+	}
+}
+
+func TestEndSpanOnce(t *testing.T) {
+	exporter := InitializeInMemoryTracing()
+
+	_, span := otel.Tracer("test").Start(context.Background(), "setup")
+	endSpan := EndSpanOnce(span)
+	endSpan(nil)
+	endSpan(errors.New("stream closed"))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status.Code != codes.Unset || len(spans[0].Events) != 0 {
+		t.Errorf("Expected the span to be ended without error, got %v %v", spans[0].Status, spans[0].Events)
+	}
+}
+
+func TestB3Propagation(t *testing.T) {
+	InitializeInMemoryTracing()
+
+	expected := "4bf92f3577b34da6a3ce929d0e0e4736"
+	headers := http.Header{}
+	headers.Set("X-B3-TraceId", expected)
+	headers.Set("X-B3-SpanId", "00f067aa0ba902b7")
+	headers.Set("X-B3-Sampled", "1")
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(headers))
+	if traceID := trace.SpanContextFromContext(ctx).TraceID().String(); traceID != expected {
+		t.Errorf("Expected the B3 trace ID %s to be extracted, got %s", expected, traceID)
+	}
+}
@@ -21,9 +21,22 @@ const (
 	envDisabled     = "LINKERD2_PROXY_IDENTITY_DISABLED"
 	envLocalName    = "LINKERD2_PROXY_IDENTITY_LOCAL_NAME"
 	envTrustAnchors = "LINKERD2_PROXY_IDENTITY_TRUST_ANCHORS"
+
+	// envAwaitCopyPath is set in the init container that copies linkerd-await
+	// into the pods of Jobs, in which case the proxy isn't run
+	envAwaitCopyPath = "LINKERD2_PROXY_AWAIT_COPY_PATH"
+
+	awaitPath = "/usr/lib/linkerd/linkerd-await"
 )
 
 func main() {
+	if dst := os.Getenv(envAwaitCopyPath); dst != "" {
+		if err := copyAwait(dst); err != nil {
+			log.Fatalf("Failed to copy linkerd-await: %s", err)
+		}
+		return
+	}
+
 	defer runProxy()
 
 	if os.Getenv(envDisabled) != "" {
@@ -145,6 +158,16 @@ func generateAndStoreCSR(p, id string, key *ecdsa.PrivateKey) ([]byte, error) {
 	return csrb, nil
 }
 
+// copyAwait copies linkerd-await to the given path, so that it can wrap the
+// containers of a pod whose images don't ship it
+func copyAwait(dst string) error {
+	bin, err := ioutil.ReadFile(awaitPath)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(dst, bin, 0755)
+}
+
 func runProxy() {
 	err := syscall.Exec("/usr/lib/linkerd/linkerd2-proxy", []string{}, os.Environ())
 	if err != nil {
@@ -36,6 +36,7 @@ type tapOptions struct {
 	method        string
 	authority     string
 	path          string
+	traceID       string
 	output        string
 	labelSelector string
 }
@@ -76,6 +77,8 @@ type requestInitEvent struct {
 	Authority string     `json:"authority"`
 	Path      string     `json:"path"`
 	Headers   []metadata `json:"headers"`
+	TraceID   string     `json:"traceId,omitempty"`
+	SpanID    string     `json:"spanId,omitempty"`
 }
 
 type responseInitEvent struct {
@@ -115,6 +118,7 @@ func newTapOptions() *tapOptions {
 		method:        "",
 		authority:     "",
 		path:          "",
+		traceID:       "",
 		output:        "",
 		labelSelector: "",
 	}
@@ -171,7 +175,10 @@ func NewCmdTap() *cobra.Command {
   linkerd viz tap pod/web-dlbvj
 
   # tap the test namespace, filter by request to prod namespace
-  linkerd viz tap ns/test --to ns/prod`,
+  linkerd viz tap ns/test --to ns/prod
+
+  # tap the requests of a trace found in Jaeger
+  linkerd viz tap deploy/web --trace-id 4bf92f3577b34da6a3ce929d0e0e4736`,
 		Args: cobra.RangeArgs(1, 2),
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			// This command requires at most two arguments if we already have
@@ -223,6 +230,7 @@ func NewCmdTap() *cobra.Command {
 				Method:        options.method,
 				Authority:     options.authority,
 				Path:          options.path,
+				TraceID:       options.traceID,
 				Extract:       options.output == jsonOutput,
 				LabelSelector: options.labelSelector,
 			}
@@ -270,6 +278,8 @@ func NewCmdTap() *cobra.Command {
 		"Display requests with this :authority")
 	cmd.PersistentFlags().StringVar(&options.path, "path", options.path,
 		"Display requests with paths that start with this prefix")
+	cmd.PersistentFlags().StringVar(&options.traceID, "trace-id", options.traceID,
+		"Display requests whose W3C traceparent or B3 headers carry this trace ID")
 	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
 		fmt.Sprintf("Output format. One of: \"%s\", \"%s\"", wideOutput, jsonOutput))
 	cmd.PersistentFlags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector,
@@ -368,13 +378,18 @@ func renderTapEvent(event *tapPb.TapEvent, resource string) string {
 
 	switch ev := event.GetHttp().GetEvent().(type) {
 	case *tapPb.TapEvent_Http_RequestInit_:
-		return fmt.Sprintf("req id=%d:%d %s :method=%s :authority=%s :path=%s%s",
+		traceID := ""
+		if id := ev.RequestInit.GetTraceId(); id != "" {
+			traceID = fmt.Sprintf(" trace-id=%s", id)
+		}
+		return fmt.Sprintf("req id=%d:%d %s :method=%s :authority=%s :path=%s%s%s",
 			ev.RequestInit.GetId().GetBase(),
 			ev.RequestInit.GetId().GetStream(),
 			flow,
 			ev.RequestInit.GetMethod().GetRegistered().String(),
 			ev.RequestInit.GetAuthority(),
 			ev.RequestInit.GetPath(),
+			traceID,
 			resources,
 		)
 
@@ -486,6 +501,8 @@ func getRequestInitEvent(pubEv *tapPb.TapEvent_Http) *requestInitEvent {
 		Authority: reqI.GetAuthority(),
 		Path:      reqI.GetPath(),
 		Headers:   formatHeadersTrailers(reqI.GetHeaders()),
+		TraceID:   reqI.GetTraceId(),
+		SpanID:    reqI.GetSpanId(),
 	}
 }
 
@@ -305,6 +305,35 @@ func TestEventToString(t *testing.T) {
 		}
 	})
 
+	t.Run("Converts HTTP request init event with a trace ID to string", func(t *testing.T) {
+		event := toTapEvent(&tapPb.TapEvent_Http{
+			Event: &tapPb.TapEvent_Http_RequestInit_{
+				RequestInit: &tapPb.TapEvent_Http_RequestInit{
+					Method: &metricsPb.HttpMethod{
+						Type: &metricsPb.HttpMethod_Registered_{
+							Registered: metricsPb.HttpMethod_GET,
+						},
+					},
+					Authority: "hello.default:7777",
+					Path:      "/hello",
+					TraceId:   "4bf92f3577b34da6a3ce929d0e0e4736",
+					SpanId:    "00f067aa0ba902b7",
+				},
+			},
+		})
+
+		expectedOutput := "req id=7:8 proxy=out src=1.2.3.4:5555 dst=2.3.4.5:6666 tls= :method=GET :authority=hello.default:7777 :path=/hello trace-id=4bf92f3577b34da6a3ce929d0e0e4736"
+		output := renderTapEvent(event, "")
+		if output != expectedOutput {
+			t.Fatalf("Expecting command output to be [%s], got [%s]", expectedOutput, output)
+		}
+
+		reqInit := mapPublicToDisplayTapEvent(event).RequestInitEvent
+		if reqInit.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || reqInit.SpanID != "00f067aa0ba902b7" {
+			t.Fatalf("Expecting the trace context in the JSON event, got [%+v]", reqInit)
+		}
+	})
+
 	t.Run("Converts HTTP response init event to string", func(t *testing.T) {
 		event := toTapEvent(&tapPb.TapEvent_Http{
 			Event: &tapPb.TapEvent_Http_ResponseInit_{
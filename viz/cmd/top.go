@@ -37,8 +37,10 @@ type topOptions struct {
 	method        string
 	authority     string
 	path          string
+	traceID       string
 	hideSources   bool
 	routes        bool
+	traceIDs      bool
 	labelSelector string
 }
 
@@ -86,6 +88,7 @@ type tableRow struct {
 	last        time.Duration
 	successes   int
 	failures    int
+	traceID     string
 }
 
 func (r tableRow) merge(other tableRow) tableRow {
@@ -99,6 +102,9 @@ func (r tableRow) merge(other tableRow) tableRow {
 	r.last = other.last
 	r.successes += other.successes
 	r.failures += other.failures
+	if other.traceID != "" {
+		r.traceID = other.traceID
+	}
 	return r
 }
 
@@ -115,6 +121,7 @@ const (
 	worstColumn
 	lastColumn
 	successRateColumn
+	traceIDColumn
 
 	columnCount
 )
@@ -252,6 +259,18 @@ func newTopTable() *topTable {
 			},
 		}
 
+	table.columns[traceIDColumn] =
+		tableColumn{
+			header:   "Last Trace ID",
+			width:    32,
+			key:      false,
+			display:  false,
+			flexible: false,
+			value: func(r tableRow) string {
+				return r.traceID
+			},
+		}
+
 	return &table
 }
 
@@ -270,8 +289,10 @@ func newTopOptions() *topOptions {
 		method:        "",
 		authority:     "",
 		path:          "",
+		traceID:       "",
 		hideSources:   false,
 		routes:        false,
+		traceIDs:      false,
 		labelSelector: "",
 	}
 }
@@ -318,7 +339,11 @@ func NewCmdTop() *cobra.Command {
   linkerd viz top deploy/web
 
   # display traffic for the web-dlbvj pod in the default namespace
-  linkerd viz top pod/web-dlbvj`,
+  linkerd viz top pod/web-dlbvj
+
+  # display traffic for the web deployment along with the trace ID of the
+  # last request of each row
+  linkerd viz top deploy/web --trace-ids`,
 		Args: cobra.RangeArgs(1, 2),
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			// This command requires at most two arguments if we already have
@@ -370,6 +395,8 @@ func NewCmdTop() *cobra.Command {
 				Method:        options.method,
 				Authority:     options.authority,
 				Path:          options.path,
+				TraceID:       options.traceID,
+				Extract:       options.traceIDs,
 				LabelSelector: options.labelSelector,
 			}
 
@@ -387,6 +414,10 @@ func NewCmdTop() *cobra.Command {
 				table.columns[routeColumn].display = true
 			}
 
+			if options.traceIDs {
+				table.columns[traceIDColumn].display = true
+			}
+
 			req, err := pkg.BuildTapByResourceRequest(requestParams)
 			if err != nil {
 				return err
@@ -417,8 +448,12 @@ func NewCmdTop() *cobra.Command {
 		"Display requests with this :authority")
 	cmd.PersistentFlags().StringVar(&options.path, "path", options.path,
 		"Display requests with paths that start with this prefix")
+	cmd.PersistentFlags().StringVar(&options.traceID, "trace-id", options.traceID,
+		"Display requests whose W3C traceparent or B3 headers carry this trace ID")
 	cmd.PersistentFlags().BoolVar(&options.hideSources, "hide-sources", options.hideSources, "Hide the source column")
 	cmd.PersistentFlags().BoolVar(&options.routes, "routes", options.routes, "Display data per route instead of per path")
+	cmd.PersistentFlags().BoolVar(&options.traceIDs, "trace-ids", options.traceIDs,
+		"Display the trace ID of the last request of each row, parsed from its W3C traceparent or B3 headers")
 	cmd.PersistentFlags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='")
 
 	pkgcmd.ConfigureNamespaceFlagCompletion(
@@ -641,6 +676,7 @@ func newRow(req topRequest) (tableRow, error) {
 		count:       1,
 		successes:   successes,
 		failures:    failures,
+		traceID:     req.reqInit.GetTraceId(),
 	}, nil
 }
 
@@ -13,7 +13,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/protohttp"
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 	log "github.com/sirupsen/logrus"
-	"go.opencensus.io/plugin/ochttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
 )
 
@@ -61,6 +61,12 @@ func (c *grpcOverHTTPClient) Gateways(ctx context.Context, req *pb.GatewaysReque
 	return &msg, err
 }
 
+func (c *grpcOverHTTPClient) MulticlusterStats(ctx context.Context, req *pb.MulticlusterStatsRequest, _ ...grpc.CallOption) (*pb.MulticlusterStatsResponse, error) {
+	var msg pb.MulticlusterStatsResponse
+	err := c.apiRequest(ctx, "MulticlusterStats", req, &msg)
+	return &msg, err
+}
+
 func (c *grpcOverHTTPClient) SelfCheck(ctx context.Context, req *pb.SelfCheckRequest, _ ...grpc.CallOption) (*pb.SelfCheckResponse, error) {
 	var msg pb.SelfCheckResponse
 	err := c.apiRequest(ctx, "SelfCheck", req, &msg)
@@ -151,7 +157,7 @@ func NewInternalClient(namespace string, kubeAPIHost string) (pb.ApiClient, erro
 		return nil, err
 	}
 
-	return newClient(apiURL, &http.Client{Transport: &ochttp.Transport{}}, namespace)
+	return newClient(apiURL, &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}, namespace)
 }
 
 // NewExternalClient creates a new Viz API client intended to run from
@@ -28,7 +28,7 @@ func main() {
 	ignoredNamespaces := cmd.String("ignore-namespaces", "kube-system", "comma separated list of namespaces to not list pods from")
 	clusterDomain := cmd.String("cluster-domain", "cluster.local", "kubernetes cluster domain")
 
-	traceCollector := flags.AddTraceFlags(cmd)
+	traceConfig := flags.AddTraceFlags(cmd)
 
 	flags.ConfigureAndParse(cmd, os.Args[1:])
 	ctx := context.Background()
@@ -57,8 +57,8 @@ func main() {
 	log.Infof("prometheusClient: %#v", prometheusClient)
 	log.Info("Using cluster domain: ", *clusterDomain)
 
-	if *traceCollector != "" {
-		if err := trace.InitializeTracing("linkerd-public-api", *traceCollector); err != nil {
+	if traceConfig.Enabled() {
+		if err := trace.InitializeTracing("linkerd-public-api", traceConfig); err != nil {
 			log.Warnf("failed to initialize tracing: %s", err)
 		}
 	}
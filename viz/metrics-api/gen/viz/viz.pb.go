@@ -2547,6 +2547,216 @@ func (*GatewaysResponse_Ok_) isGatewaysResponse_Response() {}
 
 func (*GatewaysResponse_Error) isGatewaysResponse_Response() {}
 
+// MulticlusterStatsTable holds request metrics for traffic sent by local
+// workloads to services mirrored from target clusters.
+type MulticlusterStatsTable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows []*MulticlusterStatsTable_Row `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *MulticlusterStatsTable) Reset() {
+	*x = MulticlusterStatsTable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MulticlusterStatsTable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MulticlusterStatsTable) ProtoMessage() {}
+
+func (x *MulticlusterStatsTable) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MulticlusterStatsTable.ProtoReflect.Descriptor instead.
+func (*MulticlusterStatsTable) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *MulticlusterStatsTable) GetRows() []*MulticlusterStatsTable_Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type MulticlusterStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only report traffic sent to this target cluster, if set
+	RemoteClusterName string `protobuf:"bytes,1,opt,name=remote_cluster_name,json=remoteClusterName,proto3" json:"remote_cluster_name,omitempty"`
+	// only report traffic sent from this local namespace, if set
+	FromNamespace string `protobuf:"bytes,2,opt,name=from_namespace,json=fromNamespace,proto3" json:"from_namespace,omitempty"`
+	TimeWindow    string `protobuf:"bytes,3,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	ByService     bool   `protobuf:"varint,4,opt,name=by_service,json=byService,proto3" json:"by_service,omitempty"`
+	ByWorkload    bool   `protobuf:"varint,5,opt,name=by_workload,json=byWorkload,proto3" json:"by_workload,omitempty"`
+}
+
+func (x *MulticlusterStatsRequest) Reset() {
+	*x = MulticlusterStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MulticlusterStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MulticlusterStatsRequest) ProtoMessage() {}
+
+func (x *MulticlusterStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MulticlusterStatsRequest.ProtoReflect.Descriptor instead.
+func (*MulticlusterStatsRequest) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MulticlusterStatsRequest) GetRemoteClusterName() string {
+	if x != nil {
+		return x.RemoteClusterName
+	}
+	return ""
+}
+
+func (x *MulticlusterStatsRequest) GetFromNamespace() string {
+	if x != nil {
+		return x.FromNamespace
+	}
+	return ""
+}
+
+func (x *MulticlusterStatsRequest) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return ""
+}
+
+func (x *MulticlusterStatsRequest) GetByService() bool {
+	if x != nil {
+		return x.ByService
+	}
+	return false
+}
+
+func (x *MulticlusterStatsRequest) GetByWorkload() bool {
+	if x != nil {
+		return x.ByWorkload
+	}
+	return false
+}
+
+type MulticlusterStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Response:
+	//	*MulticlusterStatsResponse_Ok_
+	//	*MulticlusterStatsResponse_Error
+	Response isMulticlusterStatsResponse_Response `protobuf_oneof:"response"`
+}
+
+func (x *MulticlusterStatsResponse) Reset() {
+	*x = MulticlusterStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MulticlusterStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MulticlusterStatsResponse) ProtoMessage() {}
+
+func (x *MulticlusterStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MulticlusterStatsResponse.ProtoReflect.Descriptor instead.
+func (*MulticlusterStatsResponse) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{36}
+}
+
+func (m *MulticlusterStatsResponse) GetResponse() isMulticlusterStatsResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (x *MulticlusterStatsResponse) GetOk() *MulticlusterStatsResponse_Ok {
+	if x, ok := x.GetResponse().(*MulticlusterStatsResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+func (x *MulticlusterStatsResponse) GetError() *ResourceError {
+	if x, ok := x.GetResponse().(*MulticlusterStatsResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isMulticlusterStatsResponse_Response interface {
+	isMulticlusterStatsResponse_Response()
+}
+
+type MulticlusterStatsResponse_Ok_ struct {
+	Ok *MulticlusterStatsResponse_Ok `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type MulticlusterStatsResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*MulticlusterStatsResponse_Ok_) isMulticlusterStatsResponse_Response() {}
+
+func (*MulticlusterStatsResponse_Error) isMulticlusterStatsResponse_Response() {}
+
 type Headers_Header struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2567,7 +2777,7 @@ type Headers_Header struct {
 func (x *Headers_Header) Reset() {
 	*x = Headers_Header{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[34]
+		mi := &file_viz_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2580,7 +2790,7 @@ func (x *Headers_Header) String() string {
 func (*Headers_Header) ProtoMessage() {}
 
 func (x *Headers_Header) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[34]
+	mi := &file_viz_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2653,7 +2863,7 @@ type PodErrors_PodError struct {
 func (x *PodErrors_PodError) Reset() {
 	*x = PodErrors_PodError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[35]
+		mi := &file_viz_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2666,7 +2876,7 @@ func (x *PodErrors_PodError) String() string {
 func (*PodErrors_PodError) ProtoMessage() {}
 
 func (x *PodErrors_PodError) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[35]
+	mi := &file_viz_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2721,7 +2931,7 @@ type PodErrors_PodError_ContainerError struct {
 func (x *PodErrors_PodError_ContainerError) Reset() {
 	*x = PodErrors_PodError_ContainerError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[36]
+		mi := &file_viz_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2734,7 +2944,7 @@ func (x *PodErrors_PodError_ContainerError) String() string {
 func (*PodErrors_PodError_ContainerError) ProtoMessage() {}
 
 func (x *PodErrors_PodError_ContainerError) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[36]
+	mi := &file_viz_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2789,7 +2999,7 @@ type StatSummaryResponse_Ok struct {
 func (x *StatSummaryResponse_Ok) Reset() {
 	*x = StatSummaryResponse_Ok{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[37]
+		mi := &file_viz_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2802,7 +3012,7 @@ func (x *StatSummaryResponse_Ok) String() string {
 func (*StatSummaryResponse_Ok) ProtoMessage() {}
 
 func (x *StatSummaryResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[37]
+	mi := &file_viz_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2836,7 +3046,7 @@ type StatTable_PodGroup struct {
 func (x *StatTable_PodGroup) Reset() {
 	*x = StatTable_PodGroup{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[38]
+		mi := &file_viz_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2849,7 +3059,7 @@ func (x *StatTable_PodGroup) String() string {
 func (*StatTable_PodGroup) ProtoMessage() {}
 
 func (x *StatTable_PodGroup) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[38]
+	mi := &file_viz_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2897,7 +3107,7 @@ type StatTable_PodGroup_Row struct {
 func (x *StatTable_PodGroup_Row) Reset() {
 	*x = StatTable_PodGroup_Row{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[39]
+		mi := &file_viz_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2910,7 +3120,7 @@ func (x *StatTable_PodGroup_Row) String() string {
 func (*StatTable_PodGroup_Row) ProtoMessage() {}
 
 func (x *StatTable_PodGroup_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[39]
+	mi := &file_viz_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3007,7 +3217,7 @@ type EdgesResponse_Ok struct {
 func (x *EdgesResponse_Ok) Reset() {
 	*x = EdgesResponse_Ok{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[41]
+		mi := &file_viz_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3020,7 +3230,7 @@ func (x *EdgesResponse_Ok) String() string {
 func (*EdgesResponse_Ok) ProtoMessage() {}
 
 func (x *EdgesResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[41]
+	mi := &file_viz_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3054,7 +3264,7 @@ type TopRoutesResponse_Ok struct {
 func (x *TopRoutesResponse_Ok) Reset() {
 	*x = TopRoutesResponse_Ok{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[42]
+		mi := &file_viz_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3067,7 +3277,7 @@ func (x *TopRoutesResponse_Ok) String() string {
 func (*TopRoutesResponse_Ok) ProtoMessage() {}
 
 func (x *TopRoutesResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[42]
+	mi := &file_viz_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3104,7 +3314,7 @@ type RouteTable_Row struct {
 func (x *RouteTable_Row) Reset() {
 	*x = RouteTable_Row{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[43]
+		mi := &file_viz_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3117,7 +3327,7 @@ func (x *RouteTable_Row) String() string {
 func (*RouteTable_Row) ProtoMessage() {}
 
 func (x *RouteTable_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[43]
+	mi := &file_viz_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3179,7 +3389,7 @@ type GatewaysTable_Row struct {
 func (x *GatewaysTable_Row) Reset() {
 	*x = GatewaysTable_Row{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[44]
+		mi := &file_viz_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3192,7 +3402,7 @@ func (x *GatewaysTable_Row) String() string {
 func (*GatewaysTable_Row) ProtoMessage() {}
 
 func (x *GatewaysTable_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[44]
+	mi := &file_viz_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3275,7 +3485,7 @@ type GatewaysResponse_Ok struct {
 func (x *GatewaysResponse_Ok) Reset() {
 	*x = GatewaysResponse_Ok{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[45]
+		mi := &file_viz_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3288,7 +3498,7 @@ func (x *GatewaysResponse_Ok) String() string {
 func (*GatewaysResponse_Ok) ProtoMessage() {}
 
 func (x *GatewaysResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[45]
+	mi := &file_viz_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3311,6 +3521,152 @@ func (x *GatewaysResponse_Ok) GetGatewaysTable() *GatewaysTable {
 	return nil
 }
 
+type MulticlusterStatsTable_Row struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName string `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	// namespace and name of the service in the target cluster; only set when
+	// stats are broken down by service
+	ServiceNamespace string `protobuf:"bytes,2,opt,name=service_namespace,json=serviceNamespace,proto3" json:"service_namespace,omitempty"`
+	ServiceName      string `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// namespace and name of the local deployment sending traffic; only set
+	// when stats are broken down by workload
+	FromNamespace  string      `protobuf:"bytes,4,opt,name=from_namespace,json=fromNamespace,proto3" json:"from_namespace,omitempty"`
+	FromDeployment string      `protobuf:"bytes,5,opt,name=from_deployment,json=fromDeployment,proto3" json:"from_deployment,omitempty"`
+	Stats          *BasicStats `protobuf:"bytes,6,opt,name=stats,proto3" json:"stats,omitempty"`
+	TimeWindow     string      `protobuf:"bytes,7,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+}
+
+func (x *MulticlusterStatsTable_Row) Reset() {
+	*x = MulticlusterStatsTable_Row{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MulticlusterStatsTable_Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MulticlusterStatsTable_Row) ProtoMessage() {}
+
+func (x *MulticlusterStatsTable_Row) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MulticlusterStatsTable_Row.ProtoReflect.Descriptor instead.
+func (*MulticlusterStatsTable_Row) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{34, 0}
+}
+
+func (x *MulticlusterStatsTable_Row) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *MulticlusterStatsTable_Row) GetServiceNamespace() string {
+	if x != nil {
+		return x.ServiceNamespace
+	}
+	return ""
+}
+
+func (x *MulticlusterStatsTable_Row) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *MulticlusterStatsTable_Row) GetFromNamespace() string {
+	if x != nil {
+		return x.FromNamespace
+	}
+	return ""
+}
+
+func (x *MulticlusterStatsTable_Row) GetFromDeployment() string {
+	if x != nil {
+		return x.FromDeployment
+	}
+	return ""
+}
+
+func (x *MulticlusterStatsTable_Row) GetStats() *BasicStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *MulticlusterStatsTable_Row) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return ""
+}
+
+type MulticlusterStatsResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MulticlusterStatsTable *MulticlusterStatsTable `protobuf:"bytes,1,opt,name=multicluster_stats_table,json=multiclusterStatsTable,proto3" json:"multicluster_stats_table,omitempty"`
+}
+
+func (x *MulticlusterStatsResponse_Ok) Reset() {
+	*x = MulticlusterStatsResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MulticlusterStatsResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MulticlusterStatsResponse_Ok) ProtoMessage() {}
+
+func (x *MulticlusterStatsResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MulticlusterStatsResponse_Ok.ProtoReflect.Descriptor instead.
+func (*MulticlusterStatsResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{36, 0}
+}
+
+func (x *MulticlusterStatsResponse_Ok) GetMulticlusterStatsTable() *MulticlusterStatsTable {
+	if x != nil {
+		return x.MulticlusterStatsTable
+	}
+	return nil
+}
+
 var File_viz_proto protoreflect.FileDescriptor
 
 var file_viz_proto_rawDesc = []byte{
@@ -3707,49 +4063,108 @@ var file_viz_proto_rawDesc = []byte{
 	0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
 	0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0d,
 	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x0a, 0x0a,
-	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x2a, 0x0a, 0x0b, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00,
-	0x12, 0x08, 0x0a, 0x04, 0x46, 0x41, 0x49, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52,
-	0x52, 0x4f, 0x52, 0x10, 0x02, 0x32, 0xb2, 0x04, 0x0a, 0x03, 0x41, 0x70, 0x69, 0x12, 0x54, 0x0a,
-	0x0b, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x20, 0x2e, 0x6c,
-	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
-	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74,
-	0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x05, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x6c,
-	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x64, 0x67, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x08, 0x47, 0x61, 0x74, 0x65, 0x77,
-	0x61, 0x79, 0x73, 0x12, 0x1d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76,
-	0x69, 0x7a, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
-	0x7a, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x09, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65,
-	0x73, 0x12, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
-	0x2e, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
-	0x2e, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73,
+	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xf2, 0x02, 0x0a, 0x16, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x3c, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
+	0x7a, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f,
+	0x77, 0x73, 0x1a, 0x99, 0x02, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a,
+	0x11, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x66,
+	0x72, 0x6f, 0x6d, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x42, 0x61, 0x73, 0x69,
+	0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0xd2,
+	0x01, 0x0a, 0x18, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x79, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x62, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x79, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x62, 0x79, 0x57, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x22, 0x80, 0x02, 0x0a, 0x19, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3c, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12,
+	0x33, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x1a, 0x64, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x5e, 0x0a, 0x18, 0x6d, 0x75,
+	0x6c, 0x74, 0x69, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4d, 0x75, 0x6c, 0x74,
+	0x69, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x52, 0x16, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x2a, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x12, 0x08, 0x0a,
+	0x04, 0x46, 0x41, 0x49, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x10, 0x02, 0x32, 0x9a, 0x05, 0x0a, 0x03, 0x41, 0x70, 0x69, 0x12, 0x54, 0x0a, 0x0b, 0x53, 0x74,
+	0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x20, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x42, 0x0a, 0x05, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x08, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73,
 	0x12, 0x1d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x57, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x73, 0x12, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x09, 0x53, 0x65,
-	0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
-	0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2f, 0x76, 0x69, 0x7a, 0x2f, 0x6d, 0x65,
-	0x74, 0x72, 0x69, 0x63, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x69,
-	0x7a, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x47,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x66, 0x0a, 0x11, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x26, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x09, 0x54, 0x6f, 0x70,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x08, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x6f, 0x64, 0x73, 0x12, 0x1d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x4e, 0x0a, 0x09, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1e, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x65, 0x6c, 0x66,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x65, 0x6c, 0x66,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
+	0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2f, 0x76,
+	0x69, 0x7a, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x67,
+	0x65, 0x6e, 0x2f, 0x76, 0x69, 0x7a, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3765,7 +4180,7 @@ func file_viz_proto_rawDescGZIP() []byte {
 }
 
 var file_viz_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_viz_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
+var file_viz_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_viz_proto_goTypes = []interface{}{
 	(CheckStatus)(0),                          // 0: linkerd2.viz.CheckStatus
 	(HttpMethod_Registered)(0),                // 1: linkerd2.viz.HttpMethod.Registered
@@ -3804,19 +4219,24 @@ var file_viz_proto_goTypes = []interface{}{
 	(*GatewaysTable)(nil),                     // 34: linkerd2.viz.GatewaysTable
 	(*GatewaysRequest)(nil),                   // 35: linkerd2.viz.GatewaysRequest
 	(*GatewaysResponse)(nil),                  // 36: linkerd2.viz.GatewaysResponse
-	(*Headers_Header)(nil),                    // 37: linkerd2.viz.Headers.Header
-	(*PodErrors_PodError)(nil),                // 38: linkerd2.viz.PodErrors.PodError
-	(*PodErrors_PodError_ContainerError)(nil), // 39: linkerd2.viz.PodErrors.PodError.ContainerError
-	(*StatSummaryResponse_Ok)(nil),            // 40: linkerd2.viz.StatSummaryResponse.Ok
-	(*StatTable_PodGroup)(nil),                // 41: linkerd2.viz.StatTable.PodGroup
-	(*StatTable_PodGroup_Row)(nil),            // 42: linkerd2.viz.StatTable.PodGroup.Row
-	nil,                                       // 43: linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry
-	(*EdgesResponse_Ok)(nil),                  // 44: linkerd2.viz.EdgesResponse.Ok
-	(*TopRoutesResponse_Ok)(nil),              // 45: linkerd2.viz.TopRoutesResponse.Ok
-	(*RouteTable_Row)(nil),                    // 46: linkerd2.viz.RouteTable.Row
-	(*GatewaysTable_Row)(nil),                 // 47: linkerd2.viz.GatewaysTable.Row
-	(*GatewaysResponse_Ok)(nil),               // 48: linkerd2.viz.GatewaysResponse.Ok
-	(*duration.Duration)(nil),                 // 49: google.protobuf.Duration
+	(*MulticlusterStatsTable)(nil),            // 37: linkerd2.viz.MulticlusterStatsTable
+	(*MulticlusterStatsRequest)(nil),          // 38: linkerd2.viz.MulticlusterStatsRequest
+	(*MulticlusterStatsResponse)(nil),         // 39: linkerd2.viz.MulticlusterStatsResponse
+	(*Headers_Header)(nil),                    // 40: linkerd2.viz.Headers.Header
+	(*PodErrors_PodError)(nil),                // 41: linkerd2.viz.PodErrors.PodError
+	(*PodErrors_PodError_ContainerError)(nil), // 42: linkerd2.viz.PodErrors.PodError.ContainerError
+	(*StatSummaryResponse_Ok)(nil),            // 43: linkerd2.viz.StatSummaryResponse.Ok
+	(*StatTable_PodGroup)(nil),                // 44: linkerd2.viz.StatTable.PodGroup
+	(*StatTable_PodGroup_Row)(nil),            // 45: linkerd2.viz.StatTable.PodGroup.Row
+	nil,                                       // 46: linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry
+	(*EdgesResponse_Ok)(nil),                  // 47: linkerd2.viz.EdgesResponse.Ok
+	(*TopRoutesResponse_Ok)(nil),              // 48: linkerd2.viz.TopRoutesResponse.Ok
+	(*RouteTable_Row)(nil),                    // 49: linkerd2.viz.RouteTable.Row
+	(*GatewaysTable_Row)(nil),                 // 50: linkerd2.viz.GatewaysTable.Row
+	(*GatewaysResponse_Ok)(nil),               // 51: linkerd2.viz.GatewaysResponse.Ok
+	(*MulticlusterStatsTable_Row)(nil),        // 52: linkerd2.viz.MulticlusterStatsTable.Row
+	(*MulticlusterStatsResponse_Ok)(nil),      // 53: linkerd2.viz.MulticlusterStatsResponse.Ok
+	(*duration.Duration)(nil),                 // 54: google.protobuf.Duration
 }
 var file_viz_proto_depIdxs = []int32{
 	0,  // 0: linkerd2.viz.CheckResult.Status:type_name -> linkerd2.viz.CheckStatus
@@ -3824,23 +4244,23 @@ var file_viz_proto_depIdxs = []int32{
 	9,  // 2: linkerd2.viz.ListServicesResponse.services:type_name -> linkerd2.viz.Service
 	20, // 3: linkerd2.viz.ListPodsRequest.selector:type_name -> linkerd2.viz.ResourceSelection
 	12, // 4: linkerd2.viz.ListPodsResponse.pods:type_name -> linkerd2.viz.Pod
-	49, // 5: linkerd2.viz.Pod.sinceLastReport:type_name -> google.protobuf.Duration
-	49, // 6: linkerd2.viz.Pod.uptime:type_name -> google.protobuf.Duration
+	54, // 5: linkerd2.viz.Pod.sinceLastReport:type_name -> google.protobuf.Duration
+	54, // 6: linkerd2.viz.Pod.uptime:type_name -> google.protobuf.Duration
 	1,  // 7: linkerd2.viz.HttpMethod.registered:type_name -> linkerd2.viz.HttpMethod.Registered
 	2,  // 8: linkerd2.viz.Scheme.registered:type_name -> linkerd2.viz.Scheme.Registered
-	37, // 9: linkerd2.viz.Headers.headers:type_name -> linkerd2.viz.Headers.Header
-	38, // 10: linkerd2.viz.PodErrors.errors:type_name -> linkerd2.viz.PodErrors.PodError
+	40, // 9: linkerd2.viz.Headers.headers:type_name -> linkerd2.viz.Headers.Header
+	41, // 10: linkerd2.viz.PodErrors.errors:type_name -> linkerd2.viz.PodErrors.PodError
 	19, // 11: linkerd2.viz.ResourceSelection.resource:type_name -> linkerd2.viz.Resource
 	19, // 12: linkerd2.viz.ResourceError.resource:type_name -> linkerd2.viz.Resource
 	20, // 13: linkerd2.viz.StatSummaryRequest.selector:type_name -> linkerd2.viz.ResourceSelection
 	3,  // 14: linkerd2.viz.StatSummaryRequest.none:type_name -> linkerd2.viz.Empty
 	19, // 15: linkerd2.viz.StatSummaryRequest.to_resource:type_name -> linkerd2.viz.Resource
 	19, // 16: linkerd2.viz.StatSummaryRequest.from_resource:type_name -> linkerd2.viz.Resource
-	40, // 17: linkerd2.viz.StatSummaryResponse.ok:type_name -> linkerd2.viz.StatSummaryResponse.Ok
+	43, // 17: linkerd2.viz.StatSummaryResponse.ok:type_name -> linkerd2.viz.StatSummaryResponse.Ok
 	21, // 18: linkerd2.viz.StatSummaryResponse.error:type_name -> linkerd2.viz.ResourceError
-	41, // 19: linkerd2.viz.StatTable.pod_group:type_name -> linkerd2.viz.StatTable.PodGroup
+	44, // 19: linkerd2.viz.StatTable.pod_group:type_name -> linkerd2.viz.StatTable.PodGroup
 	20, // 20: linkerd2.viz.EdgesRequest.selector:type_name -> linkerd2.viz.ResourceSelection
-	44, // 21: linkerd2.viz.EdgesResponse.ok:type_name -> linkerd2.viz.EdgesResponse.Ok
+	47, // 21: linkerd2.viz.EdgesResponse.ok:type_name -> linkerd2.viz.EdgesResponse.Ok
 	21, // 22: linkerd2.viz.EdgesResponse.error:type_name -> linkerd2.viz.ResourceError
 	19, // 23: linkerd2.viz.Edge.src:type_name -> linkerd2.viz.Resource
 	19, // 24: linkerd2.viz.Edge.dst:type_name -> linkerd2.viz.Resource
@@ -3848,43 +4268,50 @@ var file_viz_proto_depIdxs = []int32{
 	3,  // 26: linkerd2.viz.TopRoutesRequest.none:type_name -> linkerd2.viz.Empty
 	19, // 27: linkerd2.viz.TopRoutesRequest.to_resource:type_name -> linkerd2.viz.Resource
 	21, // 28: linkerd2.viz.TopRoutesResponse.error:type_name -> linkerd2.viz.ResourceError
-	45, // 29: linkerd2.viz.TopRoutesResponse.ok:type_name -> linkerd2.viz.TopRoutesResponse.Ok
-	46, // 30: linkerd2.viz.RouteTable.rows:type_name -> linkerd2.viz.RouteTable.Row
-	47, // 31: linkerd2.viz.GatewaysTable.rows:type_name -> linkerd2.viz.GatewaysTable.Row
-	48, // 32: linkerd2.viz.GatewaysResponse.ok:type_name -> linkerd2.viz.GatewaysResponse.Ok
+	48, // 29: linkerd2.viz.TopRoutesResponse.ok:type_name -> linkerd2.viz.TopRoutesResponse.Ok
+	49, // 30: linkerd2.viz.RouteTable.rows:type_name -> linkerd2.viz.RouteTable.Row
+	50, // 31: linkerd2.viz.GatewaysTable.rows:type_name -> linkerd2.viz.GatewaysTable.Row
+	51, // 32: linkerd2.viz.GatewaysResponse.ok:type_name -> linkerd2.viz.GatewaysResponse.Ok
 	21, // 33: linkerd2.viz.GatewaysResponse.error:type_name -> linkerd2.viz.ResourceError
-	39, // 34: linkerd2.viz.PodErrors.PodError.container:type_name -> linkerd2.viz.PodErrors.PodError.ContainerError
-	27, // 35: linkerd2.viz.StatSummaryResponse.Ok.stat_tables:type_name -> linkerd2.viz.StatTable
-	42, // 36: linkerd2.viz.StatTable.PodGroup.rows:type_name -> linkerd2.viz.StatTable.PodGroup.Row
-	19, // 37: linkerd2.viz.StatTable.PodGroup.Row.resource:type_name -> linkerd2.viz.Resource
-	24, // 38: linkerd2.viz.StatTable.PodGroup.Row.stats:type_name -> linkerd2.viz.BasicStats
-	25, // 39: linkerd2.viz.StatTable.PodGroup.Row.tcp_stats:type_name -> linkerd2.viz.TcpStats
-	26, // 40: linkerd2.viz.StatTable.PodGroup.Row.ts_stats:type_name -> linkerd2.viz.TrafficSplitStats
-	43, // 41: linkerd2.viz.StatTable.PodGroup.Row.errors_by_pod:type_name -> linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry
-	18, // 42: linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry.value:type_name -> linkerd2.viz.PodErrors
-	30, // 43: linkerd2.viz.EdgesResponse.Ok.edges:type_name -> linkerd2.viz.Edge
-	33, // 44: linkerd2.viz.TopRoutesResponse.Ok.routes:type_name -> linkerd2.viz.RouteTable
-	24, // 45: linkerd2.viz.RouteTable.Row.stats:type_name -> linkerd2.viz.BasicStats
-	34, // 46: linkerd2.viz.GatewaysResponse.Ok.gateways_table:type_name -> linkerd2.viz.GatewaysTable
-	22, // 47: linkerd2.viz.Api.StatSummary:input_type -> linkerd2.viz.StatSummaryRequest
-	28, // 48: linkerd2.viz.Api.Edges:input_type -> linkerd2.viz.EdgesRequest
-	35, // 49: linkerd2.viz.Api.Gateways:input_type -> linkerd2.viz.GatewaysRequest
-	31, // 50: linkerd2.viz.Api.TopRoutes:input_type -> linkerd2.viz.TopRoutesRequest
-	10, // 51: linkerd2.viz.Api.ListPods:input_type -> linkerd2.viz.ListPodsRequest
-	7,  // 52: linkerd2.viz.Api.ListServices:input_type -> linkerd2.viz.ListServicesRequest
-	5,  // 53: linkerd2.viz.Api.SelfCheck:input_type -> linkerd2.viz.SelfCheckRequest
-	23, // 54: linkerd2.viz.Api.StatSummary:output_type -> linkerd2.viz.StatSummaryResponse
-	29, // 55: linkerd2.viz.Api.Edges:output_type -> linkerd2.viz.EdgesResponse
-	36, // 56: linkerd2.viz.Api.Gateways:output_type -> linkerd2.viz.GatewaysResponse
-	32, // 57: linkerd2.viz.Api.TopRoutes:output_type -> linkerd2.viz.TopRoutesResponse
-	11, // 58: linkerd2.viz.Api.ListPods:output_type -> linkerd2.viz.ListPodsResponse
-	8,  // 59: linkerd2.viz.Api.ListServices:output_type -> linkerd2.viz.ListServicesResponse
-	6,  // 60: linkerd2.viz.Api.SelfCheck:output_type -> linkerd2.viz.SelfCheckResponse
-	54, // [54:61] is the sub-list for method output_type
-	47, // [47:54] is the sub-list for method input_type
-	47, // [47:47] is the sub-list for extension type_name
-	47, // [47:47] is the sub-list for extension extendee
-	0,  // [0:47] is the sub-list for field type_name
+	52, // 34: linkerd2.viz.MulticlusterStatsTable.rows:type_name -> linkerd2.viz.MulticlusterStatsTable.Row
+	53, // 35: linkerd2.viz.MulticlusterStatsResponse.ok:type_name -> linkerd2.viz.MulticlusterStatsResponse.Ok
+	21, // 36: linkerd2.viz.MulticlusterStatsResponse.error:type_name -> linkerd2.viz.ResourceError
+	42, // 37: linkerd2.viz.PodErrors.PodError.container:type_name -> linkerd2.viz.PodErrors.PodError.ContainerError
+	27, // 38: linkerd2.viz.StatSummaryResponse.Ok.stat_tables:type_name -> linkerd2.viz.StatTable
+	45, // 39: linkerd2.viz.StatTable.PodGroup.rows:type_name -> linkerd2.viz.StatTable.PodGroup.Row
+	19, // 40: linkerd2.viz.StatTable.PodGroup.Row.resource:type_name -> linkerd2.viz.Resource
+	24, // 41: linkerd2.viz.StatTable.PodGroup.Row.stats:type_name -> linkerd2.viz.BasicStats
+	25, // 42: linkerd2.viz.StatTable.PodGroup.Row.tcp_stats:type_name -> linkerd2.viz.TcpStats
+	26, // 43: linkerd2.viz.StatTable.PodGroup.Row.ts_stats:type_name -> linkerd2.viz.TrafficSplitStats
+	46, // 44: linkerd2.viz.StatTable.PodGroup.Row.errors_by_pod:type_name -> linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry
+	18, // 45: linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry.value:type_name -> linkerd2.viz.PodErrors
+	30, // 46: linkerd2.viz.EdgesResponse.Ok.edges:type_name -> linkerd2.viz.Edge
+	33, // 47: linkerd2.viz.TopRoutesResponse.Ok.routes:type_name -> linkerd2.viz.RouteTable
+	24, // 48: linkerd2.viz.RouteTable.Row.stats:type_name -> linkerd2.viz.BasicStats
+	34, // 49: linkerd2.viz.GatewaysResponse.Ok.gateways_table:type_name -> linkerd2.viz.GatewaysTable
+	24, // 50: linkerd2.viz.MulticlusterStatsTable.Row.stats:type_name -> linkerd2.viz.BasicStats
+	37, // 51: linkerd2.viz.MulticlusterStatsResponse.Ok.multicluster_stats_table:type_name -> linkerd2.viz.MulticlusterStatsTable
+	22, // 52: linkerd2.viz.Api.StatSummary:input_type -> linkerd2.viz.StatSummaryRequest
+	28, // 53: linkerd2.viz.Api.Edges:input_type -> linkerd2.viz.EdgesRequest
+	35, // 54: linkerd2.viz.Api.Gateways:input_type -> linkerd2.viz.GatewaysRequest
+	38, // 55: linkerd2.viz.Api.MulticlusterStats:input_type -> linkerd2.viz.MulticlusterStatsRequest
+	31, // 56: linkerd2.viz.Api.TopRoutes:input_type -> linkerd2.viz.TopRoutesRequest
+	10, // 57: linkerd2.viz.Api.ListPods:input_type -> linkerd2.viz.ListPodsRequest
+	7,  // 58: linkerd2.viz.Api.ListServices:input_type -> linkerd2.viz.ListServicesRequest
+	5,  // 59: linkerd2.viz.Api.SelfCheck:input_type -> linkerd2.viz.SelfCheckRequest
+	23, // 60: linkerd2.viz.Api.StatSummary:output_type -> linkerd2.viz.StatSummaryResponse
+	29, // 61: linkerd2.viz.Api.Edges:output_type -> linkerd2.viz.EdgesResponse
+	36, // 62: linkerd2.viz.Api.Gateways:output_type -> linkerd2.viz.GatewaysResponse
+	39, // 63: linkerd2.viz.Api.MulticlusterStats:output_type -> linkerd2.viz.MulticlusterStatsResponse
+	32, // 64: linkerd2.viz.Api.TopRoutes:output_type -> linkerd2.viz.TopRoutesResponse
+	11, // 65: linkerd2.viz.Api.ListPods:output_type -> linkerd2.viz.ListPodsResponse
+	8,  // 66: linkerd2.viz.Api.ListServices:output_type -> linkerd2.viz.ListServicesResponse
+	6,  // 67: linkerd2.viz.Api.SelfCheck:output_type -> linkerd2.viz.SelfCheckResponse
+	60, // [60:68] is the sub-list for method output_type
+	52, // [52:60] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_viz_proto_init() }
@@ -4302,7 +4729,7 @@ func file_viz_proto_init() {
 			}
 		}
 		file_viz_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Headers_Header); i {
+			switch v := v.(*MulticlusterStatsTable); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4314,7 +4741,7 @@ func file_viz_proto_init() {
 			}
 		}
 		file_viz_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PodErrors_PodError); i {
+			switch v := v.(*MulticlusterStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4326,7 +4753,7 @@ func file_viz_proto_init() {
 			}
 		}
 		file_viz_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PodErrors_PodError_ContainerError); i {
+			switch v := v.(*MulticlusterStatsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4338,7 +4765,7 @@ func file_viz_proto_init() {
 			}
 		}
 		file_viz_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatSummaryResponse_Ok); i {
+			switch v := v.(*Headers_Header); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4350,7 +4777,7 @@ func file_viz_proto_init() {
 			}
 		}
 		file_viz_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatTable_PodGroup); i {
+			switch v := v.(*PodErrors_PodError); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4362,7 +4789,19 @@ func file_viz_proto_init() {
 			}
 		}
 		file_viz_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatTable_PodGroup_Row); i {
+			switch v := v.(*PodErrors_PodError_ContainerError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatSummaryResponse_Ok); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4374,7 +4813,7 @@ func file_viz_proto_init() {
 			}
 		}
 		file_viz_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EdgesResponse_Ok); i {
+			switch v := v.(*StatTable_PodGroup); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4386,6 +4825,30 @@ func file_viz_proto_init() {
 			}
 		}
 		file_viz_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatTable_PodGroup_Row); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgesResponse_Ok); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TopRoutesResponse_Ok); i {
 			case 0:
 				return &v.state
@@ -4397,7 +4860,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RouteTable_Row); i {
 			case 0:
 				return &v.state
@@ -4409,7 +4872,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GatewaysTable_Row); i {
 			case 0:
 				return &v.state
@@ -4421,7 +4884,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GatewaysResponse_Ok); i {
 			case 0:
 				return &v.state
@@ -4433,6 +4896,30 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
+		file_viz_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MulticlusterStatsTable_Row); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MulticlusterStatsResponse_Ok); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_viz_proto_msgTypes[9].OneofWrappers = []interface{}{
 		(*Pod_Deployment)(nil),
@@ -4482,11 +4969,15 @@ func file_viz_proto_init() {
 		(*GatewaysResponse_Ok_)(nil),
 		(*GatewaysResponse_Error)(nil),
 	}
-	file_viz_proto_msgTypes[34].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[36].OneofWrappers = []interface{}{
+		(*MulticlusterStatsResponse_Ok_)(nil),
+		(*MulticlusterStatsResponse_Error)(nil),
+	}
+	file_viz_proto_msgTypes[37].OneofWrappers = []interface{}{
 		(*Headers_Header_ValueStr)(nil),
 		(*Headers_Header_ValueBin)(nil),
 	}
-	file_viz_proto_msgTypes[35].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[38].OneofWrappers = []interface{}{
 		(*PodErrors_PodError_Container)(nil),
 	}
 	type x struct{}
@@ -4495,7 +4986,7 @@ func file_viz_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_viz_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   46,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,7 @@ type ApiClient interface {
 	StatSummary(ctx context.Context, in *StatSummaryRequest, opts ...grpc.CallOption) (*StatSummaryResponse, error)
 	Edges(ctx context.Context, in *EdgesRequest, opts ...grpc.CallOption) (*EdgesResponse, error)
 	Gateways(ctx context.Context, in *GatewaysRequest, opts ...grpc.CallOption) (*GatewaysResponse, error)
+	MulticlusterStats(ctx context.Context, in *MulticlusterStatsRequest, opts ...grpc.CallOption) (*MulticlusterStatsResponse, error)
 	TopRoutes(ctx context.Context, in *TopRoutesRequest, opts ...grpc.CallOption) (*TopRoutesResponse, error)
 	ListPods(ctx context.Context, in *ListPodsRequest, opts ...grpc.CallOption) (*ListPodsResponse, error)
 	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
@@ -62,6 +63,15 @@ func (c *apiClient) Gateways(ctx context.Context, in *GatewaysRequest, opts ...g
 	return out, nil
 }
 
+func (c *apiClient) MulticlusterStats(ctx context.Context, in *MulticlusterStatsRequest, opts ...grpc.CallOption) (*MulticlusterStatsResponse, error) {
+	out := new(MulticlusterStatsResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/MulticlusterStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiClient) TopRoutes(ctx context.Context, in *TopRoutesRequest, opts ...grpc.CallOption) (*TopRoutesResponse, error) {
 	out := new(TopRoutesResponse)
 	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/TopRoutes", in, out, opts...)
@@ -105,6 +115,7 @@ type ApiServer interface {
 	StatSummary(context.Context, *StatSummaryRequest) (*StatSummaryResponse, error)
 	Edges(context.Context, *EdgesRequest) (*EdgesResponse, error)
 	Gateways(context.Context, *GatewaysRequest) (*GatewaysResponse, error)
+	MulticlusterStats(context.Context, *MulticlusterStatsRequest) (*MulticlusterStatsResponse, error)
 	TopRoutes(context.Context, *TopRoutesRequest) (*TopRoutesResponse, error)
 	ListPods(context.Context, *ListPodsRequest) (*ListPodsResponse, error)
 	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
@@ -125,6 +136,9 @@ func (UnimplementedApiServer) Edges(context.Context, *EdgesRequest) (*EdgesRespo
 func (UnimplementedApiServer) Gateways(context.Context, *GatewaysRequest) (*GatewaysResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Gateways not implemented")
 }
+func (UnimplementedApiServer) MulticlusterStats(context.Context, *MulticlusterStatsRequest) (*MulticlusterStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MulticlusterStats not implemented")
+}
 func (UnimplementedApiServer) TopRoutes(context.Context, *TopRoutesRequest) (*TopRoutesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TopRoutes not implemented")
 }
@@ -204,6 +218,24 @@ func _Api_Gateways_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Api_MulticlusterStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MulticlusterStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).MulticlusterStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.viz.Api/MulticlusterStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).MulticlusterStats(ctx, req.(*MulticlusterStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Api_TopRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TopRoutesRequest)
 	if err := dec(in); err != nil {
@@ -295,6 +327,10 @@ var Api_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Gateways",
 			Handler:    _Api_Gateways_Handler,
 		},
+		{
+			MethodName: "MulticlusterStats",
+			Handler:    _Api_MulticlusterStats_Handler,
+		},
 		{
 			MethodName: "TopRoutes",
 			Handler:    _Api_TopRoutes_Handler,
@@ -15,13 +15,14 @@ import (
 )
 
 var (
-	gatewaysPath     = fullURLPathFor("Gateways")
-	statSummaryPath  = fullURLPathFor("StatSummary")
-	topRoutesPath    = fullURLPathFor("TopRoutes")
-	listPodsPath     = fullURLPathFor("ListPods")
-	listServicesPath = fullURLPathFor("ListServices")
-	selfCheckPath    = fullURLPathFor("SelfCheck")
-	edgesPath        = fullURLPathFor("Edges")
+	gatewaysPath          = fullURLPathFor("Gateways")
+	multiclusterStatsPath = fullURLPathFor("MulticlusterStats")
+	statSummaryPath       = fullURLPathFor("StatSummary")
+	topRoutesPath         = fullURLPathFor("TopRoutes")
+	listPodsPath          = fullURLPathFor("ListPods")
+	listServicesPath      = fullURLPathFor("ListServices")
+	selfCheckPath         = fullURLPathFor("SelfCheck")
+	edgesPath             = fullURLPathFor("Edges")
 )
 
 type handler struct {
@@ -42,6 +43,8 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	switch req.URL.Path {
 	case gatewaysPath:
 		h.handleGateways(w, req)
+	case multiclusterStatsPath:
+		h.handleMulticlusterStats(w, req)
 	case statSummaryPath:
 		h.handleStatSummary(w, req)
 	case topRoutesPath:
@@ -81,6 +84,27 @@ func (h *handler) handleGateways(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func (h *handler) handleMulticlusterStats(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.MulticlusterStatsRequest
+
+	err := protohttp.HTTPRequestToProto(req, &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.MulticlusterStats(req.Context(), &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+	err = protohttp.WriteProtoToHTTPResponse(w, rsp)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+}
+
 func (h *handler) handleStatSummary(w http.ResponseWriter, req *http.Request) {
 	var protoRequest pb.StatSummaryRequest
 
@@ -238,7 +262,16 @@ func NewServer(
 		grpcServer: grpcServer,
 	}
 
-	instrumentedHandler := prometheus.WithTelemetry(baseHandler)
+	instrumentedHandler := prometheus.WithTelemetry(baseHandler, prometheus.PathRoute(
+		gatewaysPath,
+		multiclusterStatsPath,
+		statSummaryPath,
+		topRoutesPath,
+		listPodsPath,
+		listServicesPath,
+		selfCheckPath,
+		edgesPath,
+	))
 
 	return &http.Server{
 		Addr:    addr,
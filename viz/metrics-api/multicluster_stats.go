@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	mcReqQuery             = "sum(%s) by (%s)"
+	mcReqSelector          = "increase(response_total%s[%s])"
+	mcLatencyQuantileQuery = "histogram_quantile(%s, sum(%s) by (%s))"
+	mcLatencySelector      = "irate(response_latency_ms_bucket%s[%s])"
+
+	// mcMinTimeWindow matches the lower bound of the --time-window flag
+	mcMinTimeWindow = 15 * time.Second
+
+	dstServiceLabel = model.LabelName("dst_service")
+	deploymentLabel = model.LabelName("deployment")
+)
+
+// mirrorService is a local service mirroring a service from a target cluster
+type mirrorService struct {
+	remoteNamespace string
+	remoteName      string
+}
+
+type mcStatsKey struct {
+	serviceNamespace string
+	serviceName      string
+	fromNamespace    string
+	fromDeployment   string
+}
+
+func (s *grpcServer) MulticlusterStats(ctx context.Context, req *pb.MulticlusterStatsRequest) (*pb.MulticlusterStatsResponse, error) {
+	if err := validateTimeWindow(req.TimeWindow); err != nil {
+		return nil, err
+	}
+
+	mirrors, err := s.getMirrorServices(ctx, req.RemoteClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The proxies know nothing about links, so the traffic sent to each target
+	// cluster is found by joining the request metrics with the mirror
+	// services created for it.
+	rows := []*pb.MulticlusterStatsTable_Row{}
+	for clusterName, services := range mirrors {
+		stats, err := s.getMulticlusterStatsMetrics(ctx, req, services)
+		if err != nil {
+			return nil, err
+		}
+		for key, basicStats := range stats {
+			rows = append(rows, &pb.MulticlusterStatsTable_Row{
+				ClusterName:      clusterName,
+				ServiceNamespace: key.serviceNamespace,
+				ServiceName:      key.serviceName,
+				FromNamespace:    key.fromNamespace,
+				FromDeployment:   key.fromDeployment,
+				Stats:            basicStats,
+				TimeWindow:       req.TimeWindow,
+			})
+		}
+	}
+	sortMulticlusterStatsRows(rows)
+
+	return &pb.MulticlusterStatsResponse{
+		Response: &pb.MulticlusterStatsResponse_Ok_{
+			Ok: &pb.MulticlusterStatsResponse_Ok{
+				MulticlusterStatsTable: &pb.MulticlusterStatsTable{
+					Rows: rows,
+				},
+			},
+		},
+	}, nil
+}
+
+// getMirrorServices returns, for each target cluster, the local mirror services
+// keyed by namespace/name.
+func (s *grpcServer) getMirrorServices(ctx context.Context, clusterName string) (map[string]map[string]mirrorService, error) {
+	selector := fmt.Sprintf("%s,!%s", k8s.MirroredResourceLabel, k8s.MirroredGatewayLabel)
+	if clusterName != "" {
+		selector = fmt.Sprintf("%s,%s=%s", selector, k8s.RemoteClusterNameLabel, clusterName)
+	}
+	services, err := s.k8sAPI.Client.CoreV1().Services(corev1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]map[string]mirrorService)
+	for _, svc := range services.Items {
+		clusterName := svc.Labels[k8s.RemoteClusterNameLabel]
+		if results[clusterName] == nil {
+			results[clusterName] = make(map[string]mirrorService)
+		}
+		// the fully qualified name has the form name.namespace.svc.cluster-domain
+		mirror := mirrorService{}
+		fqParts := strings.Split(svc.Annotations[k8s.RemoteServiceFqName], ".")
+		if len(fqParts) >= 2 {
+			mirror.remoteName, mirror.remoteNamespace = fqParts[0], fqParts[1]
+		}
+		results[clusterName][svc.Namespace+"/"+svc.Name] = mirror
+	}
+	return results, nil
+}
+
+func buildMulticlusterStatsRequestLabels(req *pb.MulticlusterStatsRequest) (labels model.LabelSet, labelNames model.LabelNames) {
+	labels = promDirectionLabels("outbound")
+	if req.FromNamespace != "" {
+		labels[namespaceLabel] = model.LabelValue(req.FromNamespace)
+	}
+
+	groupBy := model.LabelNames{}
+	if req.ByService {
+		groupBy = append(groupBy, dstNamespaceLabel, dstServiceLabel)
+	}
+	if req.ByWorkload {
+		groupBy = append(groupBy, namespaceLabel, deploymentLabel)
+	}
+	return labels, groupBy
+}
+
+// validateTimeWindow checks that the time window is a Prometheus duration of
+// at least mcMinTimeWindow, as it's inserted as is into the queries.
+func validateTimeWindow(timeWindow string) error {
+	window, err := model.ParseDuration(timeWindow)
+	if err != nil {
+		return fmt.Errorf("invalid time window %q: %s", timeWindow, err)
+	}
+	if time.Duration(window) < mcMinTimeWindow {
+		return fmt.Errorf("invalid time window %q: it needs to be at least %s", timeWindow, mcMinTimeWindow)
+	}
+	return nil
+}
+
+// mirrorSelectors returns the selectors of the metrics of the requests sent to
+// the given mirror services, joined with "or". Mirror names can be reused
+// across namespaces, so each namespace gets its own selector, restricted to
+// the names of its mirrors.
+func mirrorSelectors(selector string, labels model.LabelSet, timeWindow string, mirrors map[string]mirrorService) string {
+	namesByNamespace := make(map[string][]string)
+	for key := range mirrors {
+		parts := strings.SplitN(key, "/", 2)
+		namesByNamespace[parts[0]] = append(namesByNamespace[parts[0]], parts[1])
+	}
+	namespaces := make([]string, 0, len(namesByNamespace))
+	for ns := range namesByNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	selectors := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		names := namesByNamespace[ns]
+		sort.Strings(names)
+		nsLabels := labels.Clone()
+		nsLabels[dstNamespaceLabel] = model.LabelValue(ns)
+		selectors = append(selectors, fmt.Sprintf(selector, generateLabelStringWithAlternatives(nsLabels, string(dstServiceLabel), names), timeWindow))
+	}
+	return strings.Join(selectors, " or ")
+}
+
+func (s *grpcServer) getMulticlusterStatsMetrics(ctx context.Context, req *pb.MulticlusterStatsRequest, mirrors map[string]mirrorService) (map[mcStatsKey]*pb.BasicStats, error) {
+	labels, groupBy := buildMulticlusterStatsRequestLabels(req)
+
+	reqGroupBy := append(model.LabelNames{"classification"}, groupBy...)
+	latencyGroupBy := append(model.LabelNames{"le"}, groupBy...)
+	latencySelectors := mirrorSelectors(mcLatencySelector, labels, req.TimeWindow, mirrors)
+	promQueries := map[promType]string{
+		promRequests: fmt.Sprintf(mcReqQuery, mirrorSelectors(mcReqSelector, labels, req.TimeWindow, mirrors), reqGroupBy.String()),
+	}
+	quantileQueries := map[promType]string{}
+	for _, quantile := range []promType{promLatencyP50, promLatencyP95, promLatencyP99} {
+		quantileQueries[quantile] = fmt.Sprintf(mcLatencyQuantileQuery, quantile, latencySelectors, latencyGroupBy.String())
+	}
+
+	results, err := s.getPrometheusMetrics(ctx, promQueries, quantileQueries)
+	if err != nil {
+		return nil, err
+	}
+	return processMulticlusterStatsMetrics(req, results, mirrors), nil
+}
+
+func processMulticlusterStatsMetrics(req *pb.MulticlusterStatsRequest, results []promResult, mirrors map[string]mirrorService) map[mcStatsKey]*pb.BasicStats {
+	basicStats := make(map[mcStatsKey]*pb.BasicStats)
+
+	for _, result := range results {
+		for _, sample := range result.vec {
+			key := mcStatsKey{
+				fromNamespace:  string(sample.Metric[namespaceLabel]),
+				fromDeployment: string(sample.Metric[deploymentLabel]),
+			}
+			if req.ByService {
+				mirror, ok := mirrors[fmt.Sprintf("%s/%s", sample.Metric[dstNamespaceLabel], sample.Metric[dstServiceLabel])]
+				if !ok {
+					// a service with the same name as a mirror, but
+					// in another namespace
+					continue
+				}
+				key.serviceNamespace, key.serviceName = mirror.remoteNamespace, mirror.remoteName
+			}
+
+			if basicStats[key] == nil {
+				basicStats[key] = &pb.BasicStats{}
+			}
+			value := extractSampleValue(sample)
+
+			switch result.prom {
+			case promRequests:
+				switch string(sample.Metric[model.LabelName("classification")]) {
+				case success:
+					basicStats[key].SuccessCount += value
+				case failure:
+					basicStats[key].FailureCount += value
+				}
+			case promLatencyP50:
+				basicStats[key].LatencyMsP50 = value
+			case promLatencyP95:
+				basicStats[key].LatencyMsP95 = value
+			case promLatencyP99:
+				basicStats[key].LatencyMsP99 = value
+			}
+		}
+	}
+
+	return basicStats
+}
+
+func sortMulticlusterStatsRows(rows []*pb.MulticlusterStatsTable_Row) {
+	sort.Slice(rows, func(i, j int) bool {
+		a := []string{rows[i].ClusterName, rows[i].ServiceNamespace, rows[i].ServiceName, rows[i].FromNamespace, rows[i].FromDeployment}
+		b := []string{rows[j].ClusterName, rows[j].ServiceNamespace, rows[j].ServiceName, rows[j].FromNamespace, rows[j].FromDeployment}
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+)
+
+func TestMulticlusterStats(t *testing.T) {
+	t.Run("Successfully joins request metrics with mirror services", func(t *testing.T) {
+		exp := expectedStatRPC{
+			k8sConfigs: []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-east
+  namespace: emojivoto
+  labels:
+    mirror.linkerd.io/mirrored-service: "true"
+    mirror.linkerd.io/cluster-name: east
+  annotations:
+    mirror.linkerd.io/remote-svc-fq-name: web.emojivoto-remote.svc.cluster.local
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: authors-east
+  namespace: booksapp
+  labels:
+    mirror.linkerd.io/mirrored-service: "true"
+    mirror.linkerd.io/cluster-name: east
+  annotations:
+    mirror.linkerd.io/remote-svc-fq-name: authors.booksapp.svc.cluster.local
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: emojivoto
+`,
+			},
+			mockPromResponse: model.Vector{
+				&model.Sample{
+					Metric: model.Metric{
+						dstNamespaceLabel:                     "emojivoto",
+						dstServiceLabel:                       "web-east",
+						model.LabelName("classification"):     success,
+						model.LabelName("direction"):          "outbound",
+						model.LabelName("unrelated_label"):    "ignored",
+						model.LabelName("dst_target_cluster"): "east",
+					},
+					Value:     123,
+					Timestamp: 456,
+				},
+			},
+			expectedPrometheusQueries: []string{
+				`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="booksapp", dst_service=~"^(authors-east)$"}[1m]) or irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_service=~"^(web-east)$"}[1m])) by (le, dst_namespace, dst_service))`,
+				`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="booksapp", dst_service=~"^(authors-east)$"}[1m]) or irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_service=~"^(web-east)$"}[1m])) by (le, dst_namespace, dst_service))`,
+				`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="booksapp", dst_service=~"^(authors-east)$"}[1m]) or irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_service=~"^(web-east)$"}[1m])) by (le, dst_namespace, dst_service))`,
+				`sum(increase(response_total{direction="outbound", dst_namespace="booksapp", dst_service=~"^(authors-east)$"}[1m]) or increase(response_total{direction="outbound", dst_namespace="emojivoto", dst_service=~"^(web-east)$"}[1m])) by (classification, dst_namespace, dst_service)`,
+			},
+		}
+
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(exp)
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.MulticlusterStats(context.TODO(), &pb.MulticlusterStatsRequest{
+			TimeWindow: "1m",
+			ByService:  true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if err := exp.verifyPromQueries(mockProm); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := &pb.MulticlusterStatsTable{
+			Rows: []*pb.MulticlusterStatsTable_Row{
+				{
+					ClusterName:      "east",
+					ServiceNamespace: "emojivoto-remote",
+					ServiceName:      "web",
+					Stats: &pb.BasicStats{
+						SuccessCount: 123,
+						LatencyMsP50: 123,
+						LatencyMsP95: 123,
+						LatencyMsP99: 123,
+					},
+					TimeWindow: "1m",
+				},
+			},
+		}
+		if !proto.Equal(expected, rsp.GetOk().GetMulticlusterStatsTable()) {
+			t.Fatalf("Expected: %+v\nGot: %+v", expected, rsp.GetOk().GetMulticlusterStatsTable())
+		}
+	})
+	t.Run("Rejects malformed time windows", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		for _, window := range []string{"", "1x", "1m] or vector(1)", "10s"} {
+			_, err := fakeGrpcServer.MulticlusterStats(context.TODO(), &pb.MulticlusterStatsRequest{
+				TimeWindow: window,
+			})
+			if err == nil || !strings.HasPrefix(err.Error(), fmt.Sprintf("invalid time window %q", window)) {
+				t.Fatalf("Expected an invalid time window error for %q, got %v", window, err)
+			}
+		}
+	})
+}
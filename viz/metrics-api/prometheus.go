@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -13,9 +14,13 @@ import (
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 	"github.com/prometheus/common/model"
 	log "github.com/sirupsen/logrus"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// tracerName is the name of the OpenTelemetry tracer of the metrics API
+const tracerName = "github.com/linkerd/linkerd2/viz/metrics-api"
+
 type promType string
 type promResult struct {
 	prom promType
@@ -57,9 +62,9 @@ func extractSampleValue(sample *model.Sample) uint64 {
 func (s *grpcServer) queryProm(ctx context.Context, query string) (model.Vector, error) {
 	log.Debugf("Query request:\n\t%+v", query)
 
-	_, span := trace.StartSpan(ctx, "query.prometheus")
+	_, span := otel.Tracer(tracerName).Start(ctx, "query.prometheus")
 	defer span.End()
-	span.AddAttributes(trace.StringAttribute("queryString", query))
+	span.SetAttributes(attribute.String("queryString", query))
 
 	if s.prometheusAPI == nil {
 		return nil, ErrNoPrometheusInstance
@@ -167,6 +172,23 @@ func generateLabelStringWithRegex(l model.LabelSet, labelName string, stringToMa
 	return fmt.Sprintf("{%s}", strings.Join(lstrs, ", "))
 }
 
+// insert a regex-match check into a LabelSet for labels that are equal to any
+// of the provided values. this is modeled on generateLabelStringWithExclusion().
+func generateLabelStringWithAlternatives(l model.LabelSet, labelName string, values []string) string {
+	lstrs := make([]string, 0, len(l))
+	for l, v := range l {
+		lstrs = append(lstrs, fmt.Sprintf("%s=%q", l, v))
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = regexp.QuoteMeta(v)
+	}
+	lstrs = append(lstrs, fmt.Sprintf(`%s=~"^(%s)$"`, labelName, strings.Join(quoted, "|")))
+
+	sort.Strings(lstrs)
+	return fmt.Sprintf("{%s}", strings.Join(lstrs, ", "))
+}
+
 // generate Prometheus queries for latency quantiles, based on a quantile query
 // template, query labels, a time window and grouping.
 func generateQuantileQueries(quantileQuery, labels, timeWindow, groupBy string) map[promType]string {
@@ -15,14 +15,15 @@ import (
 
 // MockAPIClient satisfies the metrics-api gRPC interfaces
 type MockAPIClient struct {
-	ErrorToReturn                error
-	ListPodsResponseToReturn     *pb.ListPodsResponse
-	ListServicesResponseToReturn *pb.ListServicesResponse
-	StatSummaryResponseToReturn  *pb.StatSummaryResponse
-	GatewaysResponseToReturn     *pb.GatewaysResponse
-	TopRoutesResponseToReturn    *pb.TopRoutesResponse
-	EdgesResponseToReturn        *pb.EdgesResponse
-	SelfCheckResponseToReturn    *pb.SelfCheckResponse
+	ErrorToReturn                     error
+	ListPodsResponseToReturn          *pb.ListPodsResponse
+	ListServicesResponseToReturn      *pb.ListServicesResponse
+	StatSummaryResponseToReturn       *pb.StatSummaryResponse
+	GatewaysResponseToReturn          *pb.GatewaysResponse
+	MulticlusterStatsResponseToReturn *pb.MulticlusterStatsResponse
+	TopRoutesResponseToReturn         *pb.TopRoutesResponse
+	EdgesResponseToReturn             *pb.EdgesResponse
+	SelfCheckResponseToReturn         *pb.SelfCheckResponse
 }
 
 // StatSummary provides a mock of a metrics-api method.
@@ -35,6 +36,11 @@ func (c *MockAPIClient) Gateways(ctx context.Context, in *pb.GatewaysRequest, op
 	return c.GatewaysResponseToReturn, c.ErrorToReturn
 }
 
+// MulticlusterStats provides a mock of a metrics-api method.
+func (c *MockAPIClient) MulticlusterStats(ctx context.Context, in *pb.MulticlusterStatsRequest, opts ...grpc.CallOption) (*pb.MulticlusterStatsResponse, error) {
+	return c.MulticlusterStatsResponseToReturn, c.ErrorToReturn
+}
+
 // TopRoutes provides a mock of a metrics-api method.
 func (c *MockAPIClient) TopRoutes(ctx context.Context, in *pb.TopRoutesRequest, opts ...grpc.CallOption) (*pb.TopRoutesResponse, error) {
 	return c.TopRoutesResponseToReturn, c.ErrorToReturn
@@ -18,11 +18,15 @@ import (
 	"github.com/linkerd/linkerd2/pkg/addr"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/prometheus"
+	"github.com/linkerd/linkerd2/pkg/trace"
 	"github.com/linkerd/linkerd2/pkg/util"
 	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 	vizLabels "github.com/linkerd/linkerd2/viz/pkg/labels"
 	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+	"github.com/linkerd/linkerd2/viz/tap/pkg"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -35,6 +39,9 @@ import (
 const ipIndex = "ip"
 const defaultMaxRps = 100.0
 
+// tracerName is the name of the OpenTelemetry tracer of the tap API
+const tracerName = "github.com/linkerd/linkerd2/viz/tap/api"
+
 // GRPCTapServer describes the gRPC server implementing pb.TapServer
 type GRPCTapServer struct {
 	tapPb.UnimplementedTapServer
@@ -54,7 +61,11 @@ func (s *GRPCTapServer) Tap(req *tapPb.TapRequest, stream tapPb.Tap_TapServer) e
 }
 
 // TapByResource taps all resources matched by the request object.
-func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream tapPb.Tap_TapByResourceServer) error {
+func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream tapPb.Tap_TapByResourceServer) (err error) {
+	ctx, span := otel.Tracer(tracerName).Start(stream.Context(), "tap.TapByResource")
+	endSpan := trace.EndSpanOnce(span)
+	defer func() { endSpan(err) }()
+
 	if req == nil {
 		return status.Error(codes.InvalidArgument, "TapByResource received nil TapByResourceRequest")
 	}
@@ -72,6 +83,12 @@ func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream ta
 	if req.GetMaxRps() == 0.0 {
 		req.MaxRps = defaultMaxRps
 	}
+	span.SetAttributes(
+		attribute.String("tap.type", res.GetType()),
+		attribute.String("tap.namespace", res.GetNamespace()),
+		attribute.String("tap.name", res.GetName()),
+		attribute.Float64("tap.max_rps", float64(req.GetMaxRps())),
+	)
 
 	objects, err := s.k8sAPI.GetObjects(res.GetNamespace(), res.GetType(), res.GetName(), labelSelector)
 	if err != nil {
@@ -121,6 +138,7 @@ func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream ta
 	}
 
 	log.Infof("Tapping %d pods for target: %s", len(pods), res.String())
+	span.SetAttributes(attribute.Int("tap.pods", len(pods)))
 
 	events := make(chan *tapPb.TapEvent)
 
@@ -144,6 +162,22 @@ func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream ta
 		extract = buildExtractHTTP(extractHTTP)
 	}
 
+	traceID, err := traceIDMatch(req.GetMatch())
+	if err != nil {
+		return err
+	}
+	// The trace ID match is evaluated on the request headers, which are
+	// extracted even if the client didn't ask for them.
+	stripHeaders := false
+	if traceID != "" && extract.GetHttp().GetHeaders() == nil {
+		extract = buildExtractHTTP(&tapPb.TapByResourceRequest_Extract_Http{
+			Extract: &tapPb.TapByResourceRequest_Extract_Http_Headers_{
+				Headers: &tapPb.TapByResourceRequest_Extract_Http_Headers{},
+			},
+		})
+		stripHeaders = true
+	}
+
 	for _, pod := range pods {
 		// create the expected pod identity from the pod spec
 		ns := res.GetNamespace()
@@ -154,13 +188,15 @@ func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream ta
 		log.Debugf("initiating tap request to %s with required name %s", pod.Spec.ServiceAccountName, name)
 
 		// pass the header metadata into the request context
-		ctx := stream.Context()
-		ctx = metadata.AppendToOutgoingContext(ctx, pkgK8s.RequireIDHeader, name)
+		ctx := metadata.AppendToOutgoingContext(ctx, pkgK8s.RequireIDHeader, name)
 
 		// initiate a tap on the pod
-		go s.tapProxy(ctx, rpsPerPod, match, extract, pod.Status.PodIP, events)
+		go s.tapProxy(ctx, rpsPerPod, match, extract, newTraceFilter(traceID, stripHeaders), pod.Status.PodIP, events)
 	}
 
+	// The span covers the tap setup, not the lifetime of the stream.
+	endSpan(nil)
+
 	// read events from the taps and send them back
 	for {
 		select {
@@ -236,6 +272,9 @@ func makeByResourceMatch(match *tapPb.TapByResourceRequest_Match) (*proxy.Observ
 						},
 					},
 				}
+			case *tapPb.TapByResourceRequest_Match_Http_TraceId:
+				// evaluated by the tap server, see traceFilter
+				continue
 			default:
 				return nil, status.Errorf(codes.Unimplemented, "unknown HTTP match type: %v", httpTyped)
 			}
@@ -260,6 +299,21 @@ func makeByResourceMatch(match *tapPb.TapByResourceRequest_Match) (*proxy.Observ
 	}, nil
 }
 
+// traceIDMatch returns the normalized trace ID of the first trace ID match of
+// the request, or an empty string if there's none
+func traceIDMatch(match *tapPb.TapByResourceRequest_Match) (string, error) {
+	for _, reqMatch := range match.GetAll().GetMatches() {
+		if traceID := reqMatch.GetHttp().GetTraceId(); traceID != "" {
+			normalized, err := pkg.NormalizeTraceID(traceID)
+			if err != nil {
+				return "", status.Error(codes.InvalidArgument, err.Error())
+			}
+			return normalized, nil
+		}
+	}
+	return "", nil
+}
+
 // TODO: factor out with `promLabels` in public-api
 func destinationLabels(resource *metricsPb.Resource) map[string]string {
 	dstLabels := map[string]string{}
@@ -296,7 +350,7 @@ func buildExtractHTTP(extract *tapPb.TapByResourceRequest_Extract_Http) *proxy.O
 // of maxRps * 1s at most once per 1s window.  If this limit is reached in
 // less than 1s, we sleep until the end of the window before calling Observe
 // again.
-func (s *GRPCTapServer) tapProxy(ctx context.Context, maxRps float32, match *proxy.ObserveRequest_Match, extract *proxy.ObserveRequest_Extract, addr string, events chan *tapPb.TapEvent) {
+func (s *GRPCTapServer) tapProxy(ctx context.Context, maxRps float32, match *proxy.ObserveRequest_Match, extract *proxy.ObserveRequest_Extract, filter *traceFilter, addr string, events chan *tapPb.TapEvent) {
 	tapAddr := fmt.Sprintf("%s:%d", addr, s.tapPort)
 	log.Infof("Establishing tap on %s", tapAddr)
 	conn, err := grpc.DialContext(ctx, tapAddr, grpc.WithInsecure())
@@ -333,6 +387,9 @@ func (s *GRPCTapServer) tapProxy(ctx context.Context, maxRps float32, match *pro
 			}
 
 			translatedEvent := s.translateEvent(ctx, event)
+			if !filter.keep(translatedEvent) {
+				continue
+			}
 
 			select {
 			case <-ctx.Done():
@@ -427,6 +484,8 @@ func (s *GRPCTapServer) translateEvent(ctx context.Context, orig *proxy.TapEvent
 
 		switch orig := orig.GetEvent().(type) {
 		case *proxy.TapEvent_Http_RequestInit_:
+			reqHeaders := headers(orig.RequestInit.GetHeaders())
+			traceID, spanID := pkg.ParseTraceContext(reqHeaders)
 			return &tapPb.TapEvent_Http_{
 				Http: &tapPb.TapEvent_Http{
 					Event: &tapPb.TapEvent_Http_RequestInit_{
@@ -436,7 +495,9 @@ func (s *GRPCTapServer) translateEvent(ctx context.Context, orig *proxy.TapEvent
 							Scheme:    scheme(orig.RequestInit.GetScheme()),
 							Authority: orig.RequestInit.Authority,
 							Path:      orig.RequestInit.Path,
-							Headers:   headers(orig.RequestInit.GetHeaders()),
+							Headers:   reqHeaders,
+							TraceId:   traceID,
+							SpanId:    spanID,
 						},
 					},
 				},
@@ -16,6 +16,8 @@ import (
 	"github.com/linkerd/linkerd2/viz/tap/pkg"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/metadata"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -120,6 +122,12 @@ func (h *handler) handleTap(w http.ResponseWriter, req *http.Request, p httprout
 		return
 	}
 
+	oteltrace.SpanFromContext(req.Context()).SetAttributes(
+		attribute.String("tap.namespace", namespace),
+		attribute.String("tap.resource", resource),
+		attribute.String("tap.name", name),
+	)
+
 	h.log.Debugf("SubjectAccessReview: namespace: %s, resource: %s, name: %s, user: <%s>, group: <%s>",
 		namespace, resource, name, h.usernameHeader, h.groupHeader,
 	)
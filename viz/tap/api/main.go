@@ -26,7 +26,7 @@ func Main(args []string) {
 	tapPort := cmd.Uint("tap-port", 4190, "proxy tap port to connect to")
 	disableCommonNames := cmd.Bool("disable-common-names", false, "disable checks for Common Names (for development)")
 	trustDomain := cmd.String("identity-trust-domain", defaultDomain, "configures the name suffix used for identities")
-	traceCollector := flags.AddTraceFlags(cmd)
+	traceConfig := flags.AddTraceFlags(cmd)
 	flags.ConfigureAndParse(cmd, args)
 	ctx := context.Background()
 	stop := make(chan os.Signal, 1)
@@ -51,8 +51,8 @@ func Main(args []string) {
 		log.Fatalf("Failed to initialize K8s API: %s", err)
 	}
 	log.Infof("Using trust domain: %s", *trustDomain)
-	if *traceCollector != "" {
-		if err := trace.InitializeTracing("linkerd-tap", *traceCollector); err != nil {
+	if traceConfig.Enabled() {
+		if err := trace.InitializeTracing("linkerd-tap", traceConfig); err != nil {
 			log.Warnf("failed to initialize tracing: %s", err)
 		}
 	}
@@ -97,7 +97,7 @@ func NewServer(
 		certValue:    &emptyCert,
 		log:          log,
 	}
-	s.Handler = prometheus.WithTelemetry(s)
+	s.Handler = prometheus.WithTelemetry(s, prometheus.RouterRoute(s.router))
 	httpServer.TLSConfig.GetCertificate = s.getCertificate
 
 	if err := watcher.UpdateCert(s.certValue); err != nil {
@@ -0,0 +1,82 @@
+package api
+
+import (
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+)
+
+type streamKey struct {
+	base   uint32
+	stream uint64
+}
+
+// traceFilter evaluates the trace ID match of a tap request over the events
+// of a single proxy. Proxies can't match on headers, so the requests are
+// matched by the trace ID parsed from their headers, and the response events
+// are matched by the stream of a matched request.
+type traceFilter struct {
+	traceID string
+	// stripHeaders removes the headers and trailers from the events, when
+	// they were extracted only to evaluate the match
+	stripHeaders bool
+	streams      map[streamKey]struct{}
+}
+
+// newTraceFilter returns a filter matching the events of the requests
+// carrying traceID, or nil if traceID is empty
+func newTraceFilter(traceID string, stripHeaders bool) *traceFilter {
+	if traceID == "" {
+		return nil
+	}
+	return &traceFilter{
+		traceID:      traceID,
+		stripHeaders: stripHeaders,
+		streams:      make(map[streamKey]struct{}),
+	}
+}
+
+// keep returns true if the event belongs to a request matched by the filter.
+// A nil filter keeps every event.
+func (f *traceFilter) keep(event *tapPb.TapEvent) bool {
+	if f == nil {
+		return true
+	}
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *tapPb.TapEvent_Http_RequestInit_:
+		if ev.RequestInit.GetTraceId() != f.traceID {
+			return false
+		}
+		f.streams[keyOf(ev.RequestInit.GetId())] = struct{}{}
+		if f.stripHeaders {
+			ev.RequestInit.Headers = nil
+		}
+		return true
+
+	case *tapPb.TapEvent_Http_ResponseInit_:
+		if _, ok := f.streams[keyOf(ev.ResponseInit.GetId())]; !ok {
+			return false
+		}
+		if f.stripHeaders {
+			ev.ResponseInit.Headers = nil
+		}
+		return true
+
+	case *tapPb.TapEvent_Http_ResponseEnd_:
+		key := keyOf(ev.ResponseEnd.GetId())
+		if _, ok := f.streams[key]; !ok {
+			return false
+		}
+		delete(f.streams, key)
+		if f.stripHeaders {
+			ev.ResponseEnd.Trailers = nil
+		}
+		return true
+
+	default:
+		return false
+	}
+}
+
+func keyOf(id *tapPb.TapEvent_Http_StreamId) streamKey {
+	return streamKey{base: id.GetBase(), stream: id.GetStream()}
+}
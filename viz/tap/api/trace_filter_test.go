@@ -0,0 +1,160 @@
+package api
+
+import (
+	"testing"
+
+	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+func requestInit(stream uint64, traceID string) *tapPb.TapEvent {
+	return &tapPb.TapEvent{
+		Event: &tapPb.TapEvent_Http_{
+			Http: &tapPb.TapEvent_Http{
+				Event: &tapPb.TapEvent_Http_RequestInit_{
+					RequestInit: &tapPb.TapEvent_Http_RequestInit{
+						Id:      &tapPb.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+						Headers: &metricsPb.Headers{},
+						TraceId: traceID,
+					},
+				},
+			},
+		},
+	}
+}
+
+func responseInit(stream uint64) *tapPb.TapEvent {
+	return &tapPb.TapEvent{
+		Event: &tapPb.TapEvent_Http_{
+			Http: &tapPb.TapEvent_Http{
+				Event: &tapPb.TapEvent_Http_ResponseInit_{
+					ResponseInit: &tapPb.TapEvent_Http_ResponseInit{
+						Id:      &tapPb.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+						Headers: &metricsPb.Headers{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func responseEnd(stream uint64) *tapPb.TapEvent {
+	return &tapPb.TapEvent{
+		Event: &tapPb.TapEvent_Http_{
+			Http: &tapPb.TapEvent_Http{
+				Event: &tapPb.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &tapPb.TapEvent_Http_ResponseEnd{
+						Id:       &tapPb.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+						Trailers: &metricsPb.Headers{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTraceFilter(t *testing.T) {
+	t.Run("Keeps every event without a trace ID", func(t *testing.T) {
+		filter := newTraceFilter("", false)
+		for _, event := range []*tapPb.TapEvent{requestInit(1, ""), responseInit(1), responseEnd(1)} {
+			if !filter.keep(event) {
+				t.Errorf("Expected event to be kept: %v", event)
+			}
+		}
+	})
+
+	t.Run("Keeps the events of the requests carrying the trace ID", func(t *testing.T) {
+		filter := newTraceFilter(testTraceID, false)
+		events := []struct {
+			event *tapPb.TapEvent
+			keep  bool
+		}{
+			{requestInit(1, testTraceID), true},
+			{requestInit(2, "0000000000000000a3ce929d0e0e4736"), false},
+			{requestInit(3, ""), false},
+			{responseInit(2), false},
+			{responseInit(1), true},
+			{responseEnd(3), false},
+			{responseEnd(1), true},
+			// the stream is forgotten once the response ends
+			{responseEnd(1), false},
+		}
+		for _, e := range events {
+			if keep := filter.keep(e.event); keep != e.keep {
+				t.Errorf("Expected keep to be %t, got %t for event %v", e.keep, keep, e.event)
+			}
+		}
+		if e := requestInit(4, testTraceID); !filter.keep(e) || e.GetHttp().GetRequestInit().GetHeaders() == nil {
+			t.Errorf("Expected the headers to be kept: %v", e)
+		}
+	})
+
+	t.Run("Strips the headers extracted only for the filter", func(t *testing.T) {
+		filter := newTraceFilter(testTraceID, true)
+		events := []*tapPb.TapEvent{requestInit(1, testTraceID), responseInit(1), responseEnd(1)}
+		for _, event := range events {
+			if !filter.keep(event) {
+				t.Fatalf("Expected event to be kept: %v", event)
+			}
+		}
+		if events[0].GetHttp().GetRequestInit().GetHeaders() != nil ||
+			events[1].GetHttp().GetResponseInit().GetHeaders() != nil ||
+			events[2].GetHttp().GetResponseEnd().GetTrailers() != nil {
+			t.Errorf("Expected the headers and trailers to be stripped: %v", events)
+		}
+		if traceID := events[0].GetHttp().GetRequestInit().GetTraceId(); traceID != testTraceID {
+			t.Errorf("Expected the trace ID to be kept, got %q", traceID)
+		}
+	})
+}
+
+func TestTraceIDMatch(t *testing.T) {
+	match := func(traceID string) *tapPb.TapByResourceRequest_Match {
+		return &tapPb.TapByResourceRequest_Match{
+			Match: &tapPb.TapByResourceRequest_Match_All{
+				All: &tapPb.TapByResourceRequest_Match_Seq{
+					Matches: []*tapPb.TapByResourceRequest_Match{
+						{
+							Match: &tapPb.TapByResourceRequest_Match_Http_{
+								Http: &tapPb.TapByResourceRequest_Match_Http{
+									Match: &tapPb.TapByResourceRequest_Match_Http_Path{Path: "/api"},
+								},
+							},
+						},
+						{
+							Match: &tapPb.TapByResourceRequest_Match_Http_{
+								Http: &tapPb.TapByResourceRequest_Match_Http{
+									Match: &tapPb.TapByResourceRequest_Match_Http_TraceId{TraceId: traceID},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	traceID, err := traceIDMatch(match("A3CE929D0E0E4736"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if traceID != "0000000000000000a3ce929d0e0e4736" {
+		t.Errorf("Unexpected trace ID %q", traceID)
+	}
+
+	if _, err := traceIDMatch(match("not-hex")); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected an InvalidArgument error, got %v", err)
+	}
+
+	proxyMatch, err := makeByResourceMatch(match(testTraceID))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if matches := proxyMatch.GetAll().GetMatches(); len(matches) != 1 || matches[0].GetHttp().GetPath() == nil {
+		t.Errorf("Expected only the path match to be sent to the proxy, got %v", matches)
+	}
+}
@@ -37,6 +37,7 @@ type TapRequestParams struct {
 	Method        string
 	Authority     string
 	Path          string
+	TraceID       string
 	Extract       bool
 	LabelSelector string
 }
@@ -97,6 +98,16 @@ func BuildTapByResourceRequest(params TapRequestParams) (*tapPb.TapByResourceReq
 		})
 		matches = append(matches, &match)
 	}
+	if params.TraceID != "" {
+		traceID, err := NormalizeTraceID(params.TraceID)
+		if err != nil {
+			return nil, err
+		}
+		match := buildMatchHTTP(&tapPb.TapByResourceRequest_Match_Http{
+			Match: &tapPb.TapByResourceRequest_Match_Http_TraceId{TraceId: traceID},
+		})
+		matches = append(matches, &match)
+	}
 
 	extract := &tapPb.TapByResourceRequest_Extract{}
 	if params.Extract {
@@ -0,0 +1,111 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+
+	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+const (
+	traceparentHeader = "traceparent"
+	b3Header          = "b3"
+	b3TraceIDHeader   = "x-b3-traceid"
+	b3SpanIDHeader    = "x-b3-spanid"
+
+	traceIDLength = 32
+	spanIDLength  = 16
+)
+
+// ParseTraceContext returns the trace and span IDs carried by the W3C
+// `traceparent` header, the single B3 header or the multiple X-B3-* headers
+// of a request, in that order of precedence. The trace ID is normalized with
+// NormalizeTraceID. Empty strings are returned if none of the headers carry a
+// valid trace context.
+func ParseTraceContext(headers *metricsPb.Headers) (traceID string, spanID string) {
+	values := map[string]string{}
+	for _, header := range headers.GetHeaders() {
+		name := strings.ToLower(header.GetName())
+		if _, ok := values[name]; !ok {
+			values[name] = strings.TrimSpace(header.GetValueStr())
+		}
+	}
+
+	if traceID, spanID, ok := parseTraceparent(values[traceparentHeader]); ok {
+		return traceID, spanID
+	}
+	if traceID, spanID, ok := parseB3(values[b3Header]); ok {
+		return traceID, spanID
+	}
+	if traceID, spanID, ok := parseTraceAndSpanID(values[b3TraceIDHeader], values[b3SpanIDHeader]); ok {
+		return traceID, spanID
+	}
+	return "", ""
+}
+
+// NormalizeTraceID validates a trace ID of up to 32 hex characters, as shown
+// by tracing backends such as Jaeger, and returns it lowercased and
+// left-padded with zeros to 32 characters, so that 64-bit and 128-bit trace
+// IDs compare equal.
+func NormalizeTraceID(traceID string) (string, error) {
+	if traceID == "" || len(traceID) > traceIDLength || !isHex(traceID) {
+		return "", fmt.Errorf("invalid trace ID \"%s\": must be up to %d hex characters", traceID, traceIDLength)
+	}
+	traceID = strings.Repeat("0", traceIDLength-len(traceID)) + strings.ToLower(traceID)
+	if isZero(traceID) {
+		return "", fmt.Errorf("invalid trace ID \"%s\": must not be all zeros", traceID)
+	}
+	return traceID, nil
+}
+
+// parseTraceparent parses a header formatted as
+// `version-traceid-parentid-flags`. Fields appended by future versions are
+// ignored, as required by the W3C Trace Context specification.
+func parseTraceparent(value string) (string, string, bool) {
+	parts := strings.Split(value, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return "", "", false
+	}
+	if len(parts[1]) != traceIDLength {
+		return "", "", false
+	}
+	return parseTraceAndSpanID(parts[1], parts[2])
+}
+
+// parseB3 parses a header formatted as
+// `traceid-spanid[-sampled[-parentspanid]]`. Headers only carrying a sampling
+// decision don't have a trace context.
+func parseB3(value string) (string, string, bool) {
+	parts := strings.Split(value, "-")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	return parseTraceAndSpanID(parts[0], parts[1])
+}
+
+func parseTraceAndSpanID(traceID, spanID string) (string, string, bool) {
+	if len(traceID) != spanIDLength && len(traceID) != traceIDLength {
+		return "", "", false
+	}
+	traceID, err := NormalizeTraceID(traceID)
+	if err != nil {
+		return "", "", false
+	}
+	if len(spanID) != spanIDLength || !isHex(spanID) || isZero(spanID) {
+		return "", "", false
+	}
+	return traceID, strings.ToLower(spanID), true
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
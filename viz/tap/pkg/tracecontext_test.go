@@ -0,0 +1,135 @@
+package pkg
+
+import (
+	"testing"
+
+	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+func headers(kvs ...string) *metricsPb.Headers {
+	hs := &metricsPb.Headers{}
+	for i := 0; i < len(kvs); i += 2 {
+		hs.Headers = append(hs.Headers, &metricsPb.Headers_Header{
+			Name:  kvs[i],
+			Value: &metricsPb.Headers_Header_ValueStr{ValueStr: kvs[i+1]},
+		})
+	}
+	return hs
+}
+
+func TestParseTraceContext(t *testing.T) {
+	testCases := []struct {
+		name    string
+		headers *metricsPb.Headers
+		traceID string
+		spanID  string
+	}{
+		{
+			name:    "no headers",
+			headers: nil,
+		},
+		{
+			name:    "traceparent",
+			headers: headers("traceparent", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00F067AA0BA902B7-01"),
+			traceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			spanID:  "00f067aa0ba902b7",
+		},
+		{
+			name:    "traceparent of a future version with extra fields",
+			headers: headers("traceparent", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"),
+			traceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			spanID:  "00f067aa0ba902b7",
+		},
+		{
+			name:    "invalid traceparent version",
+			headers: headers("traceparent", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"),
+		},
+		{
+			name:    "all-zero traceparent trace ID",
+			headers: headers("traceparent", "00-00000000000000000000000000000000-00f067aa0ba902b7-01"),
+		},
+		{
+			name:    "single b3 header with a 64-bit trace ID",
+			headers: headers("b3", "a3ce929d0e0e4736-00f067aa0ba902b7-1-05e3ac9a4f6e3b90"),
+			traceID: "0000000000000000a3ce929d0e0e4736",
+			spanID:  "00f067aa0ba902b7",
+		},
+		{
+			name:    "single b3 header with only a sampling decision",
+			headers: headers("b3", "0"),
+		},
+		{
+			name:    "multiple b3 headers",
+			headers: headers("X-B3-TraceId", "4bf92f3577b34da6a3ce929d0e0e4736", "X-B3-SpanId", "00f067aa0ba902b7"),
+			traceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			spanID:  "00f067aa0ba902b7",
+		},
+		{
+			name:    "multiple b3 headers without a span ID",
+			headers: headers("x-b3-traceid", "4bf92f3577b34da6a3ce929d0e0e4736"),
+		},
+		{
+			name: "traceparent takes precedence over b3",
+			headers: headers(
+				"b3", "a3ce929d0e0e4736-00f067aa0ba902b7",
+				"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-b7ad6b7169203331-01",
+			),
+			traceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			spanID:  "b7ad6b7169203331",
+		},
+		{
+			name: "b3 is used if traceparent is invalid",
+			headers: headers(
+				"traceparent", "garbage",
+				"b3", "a3ce929d0e0e4736-00f067aa0ba902b7",
+			),
+			traceID: "0000000000000000a3ce929d0e0e4736",
+			spanID:  "00f067aa0ba902b7",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			traceID, spanID := ParseTraceContext(tc.headers)
+			if traceID != tc.traceID {
+				t.Errorf("Expected trace ID %q, got %q", tc.traceID, traceID)
+			}
+			if spanID != tc.spanID {
+				t.Errorf("Expected span ID %q, got %q", tc.spanID, spanID)
+			}
+		})
+	}
+}
+
+func TestNormalizeTraceID(t *testing.T) {
+	testCases := []struct {
+		traceID    string
+		normalized string
+		err        bool
+	}{
+		{traceID: "4BF92F3577B34DA6A3CE929D0E0E4736", normalized: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{traceID: "a3ce929d0e0e4736", normalized: "0000000000000000a3ce929d0e0e4736"},
+		{traceID: "3ce929d0e0e4736", normalized: "000000000000000003ce929d0e0e4736"},
+		{traceID: "", err: true},
+		{traceID: "not-hex", err: true},
+		{traceID: "000", err: true},
+		{traceID: "4bf92f3577b34da6a3ce929d0e0e47360", err: true},
+	}
+
+	for _, tc := range testCases {
+		normalized, err := NormalizeTraceID(tc.traceID)
+		if tc.err {
+			if err == nil {
+				t.Errorf("Expected an error for %q, got %q", tc.traceID, normalized)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %s", tc.traceID, err)
+		}
+		if normalized != tc.normalized {
+			t.Errorf("Expected %q to be normalized to %q, got %q", tc.traceID, tc.normalized, normalized)
+		}
+	}
+}
@@ -38,7 +38,7 @@ func main() {
 	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
 	clusterDomain := cmd.String("cluster-domain", "", "kubernetes cluster domain")
 
-	traceCollector := flags.AddTraceFlags(cmd)
+	traceConfig := flags.AddTraceFlags(cmd)
 
 	flags.ConfigureAndParse(cmd, os.Args[1:])
 	ctx := context.Background()
@@ -81,8 +81,8 @@ func main() {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
-	if *traceCollector != "" {
-		if err := trace.InitializeTracing("web", *traceCollector); err != nil {
+	if traceConfig.Enabled() {
+		if err := trace.InitializeTracing("web", traceConfig); err != nil {
 			log.Warnf("failed to initialize tracing: %s", err)
 		}
 	}
@@ -108,7 +108,7 @@ func NewServer(
 		HandleMethodNotAllowed: false, // disable 405s
 	}
 
-	wrappedServer := prometheus.WithTelemetry(server)
+	wrappedServer := prometheus.WithTelemetry(server, prometheus.RouterRoute(server.router))
 	handler := &handler{
 		apiClient:           apiClient,
 		k8sAPI:              k8sAPI,